@@ -27,18 +27,18 @@ func (ic *IonClient) AddAlias(alias AddAliasOptions, token string) (*aliases.Ali
 
 	b, err := json.Marshal(alias)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshall alias: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshall alias: %w", err)
 	}
 
 	b, err = ic.Post(aliases.AddAliasEndpoint, token, params, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create alias: %v", err.Error())
+		return nil, fmt.Errorf("failed to create alias: %w", err)
 	}
 
 	var a aliases.Alias
 	err = json.Unmarshal(b, &a)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response from create: %v", err.Error())
+		return nil, fmt.Errorf("failed to read response from create: %w", err)
 	}
 
 	return &a, nil
@@ -0,0 +1,143 @@
+package aliases
+
+import (
+	"net/url"
+	"strings"
+)
+
+// FromPURL takes a package URL, such as pkg:npm/%40angular/core@12.0.0, and
+// returns the alias it identifies, using its namespace as the org.  Returns
+// false if the string is not a package URL.
+func FromPURL(purl string) (Alias, bool) {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return Alias{}, false
+	}
+
+	p := strings.TrimPrefix(purl, "pkg:")
+
+	if i := strings.Index(p, "#"); i != -1 {
+		p = p[:i]
+	}
+
+	if i := strings.Index(p, "?"); i != -1 {
+		p = p[:i]
+	}
+
+	var version string
+	if i := strings.LastIndex(p, "@"); i != -1 && i > strings.LastIndex(p, "/") {
+		version = p[i+1:]
+		p = p[:i]
+	}
+
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	if len(segments) < 2 {
+		return Alias{}, false
+	}
+
+	// the first segment is the package type
+	segments = segments[1:]
+
+	for i := range segments {
+		unescaped, err := url.PathUnescape(segments[i])
+		if err == nil {
+			segments[i] = unescaped
+		}
+	}
+
+	unescaped, err := url.PathUnescape(version)
+	if err == nil {
+		version = unescaped
+	}
+
+	return Alias{
+		Org:     strings.Join(segments[:len(segments)-1], "/"),
+		Name:    segments[len(segments)-1],
+		Version: version,
+	}, true
+}
+
+// FromCPE takes a CPE 2.3 formatted string or 2.2 URI and returns the alias it
+// identifies, using its vendor as the org.  Returns false if the string is not
+// a CPE or names no product.
+func FromCPE(cpe string) (Alias, bool) {
+	var fields []string
+
+	switch {
+	case strings.HasPrefix(cpe, "cpe:2.3:"):
+		fields = splitCPE(strings.TrimPrefix(cpe, "cpe:2.3:"))
+	case strings.HasPrefix(cpe, "cpe:/"):
+		for _, f := range strings.Split(strings.TrimPrefix(cpe, "cpe:/"), ":") {
+			unescaped, err := url.PathUnescape(f)
+			if err == nil {
+				f = unescaped
+			}
+
+			fields = append(fields, f)
+		}
+	default:
+		return Alias{}, false
+	}
+
+	// fields are part, vendor, product, version, and so on
+	for len(fields) < 4 {
+		fields = append(fields, "")
+	}
+
+	for i := range fields {
+		if fields[i] == "*" || fields[i] == "-" {
+			fields[i] = ""
+		}
+	}
+
+	if fields[2] == "" {
+		return Alias{}, false
+	}
+
+	return Alias{Org: fields[1], Name: fields[2], Version: fields[3]}, true
+}
+
+// splitCPE splits the fields of a CPE 2.3 formatted string on its unescaped
+// colons, removing the escapes
+func splitCPE(s string) []string {
+	var fields []string
+	var field strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s):
+			i++
+			field.WriteByte(s[i])
+		case s[i] == ':':
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(s[i])
+		}
+	}
+
+	return append(fields, field.String())
+}
+
+// AppendDistinct appends the given aliases to the slice, skipping any that are
+// empty or already present with the same name, org, and version
+func AppendDistinct(as []Alias, more ...Alias) []Alias {
+	for _, a := range more {
+		if a.Name == "" && a.Org == "" && a.Version == "" {
+			continue
+		}
+
+		found := false
+		for _, existing := range as {
+			if existing.Name == a.Name && existing.Org == a.Org && existing.Version == a.Version {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			as = append(as, a)
+		}
+	}
+
+	return as
+}
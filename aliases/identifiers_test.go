@@ -0,0 +1,46 @@
+package aliases
+
+import (
+	"testing"
+
+	"github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestIdentifiers(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Identifiers", func() {
+		g.It("should parse package URLs", func() {
+			a, ok := FromPURL("pkg:maven/org.apache.commons/commons-lang3@3.12.0")
+			Expect(ok).To(BeTrue())
+			Expect(a).To(Equal(Alias{Org: "org.apache.commons", Name: "commons-lang3", Version: "3.12.0"}))
+
+			a, ok = FromPURL("pkg:npm/%40angular/core@12.0.0?package-id=1#lib")
+			Expect(ok).To(BeTrue())
+			Expect(a).To(Equal(Alias{Org: "@angular", Name: "core", Version: "12.0.0"}))
+
+			_, ok = FromPURL("https://example.com")
+			Expect(ok).To(BeFalse())
+		})
+
+		g.It("should parse CPEs", func() {
+			a, ok := FromCPE(`cpe:2.3:a:some\:vendor:product:-:*:*:*:*:*:*:*`)
+			Expect(ok).To(BeTrue())
+			Expect(a).To(Equal(Alias{Org: "some:vendor", Name: "product"}))
+
+			a, ok = FromCPE("cpe:/a:some%20vendor:product:2.0")
+			Expect(ok).To(BeTrue())
+			Expect(a).To(Equal(Alias{Org: "some vendor", Name: "product", Version: "2.0"}))
+
+			_, ok = FromCPE("cpe:2.3:a:vendor")
+			Expect(ok).To(BeFalse())
+		})
+
+		g.It("should append distinct aliases", func() {
+			as := AppendDistinct(nil, Alias{Name: "a"}, Alias{}, Alias{Name: "a"}, Alias{Name: "a", Version: "1"})
+			Expect(as).To(Equal([]Alias{{Name: "a"}, {Name: "a", Version: "1"}}))
+		})
+	})
+}
@@ -22,13 +22,13 @@ func (ic *IonClient) GetAnalysis(id, teamID, projectID, token string) (*analyses
 
 	b, _, err := ic.Get(analyses.AnalysisGetAnalysisEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis: %w", err)
 	}
 
 	var a analyses.Analysis
 	err = json.Unmarshal(b, &a)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal analysis: %w", err)
 	}
 
 	return &a, nil
@@ -44,13 +44,13 @@ func (ic *IonClient) GetLatestAnalysis(teamID, projectID, token string) (*analys
 
 	b, _, err := ic.Get(analyses.AnalysisGetLatestAnalysisEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis: %w", err)
 	}
 
 	var a analyses.Analysis
 	err = json.Unmarshal(b, &a)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal analysis: %w", err)
 	}
 
 	return &a, nil
@@ -65,13 +65,13 @@ func (ic *IonClient) GetAnalyses(teamID, projectID, token string, page *paginati
 
 	b, _, err := ic.Get(analyses.AnalysisGetAnalysesEndpoint, token, params, nil, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analyses: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analyses: %w", err)
 	}
 
 	var as []analyses.Analysis
 	err = json.Unmarshal(b, &as)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal analyses: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal analyses: %w", err)
 	}
 
 	return as, nil
@@ -87,13 +87,13 @@ func (ic *IonClient) GetLatestPublicAnalysis(projectID, branch string) (*analyse
 
 	b, _, err := ic.Get(analyses.AnalysisGetLatestPublicAnalysisEndpoint, "", params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis: %w", err)
 	}
 
 	var a analyses.Analysis
 	err = json.Unmarshal(b, &a)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal analysis: %w", err)
 	}
 
 	return &a, nil
@@ -108,13 +108,13 @@ func (ic *IonClient) GetPublicAnalysis(id string) (*analyses.Analysis, error) {
 
 	b, _, err := ic.Get(analyses.AnalysisGetPublicAnalysisEndpoint, "", params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis: %w", err)
 	}
 
 	var a analyses.Analysis
 	err = json.Unmarshal(b, &a)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal analysis: %w", err)
 	}
 
 	return &a, nil
@@ -130,7 +130,7 @@ func (ic *IonClient) GetRawAnalysis(id, teamID, projectID, token string) (json.R
 
 	b, _, err := ic.Get(analyses.AnalysisGetAnalysisEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis: %w", err)
 	}
 
 	return b, nil
@@ -145,7 +145,7 @@ func (ic *IonClient) GetRawAnalyses(teamID, projectID, token string, page *pagin
 
 	b, _, err := ic.Get(analyses.AnalysisGetAnalysesEndpoint, token, params, nil, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis: %w", err)
 	}
 
 	return b, nil
@@ -162,18 +162,18 @@ func (ic *IonClient) GetLatestAnalysisIDs(teamID string, projectIDs []string, to
 
 	b, err := json.Marshal(ri)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	r, err := ic.Post(analyses.AnalysisGetLatestAnalysisIDsEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get latest analysis IDs: %v", err.Error())
+		return nil, fmt.Errorf("failed to get latest analysis IDs: %w", err)
 	}
 
 	a := make(map[string]string)
 	err = json.Unmarshal(r, &a)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get latest analysis IDs: %v", err.Error())
+		return nil, fmt.Errorf("failed to get latest analysis IDs: %w", err)
 	}
 
 	return &a, nil
@@ -189,13 +189,13 @@ func (ic *IonClient) GetLatestAnalysisSummary(teamID, projectID, token string) (
 
 	b, _, err := ic.Get(analyses.AnalysisGetLatestAnalysisSummaryEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get latest analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to get latest analysis: %w", err)
 	}
 
 	var a analyses.Summary
 	err = json.Unmarshal(b, &a)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get latest analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to get latest analysis: %w", err)
 	}
 
 	return &a, nil
@@ -212,19 +212,19 @@ func (ic *IonClient) GetLatestAnalysisSummaries(teamID string, projectIDs []stri
 
 	b, err := json.Marshal(body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	r, err := ic.Post(analyses.AnalysisGetLatestAnalysisSummariesEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to get latest analysis summaries: %v", err.Error())
+		return nil, fmt.Errorf("failed to get latest analysis summaries: %w", err)
 	}
 
 	var a []analyses.Summary
 	err = json.Unmarshal(r, &a)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get latest analysis summaries: %v", err.Error())
+		return nil, fmt.Errorf("failed to get latest analysis summaries: %w", err)
 	}
 
 	return a, nil
@@ -239,7 +239,7 @@ func (ic *IonClient) GetRawLatestAnalysisSummary(teamID, projectID, token string
 
 	b, _, err := ic.Get(analyses.AnalysisGetLatestAnalysisSummaryEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get latest analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to get latest analysis: %w", err)
 	}
 
 	return b, nil
@@ -255,18 +255,18 @@ func (ic *IonClient) GetAnalysesExportData(teamID string, ids []string, token st
 
 	b, err := json.Marshal(ri)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	r, err := ic.Post(analyses.AnalysisGetAnalysesExportData, token, nil, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project states: %v", err.Error())
+		return nil, fmt.Errorf("failed to get project states: %w", err)
 	}
 
 	var ps []analyses.ExportData
 	err = json.Unmarshal(r, &ps)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return ps, nil
@@ -282,18 +282,18 @@ func (ic *IonClient) GetAnalysesVulnerabilityExportData(teamID string, ids []str
 
 	b, err := json.Marshal(ri)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	r, err := ic.Post(analyses.AnalysisGetAnalysesVulnerabilityExportData, token, nil, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project states: %v", err.Error())
+		return nil, fmt.Errorf("failed to get project states: %w", err)
 	}
 
 	var vulnerabilities []analyses.VulnerabilityExportData
 	err = json.Unmarshal(r, &vulnerabilities)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return vulnerabilities, nil
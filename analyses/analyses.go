@@ -7,6 +7,7 @@ import (
 
 	"github.com/ion-channel/ionic/rulesets"
 	"github.com/ion-channel/ionic/scans"
+	"github.com/ion-channel/ionic/vulnerabilities"
 )
 
 const (
@@ -109,6 +110,17 @@ type VulnerabilityExportData struct {
 	DependencyVersion string             `json:"dependency_version"`
 }
 
+// NormalizedSeverity returns the vulnerability's severity the same way
+// vulnerabilities.Vulnerability does: its given severity, or its score's
+// severity on the CVSS v3 scale if its severity is not one that is known
+func (d VulnerabilityExportData) NormalizedSeverity() vulnerabilities.Severity {
+	if severity := vulnerabilities.ParseSeverity(d.Severity); severity != vulnerabilities.SeverityUnknown {
+		return severity
+	}
+
+	return vulnerabilities.SeverityFromScore(float64(d.Score))
+}
+
 // String returns a JSON formatted string of the analysis object
 func (a Analysis) String() string {
 	b, err := json.Marshal(a)
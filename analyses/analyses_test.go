@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ion-channel/ionic/rulesets"
+	"github.com/ion-channel/ionic/vulnerabilities"
 
 	"github.com/franela/goblin"
 	. "github.com/onsi/gomega"
@@ -44,6 +45,12 @@ func TestAnalysis(t *testing.T) {
 			Expect(s.Risk).To(Equal("high"))
 		})
 
+		g.It("should normalize the severity of exported vulnerabilities", func() {
+			Expect(VulnerabilityExportData{Severity: "HIGH", Score: 9.8}.NormalizedSeverity()).To(Equal(vulnerabilities.SeverityHigh))
+			Expect(VulnerabilityExportData{Severity: "Moderate"}.NormalizedSeverity()).To(Equal(vulnerabilities.SeverityMedium))
+			Expect(VulnerabilityExportData{Score: 9.8}.NormalizedSeverity()).To(Equal(vulnerabilities.SeverityCritical))
+		})
+
 		g.It("should return string in JSON", func() {
 			createdAt := time.Date(2018, 07, 07, 13, 42, 47, 651387237, time.UTC)
 			updatedAt := time.Date(2018, 07, 07, 13, 42, 47, 651387237, time.UTC)
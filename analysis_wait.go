@@ -0,0 +1,209 @@
+package ionic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ion-channel/ionic/analyses"
+	"github.com/ion-channel/ionic/rulesets"
+	"github.com/ion-channel/ionic/scanner"
+)
+
+const (
+	defaultWaitInterval    = 5 * time.Second
+	defaultWaitMaxInterval = time.Minute
+)
+
+var (
+	// ErrAnalysisErrored is returned when an analysis being waited on stops
+	// without finishing, such as when its source cannot be retrieved
+	ErrAnalysisErrored = fmt.Errorf("analysis did not finish")
+)
+
+// WaitOptions represents the settings used while waiting for an analysis to
+// finish.  The zero value polls every 5 seconds with no timeout.
+type WaitOptions struct {
+	// Interval is how long to wait between the first polls of the analysis's
+	// status.  Defaults to 5 seconds.
+	Interval time.Duration
+	// Backoff is the factor the interval is multiplied by after each poll.
+	// Values below 1 keep the interval constant.
+	Backoff float64
+	// MaxInterval caps the interval as it grows.  Defaults to 1 minute.
+	MaxInterval time.Duration
+	// Timeout is how long to wait for the analysis in total.  Zero waits
+	// until the provided context is done.
+	Timeout time.Duration
+	// OnProgress, if set, is called for each change in the status of the
+	// analysis's scans, including when a scan is first seen
+	OnProgress func(ScanTransition)
+}
+
+// ScanTransition represents a change in the status of one of an analysis's
+// scans
+type ScanTransition struct {
+	// Analysis is the status of the analysis the change was seen in
+	Analysis *scanner.AnalysisStatus
+	// Scan is the scan as of the change
+	Scan scanner.ScanStatus
+	// From is the status of the scan before the change, empty if the scan had
+	// not been seen before
+	From string
+}
+
+// AnalysisResult represents the outcome of an analysis that has been waited
+// on
+type AnalysisResult struct {
+	// Status is the last status seen for the analysis
+	Status *scanner.AnalysisStatus
+	// Analysis is the finished analysis, nil if it did not finish
+	Analysis *analyses.Analysis
+	// AppliedRuleset is the evaluation of the project's ruleset against the
+	// finished analysis, nil if it did not finish
+	AppliedRuleset *rulesets.AppliedRulesetSummary
+}
+
+// Passed returns whether the analysis finished and passed its ruleset
+func (r *AnalysisResult) Passed() bool {
+	if r == nil || r.AppliedRuleset == nil || r.AppliedRuleset.RuleEvaluationSummary == nil {
+		return false
+	}
+
+	return r.AppliedRuleset.RuleEvaluationSummary.Passed
+}
+
+// WaitForAnalysis takes a context, analysis ID, team ID, project ID, token, and
+// wait options.  It polls the status of the analysis until it is done, then
+// returns the final status along with the finished analysis and its applied
+// ruleset.  If the analysis errors, the result holds only its status and the
+// returned error matches ErrAnalysisErrored.  If the context is done or the
+// timeout passes first, the result holds the last status seen and the error
+// wraps the context's error.  Nil options use the defaults.
+func (ic *IonClient) WaitForAnalysis(ctx context.Context, analysisID, teamID, projectID, token string, opts *WaitOptions) (*AnalysisResult, error) {
+	o := opts.withDefaults()
+
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	c := ic.WithContext(ctx)
+	result := &AnalysisResult{}
+	scans := make(map[string]string)
+	interval := o.Interval
+
+	for {
+		status, err := c.GetAnalysisStatus(analysisID, teamID, projectID, token)
+		if err != nil {
+			if ctx.Err() != nil {
+				return result, fmt.Errorf("failed waiting for analysis: %w", ctx.Err())
+			}
+
+			return result, fmt.Errorf("failed waiting for analysis: %w", err)
+		}
+
+		result.Status = status
+		progress(o.OnProgress, status, scans)
+
+		if status.Done() {
+			break
+		}
+
+		err = sleep(ctx, interval)
+		if err != nil {
+			return result, fmt.Errorf("failed waiting for analysis: %w", err)
+		}
+
+		interval = o.next(interval)
+	}
+
+	if result.Status.Status != scanner.AnalysisStatusFinished {
+		return result, fmt.Errorf("%w: %v: %v", ErrAnalysisErrored, result.Status.Status, result.Status.Message)
+	}
+
+	a, err := c.GetAnalysis(analysisID, teamID, projectID, token)
+	if err != nil {
+		return result, fmt.Errorf("failed to get finished analysis: %w", err)
+	}
+
+	result.Analysis = a
+
+	applied, err := c.GetAppliedRuleSet(projectID, teamID, analysisID, token)
+	if err != nil {
+		return result, fmt.Errorf("failed to get applied ruleset of finished analysis: %w", err)
+	}
+
+	result.AppliedRuleset = applied
+
+	return result, nil
+}
+
+// withDefaults returns a copy of the options with the defaults filled in
+func (o *WaitOptions) withDefaults() WaitOptions {
+	w := WaitOptions{}
+	if o != nil {
+		w = *o
+	}
+
+	if w.Interval <= 0 {
+		w.Interval = defaultWaitInterval
+	}
+
+	if w.MaxInterval <= 0 {
+		w.MaxInterval = defaultWaitMaxInterval
+	}
+
+	if w.Backoff < 1 {
+		w.Backoff = 1
+	}
+
+	return w
+}
+
+// next returns the interval to wait after the given interval
+func (o *WaitOptions) next(interval time.Duration) time.Duration {
+	interval = time.Duration(float64(interval) * o.Backoff)
+	if interval > o.MaxInterval {
+		interval = o.MaxInterval
+	}
+
+	return interval
+}
+
+// sleep waits for the given duration, returning the context's error if it is
+// done first
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// progress reports the changes in the status of the analysis's scans since
+// they were last seen, recording their new statuses
+func progress(onProgress func(ScanTransition), status *scanner.AnalysisStatus, scans map[string]string) {
+	for _, s := range status.ScanStatus {
+		key := s.ID
+		if key == "" {
+			key = s.Name
+		}
+
+		from, seen := scans[key]
+		if seen && from == s.Status {
+			continue
+		}
+
+		scans[key] = s.Status
+
+		if onProgress != nil {
+			onProgress(ScanTransition{Analysis: status, Scan: s, From: from})
+		}
+	}
+}
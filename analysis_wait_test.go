@@ -0,0 +1,107 @@
+package ionic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic/errors"
+	"github.com/ion-channel/ionic/iontest"
+	"github.com/ion-channel/ionic/projects"
+	"github.com/ion-channel/ionic/rules"
+	"github.com/ion-channel/ionic/rulesets"
+	"github.com/ion-channel/ionic/scanner"
+	. "github.com/onsi/gomega"
+)
+
+func TestWaitForAnalysis(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Waiting for an analysis", func() {
+		var server *iontest.Server
+		var client *IonClient
+		var project projects.Project
+		var opts *WaitOptions
+
+		g.BeforeEach(func() {
+			server = iontest.NewServer()
+			client, _ = New(server.URL)
+
+			rule := server.AddRule(rules.Rule{Name: "somerule", ScanType: "vulnerability"})
+			other := server.AddRule(rules.Rule{Name: "otherrule", ScanType: "license"})
+			rs := server.AddRuleSet(rulesets.RuleSet{TeamID: "someteam", Name: "someruleset", RuleIDs: []string{rule.ID, other.ID}})
+
+			team, name := "someteam", "someproject"
+			project = server.AddProject(projects.Project{TeamID: &team, RulesetID: &rs.ID, Name: &name})
+
+			opts = &WaitOptions{Interval: time.Millisecond}
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+		})
+
+		g.It("should return the finished analysis and its applied ruleset", func() {
+			server.PollsToFinish = 3
+
+			status, _ := client.AnalyzeProject(*project.ID, "someteam", "", "sometoken")
+
+			var transitions []ScanTransition
+			opts.OnProgress = func(t ScanTransition) {
+				transitions = append(transitions, t)
+			}
+
+			result, err := client.WaitForAnalysis(context.Background(), status.ID, "someteam", *project.ID, "sometoken", opts)
+			Expect(err).To(BeNil())
+			Expect(result.Status.Status).To(Equal(scanner.AnalysisStatusFinished))
+			Expect(result.Analysis.ID).To(Equal(status.ID))
+			Expect(result.AppliedRuleset.RulesetName).To(Equal("someruleset"))
+			Expect(result.Passed()).To(BeTrue())
+
+			Expect(server.Hits(scanner.ScannerGetAnalysisStatusEndpoint)).To(Equal(3))
+
+			var vulns []string
+			for _, t := range transitions {
+				if t.Scan.Name == "vulnerability" {
+					vulns = append(vulns, t.From+">"+t.Scan.Status)
+				}
+			}
+			Expect(vulns).To(Equal([]string{">analyzing", "analyzing>finished"}))
+		})
+
+		g.It("should report a failing analysis", func() {
+			server.SetOutcome(*project.ID, iontest.Outcome{FailedRules: []string{"norule"}})
+			status, _ := client.AnalyzeProject(*project.ID, "someteam", "", "sometoken")
+
+			result, err := client.WaitForAnalysis(context.Background(), status.ID, "someteam", *project.ID, "sometoken", opts)
+			Expect(err).To(BeNil())
+			Expect(result.Passed()).To(BeFalse())
+		})
+
+		g.It("should return an error when the analysis errors", func() {
+			server.SetOutcome(*project.ID, iontest.Outcome{Error: "failed to clone"})
+			status, _ := client.AnalyzeProject(*project.ID, "someteam", "", "sometoken")
+
+			result, err := client.WaitForAnalysis(context.Background(), status.ID, "someteam", *project.ID, "sometoken", opts)
+			Expect(err).NotTo(BeNil())
+			Expect(err).To(MatchError(ContainSubstring("failed to clone")))
+			Expect(errors.Is(err, ErrAnalysisErrored)).To(BeTrue())
+			Expect(result.Status.Status).To(Equal(scanner.AnalysisStatusErrored))
+			Expect(result.Analysis).To(BeNil())
+		})
+
+		g.It("should stop waiting once the timeout passes", func() {
+			server.PollsToFinish = 1000
+			status, _ := client.AnalyzeProject(*project.ID, "someteam", "", "sometoken")
+
+			opts.Timeout = 20 * time.Millisecond
+			opts.Backoff = 2
+			result, err := client.WaitForAnalysis(context.Background(), status.ID, "someteam", *project.ID, "sometoken", opts)
+			Expect(err).NotTo(BeNil())
+			Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+			Expect(result.Status.Status).To(Equal(scanner.AnalysisStatusAnalyzing))
+		})
+	})
+}
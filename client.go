@@ -4,6 +4,7 @@ package ionic
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -22,8 +23,15 @@ const (
 
 // IonClient represnets a communication layer with the Ion Channel API
 type IonClient struct {
-	baseURL *url.URL
-	client  *http.Client
+	baseURL   *url.URL
+	client    *http.Client
+	transport *requests.Transport
+	ctx       context.Context
+	tokens    TokenSource
+	headers   http.Header
+	hooks     []requests.Hooks
+
+	pageWorkers int
 }
 
 // New takes the base URL of the API and returns a client for talking to the API
@@ -41,58 +49,284 @@ func New(baseURL string) (*IonClient, error) {
 
 // NewWithClient takes the base URL of the API and an existing HTTP client.  It
 // returns a client for talking to the API and an error if any issues
-// instantiating the client are encountered
+// instantiating the client are encountered.  The provided HTTP client is copied
+// so the client wide policies can be applied without modifying it.
 func NewWithClient(baseURL string, client *http.Client) (*IonClient, error) {
 	u, err := url.Parse(baseURL)
 	if err != nil {
-		return nil, fmt.Errorf("ionic: client initialization: %v", err.Error())
+		return nil, fmt.Errorf("ionic: client initialization: %w", err)
+	}
+
+	if client == nil {
+		client = &http.Client{}
 	}
 
+	t := &requests.Transport{Base: client.Transport}
+	c := *client
+	c.Transport = t
+
 	ic := &IonClient{
-		baseURL: u,
-		client:  client,
+		baseURL:   u,
+		client:    &c,
+		transport: t,
 	}
 
 	return ic, nil
 }
 
+// SetRetryPolicy sets the policy used to retry requests that fail with a
+// transient error, such as a rate limited or bad gateway response.  A nil
+// policy disables retries, which is the default.  It should be set before
+// the client is used.
+func (ic *IonClient) SetRetryPolicy(policy *requests.RetryPolicy) {
+	ic.transport.Retry = policy
+}
+
+// SetRateLimiter sets the limiter used to throttle the rate of requests made
+// by the client, such as a requests.TokenBucket.  Every request is throttled,
+// including each page fetched while paging and each retry attempt.  A nil
+// limiter disables throttling, which is the default.  It should be set before
+// the client is used.
+func (ic *IonClient) SetRateLimiter(limiter requests.Limiter) {
+	ic.transport.Limiter = limiter
+}
+
+// SetMaxInFlight caps the number of requests the client will have in flight
+// at any one time, across all goroutines using it.  A max of zero or less
+// removes the cap, which is the default.  It should be set before the client
+// is used.
+func (ic *IonClient) SetMaxInFlight(max int) {
+	if max <= 0 {
+		ic.transport.InFlight = nil
+		return
+	}
+
+	ic.transport.InFlight = requests.NewInFlightLimit(max)
+}
+
+// WithContext returns a shallow copy of the client with its context set to the
+// provided context.  Every call made through the returned client, including
+// the endpoint wrappers and each page fetched while paging, is bound to the
+// context and will be aborted once it is cancelled or its deadline passes.
+// The original client is left untouched.
+func (ic *IonClient) WithContext(ctx context.Context) *IonClient {
+	if ctx == nil {
+		panic("ionic: nil context")
+	}
+
+	c := *ic
+	c.ctx = ctx
+	return &c
+}
+
+// Context returns the context bound to the client.  If no context has been
+// set, the background context is returned.
+func (ic *IonClient) Context() context.Context {
+	if ic.ctx != nil {
+		return ic.ctx
+	}
+
+	return context.Background()
+}
+
+// SetPageWorkers opts in to fetching pages concurrently when getting all items
+// of a paginated endpoint, which is requested with a pagination limit of zero.
+// Once the first page reports the total count of items, the remaining pages
+// are fetched by up to the given number of workers at once and joined in
+// order.  A value of less than two fetches the pages one after another, which
+// is the default.  It should be set before the client is used.
+func (ic *IonClient) SetPageWorkers(workers int) {
+	ic.pageWorkers = workers
+}
+
+// AddHooks adds hooks that are called around every request made by the
+// client, including each page fetched while paging.  Before request hooks are
+// called in the order they were added and after response hooks in the reverse
+// order, so the first hooks added wrap all others.  They should be added
+// before the client is used.
+func (ic *IonClient) AddHooks(hooks ...requests.Hooks) {
+	// copy the hooks so clients made by WithContext never share them
+	all := make([]requests.Hooks, 0, len(ic.hooks)+len(hooks))
+	all = append(all, ic.hooks...)
+	ic.hooks = append(all, hooks...)
+}
+
+// WrapTransport wraps the RoundTripper used to send requests with the one
+// returned by the provided function, such as a RoundTripper that records
+// tracing spans.  The wrapper sits beneath the client's retry and limit
+// policies, so it sees every attempt, and can find the endpoint of each
+// request with requests.EndpointFromContext.  It should be called before the
+// client is used.
+func (ic *IonClient) WrapTransport(wrap func(http.RoundTripper) http.RoundTripper) {
+	base := ic.transport.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	ic.transport.Base = wrap(base)
+}
+
+// SetTokenSource sets the source of the token used for calls made with an
+// empty token.  A nil source leaves those calls unauthenticated, which is the
+// default.  It should be set before the client is used.
+func (ic *IonClient) SetTokenSource(source TokenSource) {
+	ic.tokens = source
+}
+
+// tokenFor returns the token to use for a call.  When no token is given and the
+// call does not carry its own Authorization header, the token is taken from
+// the client's token source.
+func (ic *IonClient) tokenFor(ctx context.Context, token string, headers http.Header) (string, error) {
+	if token != "" || ic.tokens == nil || headers.Get("Authorization") != "" {
+		return token, nil
+	}
+
+	t, err := ic.tokens.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("ionic: failed to get token: %w", err)
+	}
+
+	return t, nil
+}
+
+// headersFor returns the headers to use for a call, layering the given headers
+// over the client's default headers.
+func (ic *IonClient) headersFor(headers http.Header) http.Header {
+	if len(ic.headers) == 0 {
+		return headers
+	}
+
+	h := ic.headers.Clone()
+	for k, vs := range headers {
+		h[k] = append([]string(nil), vs...)
+	}
+
+	return h
+}
+
 // Delete takes an endpoint, token, params, and headers to pass as a delete call to the
 // API.  It will return a json RawMessage for the response and any errors it
 // encounters with the API.
 func (ic *IonClient) Delete(endpoint, token string, params *url.Values, headers http.Header) (json.RawMessage, error) {
-	return requests.Delete(ic.client, ic.baseURL, endpoint, token, params, headers)
+	return ic.DeleteWithContext(ic.Context(), endpoint, token, params, headers)
+}
+
+// DeleteWithContext performs the same call as Delete, bound to the provided
+// context.
+func (ic *IonClient) DeleteWithContext(ctx context.Context, endpoint, token string, params *url.Values, headers http.Header) (json.RawMessage, error) {
+	headers = ic.headersFor(headers)
+	token, err := ic.tokenFor(ctx, token, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = requests.WithHooks(ctx, ic.hooks...)
+
+	return requests.DeleteWithContext(ctx, ic.client, ic.baseURL, endpoint, token, params, headers)
 }
 
 // Head takes an endpoint, token, params, headers, and pagination params to pass as a
 // head call to the API.  It will return any errors it encounters with the API.
 func (ic *IonClient) Head(endpoint, token string, params *url.Values, headers http.Header, page *pagination.Pagination) error {
-	return requests.Head(ic.client, ic.baseURL, endpoint, token, params, headers, page)
+	return ic.HeadWithContext(ic.Context(), endpoint, token, params, headers, page)
+}
+
+// HeadWithContext performs the same call as Head, bound to the provided
+// context.
+func (ic *IonClient) HeadWithContext(ctx context.Context, endpoint, token string, params *url.Values, headers http.Header, page *pagination.Pagination) error {
+	headers = ic.headersFor(headers)
+	token, err := ic.tokenFor(ctx, token, headers)
+	if err != nil {
+		return err
+	}
+
+	ctx = requests.WithHooks(ctx, ic.hooks...)
+
+	return requests.HeadWithContext(ctx, ic.client, ic.baseURL, endpoint, token, params, headers, page)
 }
 
 // Get takes an endpoint, token, params, headers, and pagination params to pass as a
 // get call to the API.  It will return a json RawMessage for the response and
 // any errors it encounters with the API.
 func (ic *IonClient) Get(endpoint, token string, params *url.Values, headers http.Header, page *pagination.Pagination) (json.RawMessage, *responses.Meta, error) {
-	return requests.Get(ic.client, ic.baseURL, endpoint, token, params, headers, page)
+	return ic.GetWithContext(ic.Context(), endpoint, token, params, headers, page)
+}
+
+// GetWithContext performs the same call as Get, bound to the provided context.
+func (ic *IonClient) GetWithContext(ctx context.Context, endpoint, token string, params *url.Values, headers http.Header, page *pagination.Pagination) (json.RawMessage, *responses.Meta, error) {
+	headers = ic.headersFor(headers)
+	token, err := ic.tokenFor(ctx, token, headers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx = requests.WithHooks(ctx, ic.hooks...)
+
+	if page != nil && page.Limit <= 0 && ic.pageWorkers > 1 {
+		return requests.GetAllWithContext(ctx, ic.client, ic.baseURL, endpoint, token, params, headers, ic.pageWorkers)
+	}
+
+	return requests.GetWithContext(ctx, ic.client, ic.baseURL, endpoint, token, params, headers, page)
 }
 
 // Post takes an endpoint, token, params, payload, and headers to pass as a post call
 // to the API.  It will return a json RawMessage for the response and any errors
 // it encounters with the API.
 func (ic *IonClient) Post(endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header) (json.RawMessage, error) {
-	return requests.Post(ic.client, ic.baseURL, endpoint, token, params, payload, headers)
+	return ic.PostWithContext(ic.Context(), endpoint, token, params, payload, headers)
+}
+
+// PostWithContext performs the same call as Post, bound to the provided
+// context.
+func (ic *IonClient) PostWithContext(ctx context.Context, endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header) (json.RawMessage, error) {
+	headers = ic.headersFor(headers)
+	token, err := ic.tokenFor(ctx, token, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = requests.WithHooks(ctx, ic.hooks...)
+
+	return requests.PostWithContext(ctx, ic.client, ic.baseURL, endpoint, token, params, payload, headers)
 }
 
 // Put takes an endpoint, token, params, payload, and headers to pass as a put call to
 // the API.  It will return a json RawMessage for the response and any errors it
 // encounters with the API.
 func (ic *IonClient) Put(endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header) (json.RawMessage, error) {
-	return requests.Put(ic.client, ic.baseURL, endpoint, token, params, payload, headers)
+	return ic.PutWithContext(ic.Context(), endpoint, token, params, payload, headers)
+}
+
+// PutWithContext performs the same call as Put, bound to the provided context.
+func (ic *IonClient) PutWithContext(ctx context.Context, endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header) (json.RawMessage, error) {
+	headers = ic.headersFor(headers)
+	token, err := ic.tokenFor(ctx, token, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = requests.WithHooks(ctx, ic.hooks...)
+
+	return requests.PutWithContext(ctx, ic.client, ic.baseURL, endpoint, token, params, payload, headers)
 }
 
 // Patch takes an endpoint, token, params, payload, and headers to pass as a patch call to
 // the API.  It will return a json RawMessage for the response and any errors it
 // encounters with the API.
 func (ic *IonClient) Patch(endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header) (json.RawMessage, error) {
-	return requests.Patch(ic.client, ic.baseURL, endpoint, token, params, payload, headers)
+	return ic.PatchWithContext(ic.Context(), endpoint, token, params, payload, headers)
+}
+
+// PatchWithContext performs the same call as Patch, bound to the provided
+// context.
+func (ic *IonClient) PatchWithContext(ctx context.Context, endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header) (json.RawMessage, error) {
+	headers = ic.headersFor(headers)
+	token, err := ic.tokenFor(ctx, token, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = requests.WithHooks(ctx, ic.hooks...)
+
+	return requests.PatchWithContext(ctx, ic.client, ic.baseURL, endpoint, token, params, payload, headers)
 }
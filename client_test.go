@@ -1,11 +1,17 @@
 package ionic
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/franela/goblin"
+	"github.com/gomicro/bogus"
 	"github.com/ion-channel/ionic/pagination"
+	"github.com/ion-channel/ionic/projects"
+	"github.com/ion-channel/ionic/requests"
 	. "github.com/onsi/gomega"
 )
 
@@ -29,9 +35,205 @@ func TestClient(t *testing.T) {
 			Expect(err).NotTo(BeNil())
 			Expect(cli).To(BeNil())
 		})
+
+		g.It("should not modify the provided http client", func() {
+			hc := &http.Client{}
+			cli, err := NewWithClient("http://google.com", hc)
+
+			Expect(err).To(BeNil())
+			Expect(hc.Transport).To(BeNil())
+			Expect(cli.client).NotTo(Equal(hc))
+		})
+
+		g.It("should set a retry policy", func() {
+			cli, _ := New("http://google.com")
+			p := requests.DefaultRetryPolicy()
+
+			cli.SetRetryPolicy(p)
+			Expect(cli.transport.Retry).To(Equal(p))
+		})
+
+		g.It("should set client side limits", func() {
+			cli, _ := New("http://google.com")
+			l := requests.NewTokenBucket(10, 5)
+
+			cli.SetRateLimiter(l)
+			cli.SetMaxInFlight(4)
+			Expect(cli.transport.Limiter).To(Equal(l))
+			Expect(cli.transport.InFlight).NotTo(BeNil())
+
+			cli.SetMaxInFlight(0)
+			Expect(cli.transport.InFlight).To(BeNil())
+		})
+
+		g.It("should set the page workers", func() {
+			cli, _ := New("http://google.com")
+
+			cli.SetPageWorkers(4)
+			Expect(cli.pageWorkers).To(Equal(4))
+		})
+
+		g.It("should default to a background context", func() {
+			cli, _ := New("http://google.com")
+
+			Expect(cli.Context()).To(Equal(context.Background()))
+		})
+
+		g.It("should return a copy of the client bound to a context", func() {
+			cli, _ := New("http://google.com")
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ctxCli := cli.WithContext(ctx)
+			Expect(ctxCli.Context()).To(Equal(ctx))
+			Expect(cli.Context()).To(Equal(context.Background()))
+		})
+
+		g.It("should abort endpoint calls when the context is cancelled", func() {
+			server := bogus.New()
+			defer server.Close()
+			h, p := server.HostPort()
+			cli, _ := New(fmt.Sprintf("http://%v:%v", h, p))
+
+			server.AddPath("/v1/teams/getTeam").
+				SetMethods("GET").
+				SetPayload([]byte(`{"data":{"id":"someteam"}}`)).
+				SetStatus(http.StatusOK)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			team, err := cli.WithContext(ctx).GetTeam("someteam", "sometoken")
+			Expect(err).NotTo(BeNil())
+			Expect(team).To(BeNil())
+			Expect(server.Hits()).To(Equal(0))
+		})
+
+		g.It("should abort project validation when the context is cancelled", func() {
+			server := bogus.New()
+			defer server.Close()
+			h, p := server.HostPort()
+			cli, _ := New(fmt.Sprintf("http://%v:%v", h, p))
+
+			server.AddPath("/v1/ruleset/getRuleset").
+				SetMethods("HEAD").
+				SetStatus(http.StatusOK)
+
+			var project projects.Project
+			Expect(json.Unmarshal([]byte(`{"id":"someproject","team_id":"someteam","ruleset_id":"someruleset","name":"some project","type":"git","source":"git@github.com:ion-channel/ionic.git","branch":"master","description":"a project"}`), &project)).To(BeNil())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			updated, err := cli.WithContext(ctx).UpdateProject(&project, "sometoken")
+			Expect(err).NotTo(BeNil())
+			Expect(updated).To(BeNil())
+			Expect(server.Hits()).To(Equal(0))
+		})
+
+		g.It("should send project validation through the client's hooks and headers", func() {
+			server := bogus.New()
+			defer server.Close()
+			h, p := server.HostPort()
+			cli, _ := NewWithOptions(fmt.Sprintf("http://%v:%v", h, p),
+				WithUserAgent("ionic-test/1.0"),
+				WithHeader("X-Trace", "abc"),
+			)
+
+			server.AddPath("/v1/ruleset/getRuleset").
+				SetMethods("HEAD").
+				SetStatus(http.StatusOK)
+			server.AddPath("/v1/project/updateProject").
+				SetMethods("PUT").
+				SetPayload([]byte(`{"data":{"id":"someproject"}}`)).
+				SetStatus(http.StatusOK)
+
+			var endpoints []string
+			cli.AddHooks(requests.Hooks{
+				BeforeRequest: func(c *requests.CallInfo) { endpoints = append(endpoints, c.Endpoint) },
+			})
+
+			var project projects.Project
+			Expect(json.Unmarshal([]byte(`{"id":"someproject","team_id":"someteam","ruleset_id":"someruleset","name":"some project","type":"git","source":"git@github.com:ion-channel/ionic.git","branch":"master","description":"a project"}`), &project)).To(BeNil())
+
+			_, err := cli.UpdateProject(&project, "sometoken")
+			Expect(err).To(BeNil())
+			Expect(endpoints).To(Equal([]string{"v1/ruleset/getRuleset", "v1/project/updateProject"}))
+
+			hits := server.HitRecords()
+			Expect(hits).To(HaveLen(2))
+			Expect(hits[0].Verb).To(Equal("HEAD"))
+			Expect(hits[0].Header.Get("User-Agent")).To(Equal("ionic-test/1.0"))
+			Expect(hits[0].Header.Get("X-Trace")).To(Equal("abc"))
+			Expect(hits[0].Header.Get("Authorization")).To(Equal("Bearer sometoken"))
+		})
+
+		g.It("should keep the hooks of clients bound to contexts apart", func() {
+			cli, _ := New("http://google.com")
+
+			var called []string
+			hook := func(name string) requests.Hooks {
+				return requests.Hooks{BeforeRequest: func(*requests.CallInfo) { called = append(called, name) }}
+			}
+
+			cli.AddHooks(hook("first"), hook("second"))
+			cli.AddHooks(hook("third"))
+
+			a := cli.WithContext(context.Background())
+			b := cli.WithContext(context.Background())
+			a.AddHooks(hook("a"))
+			b.AddHooks(hook("b"))
+
+			for _, h := range a.hooks {
+				h.BeforeRequest(&requests.CallInfo{})
+			}
+
+			Expect(called).To(Equal([]string{"first", "second", "third", "a"}))
+			Expect(cli.hooks).To(HaveLen(3))
+		})
+
+		g.It("should call hooks and wrapped transports for endpoint calls", func() {
+			server := bogus.New()
+			defer server.Close()
+			h, p := server.HostPort()
+			cli, _ := New(fmt.Sprintf("http://%v:%v", h, p))
+
+			server.AddPath("/v1/teams/getTeam").
+				SetMethods("GET").
+				SetPayload([]byte(`{"data":{"id":"someteam"}}`)).
+				SetStatus(http.StatusOK)
+
+			var calls []requests.CallInfo
+			cli.AddHooks(requests.Hooks{
+				AfterResponse: func(c *requests.CallInfo) { calls = append(calls, *c) },
+			})
+
+			var endpoints []string
+			cli.WrapTransport(func(base http.RoundTripper) http.RoundTripper {
+				return roundTripFunc(func(r *http.Request) (*http.Response, error) {
+					endpoint, _ := requests.EndpointFromContext(r.Context())
+					endpoints = append(endpoints, endpoint)
+					return base.RoundTrip(r)
+				})
+			})
+
+			team, err := cli.GetTeam("someteam", "sometoken")
+			Expect(err).To(BeNil())
+			Expect(team.ID).To(Equal("someteam"))
+			Expect(calls).To(HaveLen(1))
+			Expect(calls[0].Endpoint).To(Equal("v1/teams/getTeam"))
+			Expect(calls[0].Status).To(Equal(http.StatusOK))
+			Expect(endpoints).To(Equal([]string{"v1/teams/getTeam"}))
+		})
 	})
 }
 
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
 var client = &IonClient{
 	baseURL: nil,
 	client:  nil,
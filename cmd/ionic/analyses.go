@@ -0,0 +1,294 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ion-channel/ionic"
+	"github.com/ion-channel/ionic/analyses"
+	"github.com/ion-channel/ionic/projects"
+	"github.com/ion-channel/ionic/scanner"
+)
+
+// analyzeResult represents the outcome of analyzing a single project, as
+// printed by the analyze command
+type analyzeResult struct {
+	ProjectID   string   `json:"project_id"`
+	ProjectName string   `json:"project_name,omitempty"`
+	AnalysisID  string   `json:"analysis_id"`
+	Status      string   `json:"status"`
+	Passed      *bool    `json:"passed,omitempty"`
+	FailedRules []string `json:"failed_rules,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+func runAnalyze(a *app, args []string) error {
+	fs, g := a.flags("analyze")
+	branch := fs.String("branch", "", "branch to analyze instead of the project's")
+	all := fs.Bool("all", false, "analyze and gate every active project of the team")
+	wait := fs.Bool("wait", false, "wait for the analysis and fail if it does not pass")
+	interval := fs.Duration("interval", 5*time.Second, "how often to poll while waiting")
+	timeout := fs.Duration("timeout", 0, "how long to wait in total, 0 for no limit")
+
+	args, err := a.parse(fs, g, args)
+	if err != nil {
+		return err
+	}
+
+	if *all == (len(args) == 1) || len(args) > 1 {
+		return usagef("expected either a project ID or --all")
+	}
+
+	team, err := a.team()
+	if err != nil {
+		return err
+	}
+
+	opts := &ionic.WaitOptions{
+		Interval: *interval,
+		Timeout:  *timeout,
+		OnProgress: func(t ionic.ScanTransition) {
+			fmt.Fprintf(a.stderr, "%v: %v scan %v\n", t.Analysis.ProjectID, t.Scan.Name, t.Scan.Status)
+		},
+	}
+
+	if *all {
+		return analyzeAll(a, team, opts)
+	}
+
+	status, err := a.client.AnalyzeProject(args[0], team, *branch, a.cfg.Token)
+	if err != nil {
+		return err
+	}
+
+	r := analyzeResult{ProjectID: args[0], AnalysisID: status.ID, Status: status.Status}
+	if !*wait {
+		return a.print(r, analyzeTable(r))
+	}
+
+	result, err := a.client.WaitForAnalysis(a.ctx, status.ID, team, args[0], a.cfg.Token, opts)
+	if result != nil && result.Status != nil {
+		r.Status = result.Status.Status
+	}
+
+	if err != nil {
+		r.Error = err.Error()
+		printErr := a.print(r, analyzeTable(r))
+		if printErr != nil {
+			return printErr
+		}
+
+		return err
+	}
+
+	passed := result.Passed()
+	r.Passed = &passed
+	r.FailedRules = failedRules(result)
+
+	err = a.print(r, analyzeTable(r))
+	if err != nil {
+		return err
+	}
+
+	if !passed {
+		return errGateFailed
+	}
+
+	return nil
+}
+
+// analyzeAll analyzes and gates every active project of the team
+func analyzeAll(a *app, team string, opts *ionic.WaitOptions) error {
+	active := true
+	gate, err := a.client.AnalyzeAndGate(a.ctx, team, a.cfg.Token, &projects.Filter{Active: &active}, opts)
+	if gate == nil {
+		return err
+	}
+
+	rs := make([]analyzeResult, 0, len(gate.Projects))
+	for _, p := range gate.Projects {
+		passed := p.Passed
+		r := analyzeResult{
+			ProjectID:   p.ProjectID,
+			ProjectName: p.ProjectName,
+			AnalysisID:  p.AnalysisID,
+			Status:      p.Status,
+			Passed:      &passed,
+			FailedRules: p.FailedRules,
+		}
+
+		if p.Err != nil {
+			r.Error = p.Err.Error()
+		}
+
+		rs = append(rs, r)
+	}
+
+	printErr := a.print(rs, analyzeTable(rs...))
+	if err != nil {
+		return err
+	}
+
+	if printErr != nil {
+		return printErr
+	}
+
+	if !gate.Passed() {
+		return fmt.Errorf("%w: %v of %v projects", errGateFailed, len(gate.Failed()), len(gate.Projects))
+	}
+
+	return nil
+}
+
+// failedRules returns the names of the rules the analysis failed
+func failedRules(result *ionic.AnalysisResult) []string {
+	if result.AppliedRuleset == nil || result.AppliedRuleset.RuleEvaluationSummary == nil {
+		return nil
+	}
+
+	var names []string
+	for _, e := range result.AppliedRuleset.RuleEvaluationSummary.Ruleresults {
+		if e.Passed {
+			continue
+		}
+
+		name := e.Name
+		if name == "" {
+			name = e.RuleID
+		}
+
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func analyzeTable(rs ...analyzeResult) *table {
+	t := &table{headers: []string{"PROJECT ID", "NAME", "ANALYSIS ID", "STATUS", "PASSED", "FAILED RULES", "ERROR"}}
+	for _, r := range rs {
+		passed := ""
+		if r.Passed != nil {
+			passed = strconv.FormatBool(*r.Passed)
+		}
+
+		t.add(r.ProjectID, r.ProjectName, r.AnalysisID, r.Status, passed, strings.Join(r.FailedRules, ", "), r.Error)
+	}
+
+	return t
+}
+
+func runStatus(a *app, args []string) error {
+	fs, g := a.flags("status")
+
+	args, err := a.parse(fs, g, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) < 1 || len(args) > 2 {
+		return usagef("expected a project ID and optional analysis ID")
+	}
+
+	team, err := a.team()
+	if err != nil {
+		return err
+	}
+
+	var status *scanner.AnalysisStatus
+	if len(args) == 2 {
+		status, err = a.client.GetAnalysisStatus(args[1], team, args[0], a.cfg.Token)
+	} else {
+		status, err = a.client.GetLatestAnalysisStatus(team, args[0], a.cfg.Token)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"SCAN", "STATUS", "MESSAGE"}}
+	t.add("analysis "+status.ID, status.Status, status.Message)
+	for _, s := range status.ScanStatus {
+		t.add(s.Name, s.Status, s.Message)
+	}
+
+	return a.print(status, t)
+}
+
+func runReport(a *app, args []string) error {
+	sub, args := subcommand(args)
+
+	switch sub {
+	case "project":
+		return reportProject(a, args)
+	case "analysis":
+		return reportAnalysis(a, args)
+	default:
+		return usagef("unknown report command %q", sub)
+	}
+}
+
+func reportProject(a *app, args []string) error {
+	fs, g := a.flags("report project")
+
+	args, err := a.parse(fs, g, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return usagef("expected a project ID")
+	}
+
+	team, err := a.team()
+	if err != nil {
+		return err
+	}
+
+	report, err := a.client.GetProjectReport(args[0], team, a.cfg.Token)
+	if err != nil {
+		return err
+	}
+
+	return a.print(report, summariesTable(report.AnalysisSummaries...))
+}
+
+func reportAnalysis(a *app, args []string) error {
+	fs, g := a.flags("report analysis")
+
+	args, err := a.parse(fs, g, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 2 {
+		return usagef("expected a project ID and analysis ID")
+	}
+
+	team, err := a.team()
+	if err != nil {
+		return err
+	}
+
+	report, err := a.client.GetAnalysisReport(args[1], team, args[0], a.cfg.Token)
+	if err != nil {
+		return err
+	}
+
+	t := summariesTable()
+	if report.Analysis != nil {
+		t = summariesTable(report.Analysis.Summary)
+	}
+
+	return a.print(report, t)
+}
+
+func summariesTable(ss ...analyses.Summary) *table {
+	t := &table{headers: []string{"ANALYSIS ID", "BRANCH", "STATUS", "PASSED", "RISK", "RULESET", "CREATED"}}
+	for _, s := range ss {
+		t.add(s.ID, s.Branch, s.Status, strconv.FormatBool(s.Passed), s.Risk, s.RulesetName, s.CreatedAt.Format(time.RFC3339))
+	}
+
+	return t
+}
@@ -0,0 +1,420 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ion-channel/ionic/reports"
+	"github.com/ion-channel/ionic/rulesets"
+	"github.com/ion-channel/ionic/tags"
+	"github.com/ion-channel/ionic/teams"
+	"github.com/ion-channel/ionic/vulnerabilities"
+)
+
+const envPassword = "IONCHANNEL_PASSWORD"
+
+// subcommand splits the name of a nested subcommand from its arguments.  The
+// name is empty if the arguments start with a flag or are missing.
+func subcommand(args []string) (string, []string) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return "", args
+	}
+
+	return args[0], args[1:]
+}
+
+func runSBOM(a *app, args []string) error {
+	sub, args := subcommand(args)
+	if sub != "export" {
+		return usagef("unknown sbom command %q", sub)
+	}
+
+	fs, g := a.flags("sbom export")
+	format := fs.String("format", "spdx", "SBOM format: spdx or cyclonedx")
+	serialization := fs.String("serialization", "json", "SBOM encoding: json, xml, or tag-value")
+	specVersion := fs.String("spec-version", "", "version of the format's specification to export")
+	deps := fs.Bool("include-dependencies", false, "include the projects' dependencies")
+	vulns := fs.Bool("include-vulnerabilities", false, "include the vulnerabilities found in the projects")
+	licenses := fs.Bool("include-licenses", false, "include the licenses found in the projects")
+	out := fs.String("out", "", "file to write the SBOM to instead of stdout")
+
+	args, err := a.parse(fs, g, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return usagef("expected one or more project IDs")
+	}
+
+	opts := reports.SBOMExportOptions{
+		IncludeDependencies:    *deps,
+		Serialization:          reports.SBOMSerialization(strings.ToLower(*serialization)),
+		SpecVersion:            *specVersion,
+		IncludeVulnerabilities: *vulns,
+		IncludeLicenses:        *licenses,
+	}
+
+	switch strings.ToLower(*format) {
+	case "spdx":
+		opts.Format = reports.SBOMFormatSPDX
+	case "cyclonedx":
+		opts.Format = reports.SBOMFormatCycloneDX
+	default:
+		return usagef("unknown SBOM format %q", *format)
+	}
+
+	err = opts.Validate()
+	if err != nil {
+		return usagef("%v", err)
+	}
+
+	team, err := a.team()
+	if err != nil {
+		return err
+	}
+
+	sbom, err := a.client.GetSBOM(args, team, opts, a.cfg.Token)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range sbom.Errors {
+		fmt.Fprintf(a.stderr, "%v\n", e)
+	}
+
+	if len(sbom.Content) == 0 && len(sbom.Errors) > 0 {
+		return fmt.Errorf("no SBOM exported")
+	}
+
+	// the SBOM is written as exported, regardless of the output format
+	if *out == "" {
+		_, err = sbom.WriteTo(a.stdout)
+		return err
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return fmt.Errorf("failed to write SBOM: %w", err)
+	}
+
+	_, err = sbom.WriteTo(f)
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write SBOM: %w", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write SBOM: %w", err)
+	}
+
+	return nil
+}
+
+func runVulns(a *app, args []string) error {
+	sub, args := subcommand(args)
+	if sub != "get" {
+		return usagef("unknown vulns command %q", sub)
+	}
+
+	fs, g := a.flags("vulns get")
+	id := fs.String("id", "", "external ID of a single vulnerability, such as a CVE")
+
+	args, err := a.parse(fs, g, args)
+	if err != nil {
+		return err
+	}
+
+	if *id != "" {
+		if len(args) != 0 {
+			return usagef("expected either a product or --id")
+		}
+
+		v, err := a.client.GetVulnerability(*id, a.cfg.Token)
+		if err != nil {
+			return err
+		}
+
+		return a.print(v, vulnsTable(*v))
+	}
+
+	if len(args) < 1 || len(args) > 2 {
+		return usagef("expected a product and optional version")
+	}
+
+	version := ""
+	if len(args) == 2 {
+		version = args[1]
+	}
+
+	vs, err := a.client.GetVulnerabilities(args[0], version, a.cfg.Token, nil)
+	if err != nil {
+		return err
+	}
+
+	if vs == nil {
+		vs = []vulnerabilities.Vulnerability{}
+	}
+
+	return a.print(vs, vulnsTable(vs...))
+}
+
+func vulnsTable(vs ...vulnerabilities.Vulnerability) *table {
+	t := &table{headers: []string{"ID", "SCORE", "VERSION", "TITLE"}}
+	for _, v := range vs {
+		t.add(v.ExternalID, v.Score, v.ScoreVersion, v.Title)
+	}
+
+	return t
+}
+
+func runRuleSets(a *app, args []string) error {
+	sub, args := subcommand(args)
+	if sub != "list" && sub != "get" {
+		return usagef("unknown rulesets command %q", sub)
+	}
+
+	fs, g := a.flags("rulesets " + sub)
+
+	args, err := a.parse(fs, g, args)
+	if err != nil {
+		return err
+	}
+
+	team, err := a.team()
+	if err != nil {
+		return err
+	}
+
+	if sub == "list" {
+		if len(args) != 0 {
+			return usagef("unexpected arguments")
+		}
+
+		rs, err := a.client.GetRuleSets(team, a.cfg.Token, nil)
+		if err != nil {
+			return err
+		}
+
+		if rs == nil {
+			rs = []rulesets.RuleSet{}
+		}
+
+		return a.print(rs, ruleSetsTable(rs...))
+	}
+
+	if len(args) != 1 {
+		return usagef("expected a ruleset ID")
+	}
+
+	rs, err := a.client.GetRuleSet(args[0], team, a.cfg.Token)
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"RULE ID", "NAME", "SCAN TYPE", "DESCRIPTION"}}
+	for _, r := range rs.Rules {
+		t.add(r.ID, r.Name, r.ScanType, r.Description)
+	}
+
+	return a.print(rs, t)
+}
+
+func ruleSetsTable(rs ...rulesets.RuleSet) *table {
+	t := &table{headers: []string{"ID", "NAME", "RULES", "DESCRIPTION"}}
+	for _, r := range rs {
+		t.add(r.ID, r.Name, strconv.Itoa(len(r.RuleIDs)), r.Description)
+	}
+
+	return t
+}
+
+func runTags(a *app, args []string) error {
+	sub, args := subcommand(args)
+	if sub == "" {
+		sub = "list"
+	}
+
+	if sub != "list" && sub != "get" && sub != "create" {
+		return usagef("unknown tags command %q", sub)
+	}
+
+	fs, g := a.flags("tags " + sub)
+	description := fs.String("description", "", "description of the created tag")
+
+	args, err := a.parse(fs, g, args)
+	if err != nil {
+		return err
+	}
+
+	team, err := a.team()
+	if err != nil {
+		return err
+	}
+
+	switch sub {
+	case "get":
+		if len(args) != 1 {
+			return usagef("expected a tag ID")
+		}
+
+		t, err := a.client.GetTag(args[0], team, a.cfg.Token)
+		if err != nil {
+			return err
+		}
+
+		return a.print(t, tagsTable(*t))
+
+	case "create":
+		if len(args) != 1 {
+			return usagef("expected a tag name")
+		}
+
+		t, err := a.client.CreateTag(team, args[0], *description, a.cfg.Token)
+		if err != nil {
+			return err
+		}
+
+		return a.print(t, tagsTable(*t))
+
+	default:
+		if len(args) != 0 {
+			return usagef("unexpected arguments")
+		}
+
+		ts, err := a.client.GetTags(team, a.cfg.Token)
+		if err != nil {
+			return err
+		}
+
+		if ts == nil {
+			ts = []tags.Tag{}
+		}
+
+		return a.print(ts, tagsTable(ts...))
+	}
+}
+
+func tagsTable(ts ...tags.Tag) *table {
+	t := &table{headers: []string{"ID", "NAME", "DESCRIPTION"}}
+	for _, tag := range ts {
+		t.add(tag.ID, tag.Name, tag.Description)
+	}
+
+	return t
+}
+
+func runTeams(a *app, args []string) error {
+	sub, args := subcommand(args)
+	if sub == "" {
+		sub = "list"
+	}
+
+	if sub != "list" && sub != "get" {
+		return usagef("unknown teams command %q", sub)
+	}
+
+	fs, g := a.flags("teams " + sub)
+
+	args, err := a.parse(fs, g, args)
+	if err != nil {
+		return err
+	}
+
+	if sub == "get" {
+		if len(args) != 1 {
+			return usagef("expected a team ID")
+		}
+
+		t, err := a.client.GetTeam(args[0], a.cfg.Token)
+		if err != nil {
+			return err
+		}
+
+		return a.print(t, teamsTable(*t))
+	}
+
+	if len(args) != 0 {
+		return usagef("unexpected arguments")
+	}
+
+	ts, err := a.client.GetTeams(a.cfg.Token)
+	if err != nil {
+		return err
+	}
+
+	if ts == nil {
+		ts = []teams.Team{}
+	}
+
+	return a.print(ts, teamsTable(ts...))
+}
+
+func teamsTable(ts ...teams.Team) *table {
+	t := &table{headers: []string{"ID", "NAME", "CREATED"}}
+	for _, team := range ts {
+		t.add(team.ID, team.Name, team.CreatedAt.Format(time.RFC3339))
+	}
+
+	return t
+}
+
+// runLogin logs in with a username and password, printing the session's
+// bearer token or saving it to the config file.  The password is read from
+// the environment, or from the first line of stdin.
+func runLogin(a *app, args []string) error {
+	fs, g := a.flags("login")
+	username := fs.String("username", "", "username to log in as")
+	save := fs.Bool("save", false, "save the token to the config file instead of printing it")
+
+	args, err := a.parse(fs, g, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 0 || *username == "" {
+		return usagef("a username is required, set --username")
+	}
+
+	password := a.getenv(envPassword)
+	if password == "" {
+		line, err := bufio.NewReader(a.stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	session, err := a.client.Login(*username, password)
+	if err != nil {
+		return err
+	}
+
+	if !*save {
+		t := &table{headers: []string{"USER", "TOKEN"}}
+		t.add(session.User.Email, session.BearerToken)
+		return a.print(session, t)
+	}
+
+	c, err := readConfig(a.configPath, false)
+	if err != nil {
+		return err
+	}
+
+	c.Token = session.BearerToken
+	err = writeConfig(a.configPath, c)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stderr, "saved token for %v to %v\n", *username, a.configPath)
+
+	return nil
+}
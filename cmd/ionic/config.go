@@ -0,0 +1,215 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ion-channel/ionic"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	defaultEndpoint = "https://api.ionchannel.io"
+
+	envConfig   = "IONCHANNEL_CONFIG"
+	envEndpoint = "IONCHANNEL_ENDPOINT_URL"
+	envTeam     = "IONCHANNEL_TEAM_ID"
+	envOutput   = "IONCHANNEL_OUTPUT"
+)
+
+// config represents the settings of the tool, as stored in its config file
+type config struct {
+	Endpoint string `yaml:"endpoint,omitempty"`
+	Token    string `yaml:"token,omitempty"`
+	Team     string `yaml:"team,omitempty"`
+	Output   string `yaml:"output,omitempty"`
+}
+
+// overlay returns the config with any non-empty settings of the other config
+// taking precedence
+func (c config) overlay(other config) config {
+	if other.Endpoint != "" {
+		c.Endpoint = other.Endpoint
+	}
+
+	if other.Token != "" {
+		c.Token = other.Token
+	}
+
+	if other.Team != "" {
+		c.Team = other.Team
+	}
+
+	if other.Output != "" {
+		c.Output = other.Output
+	}
+
+	return c
+}
+
+// globals holds the values of the flags shared by every subcommand
+type globals struct {
+	config string
+	flags  config
+}
+
+// flags returns a flag set for the named subcommand with the global flags
+// registered on it
+func (a *app) flags(name string) (*flag.FlagSet, *globals) {
+	fs := flag.NewFlagSet("ionic "+name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+
+	g := &globals{}
+	fs.StringVar(&g.config, "config", "", "config file")
+	fs.StringVar(&g.flags.Endpoint, "endpoint", "", "API endpoint")
+	fs.StringVar(&g.flags.Token, "token", "", "API token")
+	fs.StringVar(&g.flags.Team, "team", "", "team ID")
+	fs.StringVar(&g.flags.Output, "o", "", "output format")
+	fs.StringVar(&g.flags.Output, "output", "", "output format")
+
+	return fs, g
+}
+
+// parse parses the subcommand's arguments and loads its settings, returning
+// the positional arguments.  Flags may come before or after the positional
+// arguments.
+func (a *app) parse(fs *flag.FlagSet, g *globals, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		err := fs.Parse(args)
+		if err != nil {
+			if err == flag.ErrHelp {
+				return nil, usagef("help requested")
+			}
+
+			return nil, usageError{err.Error()}
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	err := a.load(g)
+	if err != nil {
+		return nil, err
+	}
+
+	return positional, nil
+}
+
+// load builds the tool's settings from the defaults, config file, environment,
+// and flags, in increasing order of precedence, then creates the client.  The
+// config file must exist only if it was given with the --config flag.
+func (a *app) load(g *globals) error {
+	a.configPath = g.config
+	explicit := a.configPath != ""
+
+	if a.configPath == "" {
+		a.configPath = a.getenv(envConfig)
+	}
+
+	if a.configPath == "" {
+		dir, err := os.UserConfigDir()
+		if err == nil {
+			a.configPath = filepath.Join(dir, "ionic", "config.yaml")
+		}
+	}
+
+	file, err := readConfig(a.configPath, explicit)
+	if err != nil {
+		return err
+	}
+
+	env := config{
+		Endpoint: a.getenv(envEndpoint),
+		Token:    a.getenv(ionic.DefaultTokenEnvVar),
+		Team:     a.getenv(envTeam),
+		Output:   a.getenv(envOutput),
+	}
+
+	a.cfg = config{Endpoint: defaultEndpoint, Output: outputTable}.
+		overlay(file).
+		overlay(env).
+		overlay(g.flags)
+
+	if !validOutput(a.cfg.Output) {
+		return usagef("unknown output format %q", a.cfg.Output)
+	}
+
+	a.client, err = ionic.NewWithOptions(a.cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	a.client = a.client.WithContext(a.ctx)
+
+	return nil
+}
+
+// team returns the configured team ID, or an error if there is none
+func (a *app) team() (string, error) {
+	if a.cfg.Team == "" {
+		return "", usagef("a team is required, set --team or $%v", envTeam)
+	}
+
+	return a.cfg.Team, nil
+}
+
+// readConfig reads the config file at the given path.  A missing file is only
+// an error if the file was explicitly requested.
+func readConfig(path string, required bool) (config, error) {
+	var c config
+	if path == "" {
+		return c, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !required {
+			return c, nil
+		}
+
+		return c, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	err = yaml.Unmarshal(b, &c)
+	if err != nil {
+		return c, fmt.Errorf("failed to parse config file %v: %w", path, err)
+	}
+
+	return c, nil
+}
+
+// writeConfig writes the config to the given path, creating its directory if
+// needed.  The file is only readable by the user since it may hold a token.
+func writeConfig(path string, c config) error {
+	if path == "" {
+		return fmt.Errorf("no config file path available, set --config or $%v", envConfig)
+	}
+
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	err = ioutil.WriteFile(path, b, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
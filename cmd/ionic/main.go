@@ -0,0 +1,177 @@
+// Command ionic is a command-line interface to the Ion Channel API.  It wraps
+// the ionic client library with subcommands for managing projects, running
+// and gating analyses, and exporting reports, SBOMs, and vulnerabilities.
+//
+// Settings are read from flags, then the environment, then a YAML config file.
+// The exit code reflects the outcome so the tool can gate CI pipelines:
+//
+//	0  success
+//	1  general error
+//	2  usage error
+//	3  an analysis did not pass its ruleset
+//	4  authentication or authorization failure
+//	5  resource not found
+//	6  timed out waiting for an analysis
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ion-channel/ionic"
+	"github.com/ion-channel/ionic/errors"
+)
+
+const (
+	exitOK = iota
+	exitError
+	exitUsage
+	exitGateFailed
+	exitAuth
+	exitNotFound
+	exitTimeout
+)
+
+var (
+	// errGateFailed is returned by commands when an analysis finished but did
+	// not pass its ruleset
+	errGateFailed = fmt.Errorf("analysis did not pass")
+)
+
+// usageError represents a problem with how the tool was invoked
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, a ...interface{}) error {
+	return usageError{fmt.Sprintf(format, a...)}
+}
+
+// command represents a top level subcommand of the tool
+type command struct {
+	usage   string
+	summary string
+	run     func(a *app, args []string) error
+}
+
+var commands = map[string]command{
+	"projects": {"projects list|get|create|update", "manage the team's projects", runProjects},
+	"analyze":  {"analyze PROJECT_ID | --all", "analyze projects, optionally gating on the results", runAnalyze},
+	"status":   {"status PROJECT_ID [ANALYSIS_ID]", "show the status of a project's analysis", runStatus},
+	"report":   {"report project|analysis", "show project and analysis reports", runReport},
+	"sbom":     {"sbom export PROJECT_ID...", "export SBOMs for projects", runSBOM},
+	"vulns":    {"vulns get PRODUCT [VERSION] | --id ID", "look up vulnerabilities", runVulns},
+	"rulesets": {"rulesets list|get", "show the team's rulesets", runRuleSets},
+	"tags":     {"tags [list|get|create]", "manage the team's tags", runTags},
+	"teams":    {"teams [list|get]", "show the teams available to the token", runTeams},
+	"login":    {"login --username USER", "log in and print or save a bearer token", runLogin},
+}
+
+// app holds the state shared by the subcommands of a single invocation
+type app struct {
+	ctx    context.Context
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+
+	cfg        config
+	configPath string
+	client     *ionic.IonClient
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr, os.Getenv))
+}
+
+// run executes the tool with the given arguments and environment, returning
+// the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer, getenv func(string) string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return exitUsage
+		}
+
+		return exitOK
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "ionic: unknown command %q\n\n", args[0])
+		usage(stderr)
+		return exitUsage
+	}
+
+	a := &app{
+		ctx:    context.Background(),
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+		getenv: getenv,
+	}
+
+	err := cmd.run(a, args[1:])
+	if err != nil {
+		fmt.Fprintf(stderr, "ionic: %v\n", err)
+
+		var ue usageError
+		if errors.As(err, &ue) {
+			fmt.Fprintf(stderr, "usage: ionic %v\n", cmd.usage)
+		}
+	}
+
+	return exitCode(err)
+}
+
+// exitCode maps an error returned by a command to the tool's exit code
+func exitCode(err error) int {
+	var ue usageError
+
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &ue):
+		return exitUsage
+	case errors.Is(err, errGateFailed):
+		return exitGateFailed
+	case errors.IsUnauthorized(err), errors.IsForbidden(err):
+		return exitAuth
+	case errors.IsNotFound(err):
+		return exitNotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		return exitTimeout
+	default:
+		return exitError
+	}
+}
+
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "usage: ionic <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10v %v\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "global flags:")
+	fmt.Fprintln(w, "  --config FILE     config file ($"+envConfig+")")
+	fmt.Fprintln(w, "  --endpoint URL    API endpoint ($"+envEndpoint+")")
+	fmt.Fprintln(w, "  --token TOKEN     API token ($"+ionic.DefaultTokenEnvVar+")")
+	fmt.Fprintln(w, "  --team ID         team ID ($"+envTeam+")")
+	fmt.Fprintln(w, "  -o FORMAT         output format: "+strings.Join(outputFormats, ", ")+" ($"+envOutput+")")
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic/iontest"
+	"github.com/ion-channel/ionic/projects"
+	"github.com/ion-channel/ionic/rules"
+	"github.com/ion-channel/ionic/rulesets"
+	. "github.com/onsi/gomega"
+)
+
+func TestCLI(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("ionic", func() {
+		var server *iontest.Server
+		var dir string
+		var env map[string]string
+		var stdin *bytes.Buffer
+		var stdout, stderr *bytes.Buffer
+		var passing, failing projects.Project
+
+		g.BeforeEach(func() {
+			server = iontest.NewServer()
+			server.Token = "sometoken"
+			server.PollsToFinish = 1
+
+			rule := server.AddRule(rules.Rule{Name: "No critical vulnerabilities", ScanType: "vulnerability"})
+			rs := server.AddRuleSet(rulesets.RuleSet{TeamID: "someteam", Name: "someruleset", RuleIDs: []string{rule.ID}})
+
+			team, typ := "someteam", "git"
+			for _, name := range []string{"passing", "failing"} {
+				n := name
+				p := server.AddProject(projects.Project{TeamID: &team, RulesetID: &rs.ID, Name: &n, Type: &typ, Active: true})
+				if name == "passing" {
+					passing = p
+				} else {
+					failing = p
+				}
+			}
+
+			server.SetOutcome(*failing.ID, iontest.Outcome{FailedRules: []string{rule.ID}})
+
+			dir, _ = ioutil.TempDir("", "ionic")
+			env = map[string]string{
+				envConfig:               filepath.Join(dir, "config.yaml"),
+				envEndpoint:             server.URL,
+				envTeam:                 "someteam",
+				"IONCHANNEL_SECRET_KEY": "sometoken",
+			}
+
+			stdin = &bytes.Buffer{}
+			stdout = &bytes.Buffer{}
+			stderr = &bytes.Buffer{}
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+			os.RemoveAll(dir)
+		})
+
+		ionic := func(args ...string) int {
+			stdout.Reset()
+			stderr.Reset()
+			return run(args, stdin, stdout, stderr, func(key string) string { return env[key] })
+		}
+
+		g.It("should list projects as a table", func() {
+			Expect(ionic("projects", "list")).To(Equal(exitOK))
+
+			lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+			Expect(lines).To(HaveLen(3))
+			Expect(lines[0]).To(HavePrefix("ID"))
+			Expect(lines[1]).To(ContainSubstring("passing"))
+			Expect(lines[2]).To(ContainSubstring("failing"))
+		})
+
+		g.It("should print JSON and YAML", func() {
+			Expect(ionic("projects", "get", *passing.ID, "-o", "json")).To(Equal(exitOK))
+
+			var p projects.Project
+			Expect(json.Unmarshal(stdout.Bytes(), &p)).To(Succeed())
+			Expect(*p.Name).To(Equal("passing"))
+
+			env[envOutput] = "yaml"
+			Expect(ionic("projects", "get", *passing.ID)).To(Equal(exitOK))
+			Expect(stdout.String()).To(ContainSubstring("name: passing\n"))
+		})
+
+		g.It("should read settings from the config file", func() {
+			delete(env, envTeam)
+			Expect(writeConfig(env[envConfig], config{Team: "someteam", Output: "json"})).To(Succeed())
+
+			Expect(ionic("rulesets", "list")).To(Equal(exitOK))
+			Expect(stdout.String()).To(ContainSubstring(`"name": "someruleset"`))
+		})
+
+		g.It("should create and update projects from files", func() {
+			file := filepath.Join(dir, "project.yaml")
+			ioutil.WriteFile(file, []byte("name: created\ntype: git\nsource: git@github.com:ion-channel/ionic.git\nbranch: master\ndescription: a project\nruleset_id: "+*passing.RulesetID+"\n"), 0644)
+
+			Expect(ionic("projects", "create", "-f", file, "-o", "json")).To(Equal(exitOK))
+
+			var p projects.Project
+			Expect(json.Unmarshal(stdout.Bytes(), &p)).To(Succeed())
+			Expect(*p.Name).To(Equal("created"))
+
+			stdin.WriteString(`{"description": "an updated project"}`)
+			Expect(ionic("projects", "update", *p.ID, "-f", "-", "-o", "json")).To(Equal(exitOK))
+			Expect(json.Unmarshal(stdout.Bytes(), &p)).To(Succeed())
+			Expect(*p.Name).To(Equal("created"))
+			Expect(*p.Description).To(Equal("an updated project"))
+		})
+
+		g.It("should exit cleanly when a waited analysis passes", func() {
+			Expect(ionic("analyze", *passing.ID, "--wait", "--interval", "1ms")).To(Equal(exitOK))
+			Expect(stdout.String()).To(ContainSubstring("finished"))
+			Expect(stderr.String()).To(ContainSubstring("vulnerability scan"))
+		})
+
+		g.It("should gate on a waited analysis that fails", func() {
+			Expect(ionic("analyze", *failing.ID, "--wait", "--interval", "1ms")).To(Equal(exitGateFailed))
+			Expect(stdout.String()).To(ContainSubstring("No critical vulnerabilities"))
+		})
+
+		g.It("should gate every project of the team", func() {
+			Expect(ionic("analyze", "--all", "--interval", "1ms", "-o", "json")).To(Equal(exitGateFailed))
+
+			var rs []analyzeResult
+			Expect(json.Unmarshal(stdout.Bytes(), &rs)).To(Succeed())
+			Expect(rs).To(HaveLen(2))
+			Expect(*rs[0].Passed).To(BeTrue())
+			Expect(*rs[1].Passed).To(BeFalse())
+		})
+
+		g.It("should show the status of the latest analysis", func() {
+			Expect(ionic("analyze", *passing.ID)).To(Equal(exitOK))
+			Expect(ionic("status", *passing.ID)).To(Equal(exitOK))
+			Expect(stdout.String()).To(ContainSubstring("vulnerability"))
+		})
+
+		g.It("should use meaningful exit codes", func() {
+			Expect(ionic()).To(Equal(exitUsage))
+			Expect(ionic("bogus")).To(Equal(exitUsage))
+			Expect(ionic("projects", "get")).To(Equal(exitUsage))
+			Expect(ionic("projects", "list", "-o", "xml")).To(Equal(exitUsage))
+			Expect(ionic("sbom", "export", "--format", "cyclonedx", "--serialization", "tag-value", "p1")).To(Equal(exitUsage))
+			Expect(ionic("projects", "get", "missing")).To(Equal(exitNotFound))
+
+			env["IONCHANNEL_SECRET_KEY"] = "wrongtoken"
+			Expect(ionic("teams")).To(Equal(exitAuth))
+		})
+	})
+}
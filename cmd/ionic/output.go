@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+var outputFormats = []string{outputTable, outputJSON, outputYAML}
+
+func validOutput(format string) bool {
+	for _, f := range outputFormats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
+
+// table represents the rows of a value as printed in the table output format
+type table struct {
+	headers []string
+	rows    [][]string
+}
+
+func (t *table) add(row ...string) {
+	t.rows = append(t.rows, row)
+}
+
+// print writes the value in the configured output format.  The table is used
+// for the table format and the value itself for the others.
+func (a *app) print(v interface{}, t *table) error {
+	switch a.cfg.Output {
+	case outputJSON:
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+
+	case outputYAML:
+		b, err := toYAML(v)
+		if err != nil {
+			return err
+		}
+
+		_, err = a.stdout.Write(b)
+		return err
+
+	default:
+		w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, strings.Join(t.headers, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+
+		return w.Flush()
+	}
+}
+
+// toYAML marshals the value to YAML by way of its JSON form, so the keys match
+// the JSON field names used throughout the API
+func toYAML(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var generic interface{}
+	err = dec.Decode(&generic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output: %w", err)
+	}
+
+	b, err = yaml.Marshal(numbers(generic))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output: %w", err)
+	}
+
+	return b, nil
+}
+
+// numbers replaces the JSON numbers within the value with integers or floats
+// so they are not written as strings
+func numbers(v interface{}) interface{} {
+	switch t := v.(type) {
+	case json.Number:
+		i, err := t.Int64()
+		if err == nil {
+			return i
+		}
+
+		f, _ := t.Float64()
+		return f
+
+	case map[string]interface{}:
+		for k, e := range t {
+			t[k] = numbers(e)
+		}
+
+	case []interface{}:
+		for i, e := range t {
+			t[i] = numbers(e)
+		}
+	}
+
+	return v
+}
+
+// readResource reads a JSON or YAML document into the value.  The document is
+// read from stdin if the path is "-", and treated as YAML if the path has a
+// .yaml or .yml extension.
+func (a *app) readResource(path string, v interface{}) error {
+	var b []byte
+	var err error
+
+	if path == "-" {
+		b, err = ioutil.ReadAll(a.stdin)
+	} else {
+		b, err = ioutil.ReadFile(path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read %v: %w", path, err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".yaml" || ext == ".yml" {
+		var generic interface{}
+		err = yaml.Unmarshal(b, &generic)
+		if err != nil {
+			return fmt.Errorf("failed to parse %v: %w", path, err)
+		}
+
+		b, err = json.Marshal(stringKeys(generic))
+		if err != nil {
+			return fmt.Errorf("failed to parse %v: %w", path, err)
+		}
+	}
+
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		return fmt.Errorf("failed to parse %v: %w", path, err)
+	}
+
+	return nil
+}
+
+// stringKeys converts the maps decoded from YAML to maps with string keys so
+// they can be marshalled to JSON
+func stringKeys(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[fmt.Sprint(k)] = stringKeys(e)
+		}
+		return m
+
+	case []interface{}:
+		for i, e := range t {
+			t[i] = stringKeys(e)
+		}
+	}
+
+	return v
+}
+
+// deref returns the string pointed to, or an empty string for nil
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
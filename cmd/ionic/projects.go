@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ion-channel/ionic/projects"
+)
+
+func runProjects(a *app, args []string) error {
+	sub, args := subcommand(args)
+
+	switch sub {
+	case "list":
+		return projectsList(a, args)
+	case "get":
+		return projectsGet(a, args)
+	case "create":
+		return projectsCreate(a, args)
+	case "update":
+		return projectsUpdate(a, args)
+	default:
+		return usagef("unknown projects command %q", sub)
+	}
+}
+
+func projectsList(a *app, args []string) error {
+	fs, g := a.flags("projects list")
+	active := fs.String("active", "", "only list active (true) or inactive (false) projects")
+	typ := fs.String("type", "", "only list projects of the given type")
+
+	_, err := a.parse(fs, g, args)
+	if err != nil {
+		return err
+	}
+
+	team, err := a.team()
+	if err != nil {
+		return err
+	}
+
+	var filter *projects.Filter
+	if *active != "" || *typ != "" {
+		filter = &projects.Filter{}
+	}
+
+	if *active != "" {
+		b, err := strconv.ParseBool(*active)
+		if err != nil {
+			return usagef("invalid value for --active: %q", *active)
+		}
+		filter.Active = &b
+	}
+
+	if *typ != "" {
+		filter.Type = typ
+	}
+
+	var ps []projects.Project
+	it := a.client.IterateProjects(team, a.cfg.Token, nil, filter)
+	for it.Next() {
+		ps = append(ps, it.Value())
+	}
+
+	if it.Err() != nil {
+		return fmt.Errorf("failed to list projects: %w", it.Err())
+	}
+
+	if ps == nil {
+		ps = []projects.Project{}
+	}
+
+	return a.print(ps, projectsTable(ps...))
+}
+
+func projectsGet(a *app, args []string) error {
+	fs, g := a.flags("projects get")
+
+	args, err := a.parse(fs, g, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return usagef("expected a project ID")
+	}
+
+	team, err := a.team()
+	if err != nil {
+		return err
+	}
+
+	p, err := a.client.GetProject(args[0], team, a.cfg.Token)
+	if err != nil {
+		return err
+	}
+
+	return a.print(p, projectsTable(*p))
+}
+
+func projectsCreate(a *app, args []string) error {
+	fs, g := a.flags("projects create")
+	file := fs.String("f", "", "JSON or YAML file describing the project, - for stdin")
+
+	_, err := a.parse(fs, g, args)
+	if err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return usagef("a project file is required, set -f")
+	}
+
+	team, err := a.team()
+	if err != nil {
+		return err
+	}
+
+	var p projects.Project
+	err = a.readResource(*file, &p)
+	if err != nil {
+		return err
+	}
+
+	if p.TeamID == nil {
+		p.TeamID = &team
+	}
+
+	created, err := a.client.CreateProject(&p, team, a.cfg.Token)
+	if err != nil {
+		return err
+	}
+
+	return a.print(created, projectsTable(*created))
+}
+
+// projectsUpdate applies the fields of the given file to an existing project,
+// leaving any fields missing from the file unchanged
+func projectsUpdate(a *app, args []string) error {
+	fs, g := a.flags("projects update")
+	file := fs.String("f", "", "JSON or YAML file with the fields to change, - for stdin")
+
+	args, err := a.parse(fs, g, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return usagef("expected a project ID")
+	}
+
+	if *file == "" {
+		return usagef("a project file is required, set -f")
+	}
+
+	team, err := a.team()
+	if err != nil {
+		return err
+	}
+
+	p, err := a.client.GetProject(args[0], team, a.cfg.Token)
+	if err != nil {
+		return err
+	}
+
+	err = a.readResource(*file, p)
+	if err != nil {
+		return err
+	}
+
+	p.ID = &args[0]
+	p.TeamID = &team
+
+	// the update requires every descriptive field to be present, even if empty
+	for _, f := range []**string{&p.Name, &p.Type, &p.Source, &p.Branch, &p.Description, &p.RulesetID} {
+		if *f == nil {
+			*f = new(string)
+		}
+	}
+
+	updated, err := a.client.UpdateProject(p, a.cfg.Token)
+	if err != nil {
+		return err
+	}
+
+	return a.print(updated, projectsTable(*updated))
+}
+
+func projectsTable(ps ...projects.Project) *table {
+	t := &table{headers: []string{"ID", "NAME", "TYPE", "BRANCH", "ACTIVE", "RULESET ID"}}
+	for _, p := range ps {
+		t.add(deref(p.ID), deref(p.Name), deref(p.Type), deref(p.Branch), strconv.FormatBool(p.Active), deref(p.RulesetID))
+	}
+
+	return t
+}
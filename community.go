@@ -17,12 +17,12 @@ func (ic *IonClient) GetRepo(repo, token string) (*community.Repo, error) {
 
 	b, _, err := ic.Get(community.GetRepoEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get repo: %v", err.Error())
+		return nil, fmt.Errorf("failed to get repo: %w", err)
 	}
 	var resultRepo community.Repo
 	err = json.Unmarshal(b, &resultRepo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal getRepo results: %v (%v)", err.Error(), string(b))
+		return nil, fmt.Errorf("failed to unmarshal getRepo results: %w (%v)", err, string(b))
 	}
 	return &resultRepo, nil
 }
@@ -46,17 +46,17 @@ type GetReposInCommonOutput struct {
 func (ic *IonClient) GetReposInCommon(options GetReposInCommonOptions, token string) ([]GetReposInCommonOutput, error) {
 	body, err := json.Marshal(options)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal options for repos in common (%s) : %v", options.Subject, err.Error())
+		return nil, fmt.Errorf("failed to marshal options for repos in common (%s) : %w", options.Subject, err)
 	}
 
 	b, err := ic.Post(community.GetReposInCommonEndpoint, token, nil, *bytes.NewBuffer(body), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get repos in common (%s) : %v", options.Subject, err.Error())
+		return nil, fmt.Errorf("failed to get repos in common (%s) : %w", options.Subject, err)
 	}
 	var resultRepos []GetReposInCommonOutput
 	err = json.Unmarshal(b, &resultRepos)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal repos in common results: %v (%v)", err.Error(), string(b))
+		return nil, fmt.Errorf("failed to unmarshal repos in common results: %w (%v)", err, string(b))
 	}
 	return resultRepos, nil
 }
@@ -69,12 +69,12 @@ func (ic *IonClient) GetReposForActor(name, token string) ([]community.Repo, err
 
 	b, _, err := ic.Get(community.GetReposForActorEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get repos for actor (%s) : %v", name, err.Error())
+		return nil, fmt.Errorf("failed to get repos for actor (%s) : %w", name, err)
 	}
 	var resultRepos []community.Repo
 	err = json.Unmarshal(b, &resultRepos)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal getRepos results: %v (%v)", err.Error(), string(b))
+		return nil, fmt.Errorf("failed to unmarshal getRepos results: %w (%v)", err, string(b))
 	}
 	return resultRepos, nil
 }
@@ -88,12 +88,12 @@ func (ic *IonClient) SearchRepo(q, token string) ([]community.Repo, error) {
 
 	b, _, err := ic.Get(community.SearchRepoEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get repo: %v", err.Error())
+		return nil, fmt.Errorf("failed to get repo: %w", err)
 	}
 	var results []community.Repo
 	err = json.Unmarshal(b, &results)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal getRepo results: %v (%v)", err.Error(), string(b))
+		return nil, fmt.Errorf("failed to unmarshal getRepo results: %w (%v)", err, string(b))
 	}
 	return results, nil
 }
@@ -0,0 +1,415 @@
+// Package cyclonedx reads and writes CycloneDX software bills of materials and
+// converts their components into Ion Channel projects.  JSON and XML documents
+// of specification versions 1.2 through 1.4 are supported.
+package cyclonedx
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// BOMFormat is the value of the bomFormat field of CycloneDX JSON documents
+	BOMFormat = "CycloneDX"
+	// SpecVersion is the CycloneDX specification version documents are
+	// written in when a BOM does not give one
+	SpecVersion = "1.4"
+
+	xmlNamespacePrefix = "http://cyclonedx.org/schema/bom/"
+)
+
+var (
+	// SupportedSpecVersions are the CycloneDX specification versions that can
+	// be read
+	SupportedSpecVersions = []string{"1.2", "1.3", "1.4"}
+
+	// ErrUnsupportedSpecVersion is returned when a document's specification
+	// version is not one of the supported versions
+	ErrUnsupportedSpecVersion = fmt.Errorf("unsupported CycloneDX spec version")
+)
+
+// BOM represents a CycloneDX bill of materials
+type BOM struct {
+	XMLName         xml.Name        `json:"-" xml:"bom"`
+	BOMFormat       string          `json:"bomFormat" xml:"-"`
+	SpecVersion     string          `json:"specVersion" xml:"-"`
+	SerialNumber    string          `json:"serialNumber,omitempty" xml:"serialNumber,attr,omitempty"`
+	Version         int             `json:"version" xml:"version,attr"`
+	Metadata        *Metadata       `json:"metadata,omitempty" xml:"metadata,omitempty"`
+	Components      []Component     `json:"components,omitempty" xml:"components>component,omitempty"`
+	Dependencies    []Dependency    `json:"dependencies,omitempty" xml:"dependencies>dependency,omitempty"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities,omitempty" xml:"vulnerabilities>vulnerability,omitempty"`
+}
+
+// Metadata represents the metadata of a bill of materials, including the
+// component it describes
+type Metadata struct {
+	Timestamp string                  `json:"timestamp,omitempty" xml:"timestamp,omitempty"`
+	Tools     []Tool                  `json:"tools,omitempty" xml:"tools>tool,omitempty"`
+	Authors   []OrganizationalContact `json:"authors,omitempty" xml:"authors>author,omitempty"`
+	Component *Component              `json:"component,omitempty" xml:"component,omitempty"`
+	Supplier  *OrganizationalEntity   `json:"supplier,omitempty" xml:"supplier,omitempty"`
+}
+
+// Tool represents a tool used to create a bill of materials
+type Tool struct {
+	Vendor  string `json:"vendor,omitempty" xml:"vendor,omitempty"`
+	Name    string `json:"name,omitempty" xml:"name,omitempty"`
+	Version string `json:"version,omitempty" xml:"version,omitempty"`
+}
+
+// OrganizationalContact represents a person, such as an author of a bill of
+// materials
+type OrganizationalContact struct {
+	Name  string `json:"name,omitempty" xml:"name,omitempty"`
+	Email string `json:"email,omitempty" xml:"email,omitempty"`
+}
+
+// Component represents a single software component in a bill of materials
+type Component struct {
+	Type               string                `json:"type" xml:"type,attr"`
+	BOMRef             string                `json:"bom-ref,omitempty" xml:"bom-ref,attr,omitempty"`
+	Supplier           *OrganizationalEntity `json:"supplier,omitempty" xml:"supplier,omitempty"`
+	Author             string                `json:"author,omitempty" xml:"author,omitempty"`
+	Publisher          string                `json:"publisher,omitempty" xml:"publisher,omitempty"`
+	Group              string                `json:"group,omitempty" xml:"group,omitempty"`
+	Name               string                `json:"name" xml:"name"`
+	Version            string                `json:"version,omitempty" xml:"version,omitempty"`
+	Description        string                `json:"description,omitempty" xml:"description,omitempty"`
+	Scope              string                `json:"scope,omitempty" xml:"scope,omitempty"`
+	Licenses           Licenses              `json:"licenses,omitempty" xml:"licenses,omitempty"`
+	CPE                string                `json:"cpe,omitempty" xml:"cpe,omitempty"`
+	PURL               string                `json:"purl,omitempty" xml:"purl,omitempty"`
+	ExternalReferences []ExternalReference   `json:"externalReferences,omitempty" xml:"externalReferences>reference,omitempty"`
+	Components         []Component           `json:"components,omitempty" xml:"components>component,omitempty"`
+}
+
+// OrganizationalEntity represents an organization, such as the supplier of a
+// component
+type OrganizationalEntity struct {
+	Name string   `json:"name,omitempty" xml:"name,omitempty"`
+	URL  []string `json:"url,omitempty" xml:"url,omitempty"`
+}
+
+// ExternalReference represents a reference from a component to an external
+// resource, such as its version control repository or distribution
+type ExternalReference struct {
+	Type    string `json:"type" xml:"type,attr"`
+	URL     string `json:"url" xml:"url"`
+	Comment string `json:"comment,omitempty" xml:"comment,omitempty"`
+}
+
+// Licenses are the licenses of a component, each given as either a license or
+// a license expression
+type Licenses []LicenseChoice
+
+// LicenseChoice is either a single license or an SPDX license expression
+type LicenseChoice struct {
+	License    *License `json:"license,omitempty"`
+	Expression string   `json:"expression,omitempty"`
+}
+
+// License represents a license by its SPDX identifier or, for licenses not on
+// the SPDX license list, its name
+type License struct {
+	ID   string `json:"id,omitempty" xml:"id,omitempty"`
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
+	URL  string `json:"url,omitempty" xml:"url,omitempty"`
+}
+
+// xmlLicenses is the XML form of a component's licenses, which lists licenses
+// and expressions as sibling elements
+type xmlLicenses struct {
+	Licenses    []License `xml:"license,omitempty"`
+	Expressions []string  `xml:"expression,omitempty"`
+}
+
+// UnmarshalXML reads licenses from their XML form
+func (l *Licenses) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var x xmlLicenses
+	err := dec.DecodeElement(&x, &start)
+	if err != nil {
+		return err
+	}
+
+	*l = nil
+	for ii := range x.Licenses {
+		*l = append(*l, LicenseChoice{License: &x.Licenses[ii]})
+	}
+
+	for _, e := range x.Expressions {
+		*l = append(*l, LicenseChoice{Expression: e})
+	}
+
+	return nil
+}
+
+// MarshalXML writes licenses in their XML form
+func (l Licenses) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	var x xmlLicenses
+	for _, c := range l {
+		switch {
+		case c.License != nil:
+			x.Licenses = append(x.Licenses, *c.License)
+		case c.Expression != "":
+			x.Expressions = append(x.Expressions, c.Expression)
+		}
+	}
+
+	return enc.EncodeElement(x, start)
+}
+
+// Vulnerability represents a vulnerability and the components it affects.
+// Vulnerabilities were added in spec version 1.4.
+type Vulnerability struct {
+	BOMRef         string                 `json:"bom-ref,omitempty" xml:"bom-ref,attr,omitempty"`
+	ID             string                 `json:"id,omitempty" xml:"id,omitempty"`
+	Source         *VulnerabilitySource   `json:"source,omitempty" xml:"source,omitempty"`
+	Ratings        []Rating               `json:"ratings,omitempty" xml:"ratings>rating,omitempty"`
+	Description    string                 `json:"description,omitempty" xml:"description,omitempty"`
+	Recommendation string                 `json:"recommendation,omitempty" xml:"recommendation,omitempty"`
+	Advisories     []Advisory             `json:"advisories,omitempty" xml:"advisories>advisory,omitempty"`
+	Published      string                 `json:"published,omitempty" xml:"published,omitempty"`
+	Updated        string                 `json:"updated,omitempty" xml:"updated,omitempty"`
+	Analysis       *VulnerabilityAnalysis `json:"analysis,omitempty" xml:"analysis,omitempty"`
+	Affects        []Affect               `json:"affects,omitempty" xml:"affects>target,omitempty"`
+}
+
+// VulnerabilitySource represents the source of a vulnerability, such as NVD
+type VulnerabilitySource struct {
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
+	URL  string `json:"url,omitempty" xml:"url,omitempty"`
+}
+
+// Rating represents the severity of a vulnerability under a scoring method,
+// such as CVSSv31
+type Rating struct {
+	Source   *VulnerabilitySource `json:"source,omitempty" xml:"source,omitempty"`
+	Score    float64              `json:"score,omitempty" xml:"score,omitempty"`
+	Severity string               `json:"severity,omitempty" xml:"severity,omitempty"`
+	Method   string               `json:"method,omitempty" xml:"method,omitempty"`
+	Vector   string               `json:"vector,omitempty" xml:"vector,omitempty"`
+}
+
+// Advisory represents a link to an advisory for a vulnerability
+type Advisory struct {
+	Title string `json:"title,omitempty" xml:"title,omitempty"`
+	URL   string `json:"url" xml:"url"`
+}
+
+// VulnerabilityAnalysis records whether a vulnerability is exploitable in the
+// components it affects, as used by VEX documents
+type VulnerabilityAnalysis struct {
+	State         string   `json:"state,omitempty" xml:"state,omitempty"`
+	Justification string   `json:"justification,omitempty" xml:"justification,omitempty"`
+	Response      []string `json:"response,omitempty" xml:"responses>response,omitempty"`
+	Detail        string   `json:"detail,omitempty" xml:"detail,omitempty"`
+}
+
+// Affect references a component affected by a vulnerability by its BOM
+// reference
+type Affect struct {
+	Ref string `json:"ref" xml:"ref"`
+}
+
+// Dependency represents the components a component directly depends on, by
+// their BOM references
+type Dependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// xmlDependency is the XML form of a dependency, which nests the components
+// depended on as dependency elements
+type xmlDependency struct {
+	Ref       string          `xml:"ref,attr"`
+	DependsOn []xmlDependency `xml:"dependency,omitempty"`
+}
+
+// UnmarshalXML reads a dependency from its XML form
+func (d *Dependency) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var x xmlDependency
+	err := dec.DecodeElement(&x, &start)
+	if err != nil {
+		return err
+	}
+
+	d.Ref = x.Ref
+	d.DependsOn = nil
+	for _, on := range x.DependsOn {
+		d.DependsOn = append(d.DependsOn, on.Ref)
+	}
+
+	return nil
+}
+
+// MarshalXML writes a dependency in its XML form
+func (d Dependency) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	x := xmlDependency{Ref: d.Ref}
+	for _, on := range d.DependsOn {
+		x.DependsOn = append(x.DependsOn, xmlDependency{Ref: on})
+	}
+
+	return enc.EncodeElement(x, start)
+}
+
+// Parse reads a CycloneDX document in either JSON or XML, detecting the
+// encoding from its first character.  It returns an error if the document
+// cannot be read or its spec version is not supported.
+func Parse(r io.Reader) (*BOM, error) {
+	br := bufio.NewReader(r)
+
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CycloneDX document: %w", err)
+		}
+
+		switch b[0] {
+		case ' ', '\t', '\r', '\n', 0xef, 0xbb, 0xbf:
+			br.ReadByte()
+			continue
+		case '{':
+			return ParseJSON(br)
+		case '<':
+			return ParseXML(br)
+		default:
+			return nil, fmt.Errorf("failed to read CycloneDX document: unrecognized encoding")
+		}
+	}
+}
+
+// ParseJSON reads a CycloneDX JSON document.  It returns an error if the
+// document cannot be read or its spec version is not supported.
+func ParseJSON(r io.Reader) (*BOM, error) {
+	var bom BOM
+	err := json.NewDecoder(r).Decode(&bom)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CycloneDX JSON document: %w", err)
+	}
+
+	if bom.BOMFormat != BOMFormat {
+		return nil, fmt.Errorf("failed to read CycloneDX JSON document: unexpected bomFormat %q", bom.BOMFormat)
+	}
+
+	err = checkSpecVersion(bom.SpecVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bom, nil
+}
+
+// ParseXML reads a CycloneDX XML document.  The spec version is taken from
+// the document's namespace.  It returns an error if the document cannot be
+// read or its spec version is not supported.
+func ParseXML(r io.Reader) (*BOM, error) {
+	var bom BOM
+	err := xml.NewDecoder(r).Decode(&bom)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CycloneDX XML document: %w", err)
+	}
+
+	if !strings.HasPrefix(bom.XMLName.Space, xmlNamespacePrefix) {
+		return nil, fmt.Errorf("failed to read CycloneDX XML document: unexpected namespace %q", bom.XMLName.Space)
+	}
+
+	bom.BOMFormat = BOMFormat
+	bom.SpecVersion = strings.TrimPrefix(bom.XMLName.Space, xmlNamespacePrefix)
+
+	err = checkSpecVersion(bom.SpecVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bom, nil
+}
+
+// WriteJSON writes the BOM as an indented CycloneDX JSON document.  The BOM is
+// written in spec version SpecVersion if it does not give one.
+func WriteJSON(w io.Writer, bom *BOM) error {
+	out, err := prepare(bom)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	err = enc.Encode(out)
+	if err != nil {
+		return fmt.Errorf("failed to write CycloneDX JSON document: %w", err)
+	}
+
+	return nil
+}
+
+// WriteXML writes the BOM as an indented CycloneDX XML document, in the
+// namespace of its spec version.  The BOM is written in spec version
+// SpecVersion if it does not give one.
+func WriteXML(w io.Writer, bom *BOM) error {
+	out, err := prepare(bom)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, xml.Header)
+	if err != nil {
+		return fmt.Errorf("failed to write CycloneDX XML document: %w", err)
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	start := xml.StartElement{Name: xml.Name{Space: xmlNamespacePrefix + out.SpecVersion, Local: "bom"}}
+	err = enc.EncodeElement(out, start)
+	if err != nil {
+		return fmt.Errorf("failed to write CycloneDX XML document: %w", err)
+	}
+
+	_, err = io.WriteString(w, "\n")
+	if err != nil {
+		return fmt.Errorf("failed to write CycloneDX XML document: %w", err)
+	}
+
+	return nil
+}
+
+// prepare returns a copy of the BOM with its format and spec version filled in
+// for writing
+func prepare(bom *BOM) (*BOM, error) {
+	if bom == nil {
+		return nil, fmt.Errorf("no CycloneDX BOM given")
+	}
+
+	out := *bom
+	out.BOMFormat = BOMFormat
+	if out.SpecVersion == "" {
+		out.SpecVersion = SpecVersion
+	}
+
+	if out.Version == 0 {
+		out.Version = 1
+	}
+
+	err := checkSpecVersion(out.SpecVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func checkSpecVersion(version string) error {
+	for _, v := range SupportedSpecVersions {
+		if v == version {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %q", ErrUnsupportedSpecVersion, version)
+}
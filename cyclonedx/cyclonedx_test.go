@@ -0,0 +1,219 @@
+package cyclonedx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic/aliases"
+	"github.com/ion-channel/ionic/errors"
+	. "github.com/onsi/gomega"
+)
+
+const sampleJSON = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "serialNumber": "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+  "version": 1,
+  "metadata": {
+    "component": {
+      "type": "application",
+      "bom-ref": "some-cool-pkg",
+      "group": "some-org",
+      "name": "some-cool-pkg",
+      "version": "1.2.3",
+      "description": "Some description",
+      "purl": "pkg:golang/github.com/some-org/some-cool-pkg@1.2.3",
+      "externalReferences": [
+        {"type": "website", "url": "https://some-org.io"},
+        {"type": "vcs", "url": "git+https://github.com/some-org/some-cool-pkg.git#main"}
+      ]
+    }
+  },
+  "components": [
+    {
+      "type": "library",
+      "bom-ref": "pkg:npm/%40angular/core@12.0.0",
+      "name": "core",
+      "version": "12.0.0",
+      "purl": "pkg:npm/%40angular/core@12.0.0?package-id=1#lib",
+      "cpe": "cpe:2.3:a:google:angular:12.0.0:*:*:*:*:*:*:*",
+      "externalReferences": [
+        {"type": "distribution", "url": "https://registry.npmjs.org/@angular/core/-/core-12.0.0.tgz"}
+      ],
+      "components": [
+        {"type": "library", "bom-ref": "nested", "name": "nested", "version": "0.1.0"}
+      ]
+    },
+    {
+      "type": "library",
+      "bom-ref": "unknown",
+      "name": "unknown",
+      "cpe": "cpe:/a:some%20vendor:unknown:2.0"
+    }
+  ],
+  "dependencies": [
+    {"ref": "some-cool-pkg", "dependsOn": ["pkg:npm/%40angular/core@12.0.0", "unknown"]}
+  ]
+}`
+
+const sampleXML = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.2" serialNumber="urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79" version="1">
+  <components>
+    <component type="application" bom-ref="app">
+      <name>app</name>
+      <version>2.0.0</version>
+      <externalReferences>
+        <reference type="vcs"><url>git@github.com:some-org/app.git</url></reference>
+      </externalReferences>
+    </component>
+    <component type="library" bom-ref="lib">
+      <supplier><name>The Org</name></supplier>
+      <name>lib</name>
+      <version>1.0.0</version>
+    </component>
+  </components>
+  <dependencies>
+    <dependency ref="app">
+      <dependency ref="lib"/>
+    </dependency>
+    <dependency ref="lib"/>
+  </dependencies>
+</bom>`
+
+func TestCycloneDX(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Parsing", func() {
+		g.It("should read JSON documents", func() {
+			bom, err := Parse(strings.NewReader("\n  " + sampleJSON))
+			Expect(err).To(BeNil())
+			Expect(bom.SpecVersion).To(Equal("1.4"))
+			Expect(bom.Metadata.Component.Name).To(Equal("some-cool-pkg"))
+			Expect(bom.Components).To(HaveLen(2))
+			Expect(bom.Components[0].Components).To(HaveLen(1))
+			Expect(bom.Dependencies[0].DependsOn).To(HaveLen(2))
+		})
+
+		g.It("should read XML documents", func() {
+			bom, err := Parse(strings.NewReader(sampleXML))
+			Expect(err).To(BeNil())
+			Expect(bom.BOMFormat).To(Equal(BOMFormat))
+			Expect(bom.SpecVersion).To(Equal("1.2"))
+			Expect(bom.Version).To(Equal(1))
+			Expect(bom.Components).To(HaveLen(2))
+			Expect(bom.Components[0].ExternalReferences[0].Type).To(Equal("vcs"))
+			Expect(bom.Components[1].Supplier.Name).To(Equal("The Org"))
+			Expect(bom.Dependencies).To(Equal([]Dependency{{Ref: "app", DependsOn: []string{"lib"}}, {Ref: "lib"}}))
+		})
+
+		g.It("should reject unsupported spec versions", func() {
+			_, err := Parse(strings.NewReader(strings.Replace(sampleJSON, `"1.4"`, `"1.1"`, 1)))
+			Expect(errors.Is(err, ErrUnsupportedSpecVersion)).To(BeTrue())
+
+			_, err = Parse(strings.NewReader(strings.Replace(sampleXML, "bom/1.2", "bom/1.0", 1)))
+			Expect(errors.Is(err, ErrUnsupportedSpecVersion)).To(BeTrue())
+		})
+
+		g.It("should reject documents that are not CycloneDX", func() {
+			_, err := Parse(strings.NewReader(`{"spdxVersion": "SPDX-2.2"}`))
+			Expect(err).NotTo(BeNil())
+
+			_, err = Parse(strings.NewReader("SPDXVersion: SPDX-2.2"))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	g.Describe("Writing", func() {
+		g.It("should write documents that read back the same", func() {
+			bom, _ := Parse(strings.NewReader(sampleJSON))
+			bom.Metadata.Component.Licenses = Licenses{{License: &License{ID: "MIT"}}, {Expression: "Apache-2.0 OR GPL-2.0-only"}}
+
+			var j, x bytes.Buffer
+			Expect(WriteJSON(&j, bom)).To(BeNil())
+			Expect(WriteXML(&x, bom)).To(BeNil())
+			Expect(x.String()).To(ContainSubstring(`<bom xmlns="http://cyclonedx.org/schema/bom/1.4"`))
+			Expect(x.String()).NotTo(ContainSubstring("<externalReferences></externalReferences>"))
+
+			for _, b := range []*bytes.Buffer{&j, &x} {
+				read, err := Parse(b)
+				Expect(err).To(BeNil())
+				Expect(read.Metadata).To(Equal(bom.Metadata))
+				Expect(read.Components).To(Equal(bom.Components))
+				Expect(read.Dependencies).To(Equal(bom.Dependencies))
+			}
+		})
+
+		g.It("should default the spec version and reject unsupported ones", func() {
+			var b bytes.Buffer
+			Expect(WriteJSON(&b, &BOM{})).To(BeNil())
+			Expect(b.String()).To(ContainSubstring(`"specVersion": "1.4"`))
+
+			err := WriteXML(&b, &BOM{SpecVersion: "1.0"})
+			Expect(errors.Is(err, ErrUnsupportedSpecVersion)).To(BeTrue())
+
+			Expect(WriteJSON(&b, nil)).NotTo(BeNil())
+		})
+	})
+
+	g.Describe("Projects", func() {
+		g.It("should return the described component when no dependencies requested", func() {
+			bom, _ := ParseJSON(strings.NewReader(sampleJSON))
+
+			p, err := ProjectsFromCycloneDX(bom, false)
+			Expect(err).To(BeNil())
+			Expect(p).To(HaveLen(1))
+			Expect(*p[0].Name).To(Equal("some-cool-pkg"))
+			Expect(*p[0].Type).To(Equal("git"))
+			Expect(*p[0].Source).To(Equal("https://github.com/some-org/some-cool-pkg.git"))
+			Expect(*p[0].Branch).To(Equal("main"))
+			Expect(*p[0].Description).To(Equal("Some description"))
+			Expect(*p[0].ID).NotTo(BeEmpty())
+			Expect(p[0].Aliases).To(Equal([]aliases.Alias{{Org: "github.com/some-org", Name: "some-cool-pkg", Version: "1.2.3"}}))
+		})
+
+		g.It("should return every component when dependencies requested", func() {
+			bom, _ := ParseJSON(strings.NewReader(sampleJSON))
+
+			p, err := ProjectsFromCycloneDX(bom, true)
+			Expect(err).To(BeNil())
+			Expect(p).To(HaveLen(4))
+
+			Expect(*p[1].Type).To(Equal("artifact"))
+			Expect(*p[1].Source).To(Equal("https://registry.npmjs.org/@angular/core/-/core-12.0.0.tgz"))
+			Expect(p[1].Aliases).To(Equal([]aliases.Alias{
+				{Org: "@angular", Name: "core", Version: "12.0.0"},
+				{Org: "google", Name: "angular", Version: "12.0.0"},
+			}))
+
+			Expect(*p[2].Name).To(Equal("nested"))
+			Expect(*p[2].Type).To(Equal("source_unavailable"))
+			Expect(p[2].Aliases).To(Equal([]aliases.Alias{{Name: "nested", Version: "0.1.0"}}))
+
+			Expect(p[3].Aliases).To(Equal([]aliases.Alias{{Org: "some vendor", Name: "unknown", Version: "2.0"}}))
+		})
+
+		g.It("should use the components nothing depends on when none is described", func() {
+			bom, _ := ParseXML(strings.NewReader(sampleXML))
+
+			p, err := ProjectsFromCycloneDX(bom, false)
+			Expect(err).To(BeNil())
+			Expect(p).To(HaveLen(1))
+			Expect(*p[0].Name).To(Equal("app"))
+			Expect(*p[0].Source).To(Equal("git@github.com:some-org/app.git"))
+			Expect(*p[0].Branch).To(Equal("HEAD"))
+
+			p, err = ProjectsFromCycloneDX(bom, true)
+			Expect(err).To(BeNil())
+			Expect(p).To(HaveLen(2))
+			Expect(p[1].Aliases).To(Equal([]aliases.Alias{{Org: "The Org", Name: "lib", Version: "1.0.0"}}))
+		})
+
+		g.It("should error without a BOM", func() {
+			_, err := ProjectsFromCycloneDX(nil, false)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+}
@@ -0,0 +1,164 @@
+package cyclonedx
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ion-channel/ionic/aliases"
+	"github.com/ion-channel/ionic/projects"
+)
+
+const (
+	referenceVCS          = "vcs"
+	referenceDistribution = "distribution"
+)
+
+// ProjectsFromCycloneDX parses components from a CycloneDX BOM into Projects.
+// Without dependencies, only the component the BOM describes in its metadata
+// is used, or the components no other component depends on if the metadata
+// names none.  With dependencies, every component in the BOM is used,
+// including nested components.  A component with a VCS external reference
+// becomes a git project, one with a distribution reference becomes an
+// artifact project, and any other becomes a source_unavailable project.  The
+// components' package URLs and CPEs are turned into aliases.
+func ProjectsFromCycloneDX(bom *BOM, includeDependencies bool) ([]projects.Project, error) {
+	if bom == nil {
+		return nil, fmt.Errorf("no CycloneDX BOM given")
+	}
+
+	var components []Component
+	if includeDependencies {
+		components = allComponents(bom)
+	} else {
+		components = topLevelComponents(bom)
+	}
+
+	projs := []projects.Project{}
+	for ii := range components {
+		projs = append(projs, projectFromComponent(components[ii]))
+	}
+
+	return projs, nil
+}
+
+// allComponents returns the BOM's described component followed by all of its
+// components, flattening nested components and skipping repeated references
+func allComponents(bom *BOM) []Component {
+	var components []Component
+	seen := make(map[string]bool)
+
+	var add func(cs []Component)
+	add = func(cs []Component) {
+		for _, c := range cs {
+			if c.BOMRef == "" || !seen[c.BOMRef] {
+				seen[c.BOMRef] = true
+				components = append(components, c)
+			}
+
+			add(c.Components)
+		}
+	}
+
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		add([]Component{*bom.Metadata.Component})
+	}
+
+	add(bom.Components)
+
+	return components
+}
+
+// topLevelComponents returns the component the BOM describes, or, if there is
+// none, the components no other component depends on
+func topLevelComponents(bom *BOM) []Component {
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		return []Component{*bom.Metadata.Component}
+	}
+
+	dependedOn := make(map[string]bool)
+	for _, d := range bom.Dependencies {
+		for _, ref := range d.DependsOn {
+			dependedOn[ref] = true
+		}
+	}
+
+	var components []Component
+	for _, c := range bom.Components {
+		if c.BOMRef == "" || !dependedOn[c.BOMRef] {
+			components = append(components, c)
+		}
+	}
+
+	return components
+}
+
+func projectFromComponent(c Component) projects.Project {
+	var ptype, source, branch string
+
+	tmpID := uuid.New().String()
+
+	if vcs := reference(c, referenceVCS); vcs != "" {
+		ptype = "git"
+		source, branch, _ = projects.GitSource(vcs)
+	} else if dist := reference(c, referenceDistribution); dist != "" {
+		ptype = "artifact"
+		source = dist
+	} else {
+		ptype = "source_unavailable"
+	}
+
+	name := c.Name
+	description := c.Description
+
+	proj := projects.Project{
+		ID:          &tmpID,
+		Branch:      &branch,
+		Description: &description,
+		Type:        &ptype,
+		Source:      &source,
+		Name:        &name,
+		Active:      true,
+		Monitor:     true,
+		Aliases:     aliasesFromComponent(c),
+	}
+
+	return proj
+}
+
+// reference returns the URL of the component's first external reference of the
+// given type
+func reference(c Component, refType string) string {
+	for _, r := range c.ExternalReferences {
+		if r.Type == refType && r.URL != "" {
+			return r.URL
+		}
+	}
+
+	return ""
+}
+
+// aliasesFromComponent returns the distinct aliases identified by the
+// component's package URL and CPE, falling back to its own group, name, and
+// version when it has neither
+func aliasesFromComponent(c Component) []aliases.Alias {
+	var as []aliases.Alias
+
+	if a, ok := aliases.FromPURL(c.PURL); ok {
+		as = aliases.AppendDistinct(as, a)
+	}
+
+	if a, ok := aliases.FromCPE(c.CPE); ok {
+		as = aliases.AppendDistinct(as, a)
+	}
+
+	if len(as) == 0 {
+		org := c.Group
+		if org == "" && c.Supplier != nil {
+			org = c.Supplier.Name
+		}
+
+		as = aliases.AppendDistinct(as, aliases.Alias{Name: c.Name, Org: org, Version: c.Version})
+	}
+
+	return as
+}
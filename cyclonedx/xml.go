@@ -0,0 +1,185 @@
+package cyclonedx
+
+import (
+	"encoding/xml"
+)
+
+// encoding/xml writes the wrapper element of a list tagged as "a>b" even when
+// the list is empty, which the CycloneDX schema does not allow for most lists.
+// Types with such lists are written through the shadow types below, which
+// leave the wrapper out when it is nil.  Lists are read with the tags on the
+// types themselves.
+
+type xmlTools struct {
+	Tools []Tool `xml:"tool"`
+}
+
+type xmlAuthors struct {
+	Authors []OrganizationalContact `xml:"author"`
+}
+
+type xmlComponents struct {
+	Components []Component `xml:"component"`
+}
+
+type xmlReferences struct {
+	References []ExternalReference `xml:"reference"`
+}
+
+type xmlDependencies struct {
+	Dependencies []Dependency `xml:"dependency"`
+}
+
+type xmlVulnerabilities struct {
+	Vulnerabilities []Vulnerability `xml:"vulnerability"`
+}
+
+type xmlRatings struct {
+	Ratings []Rating `xml:"rating"`
+}
+
+type xmlAdvisories struct {
+	Advisories []Advisory `xml:"advisory"`
+}
+
+type xmlTargets struct {
+	Targets []Affect `xml:"target"`
+}
+
+type xmlResponses struct {
+	Responses []string `xml:"response"`
+}
+
+// MarshalXML writes a BOM in its XML form
+func (b BOM) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	// bom has none of BOM's methods, and the wrapped lists it embeds are
+	// replaced by the shallower fields
+	type bom BOM
+	x := struct {
+		bom
+		Components      *xmlComponents      `xml:"components,omitempty"`
+		Dependencies    *xmlDependencies    `xml:"dependencies,omitempty"`
+		Vulnerabilities *xmlVulnerabilities `xml:"vulnerabilities,omitempty"`
+	}{bom: bom(b)}
+
+	if len(b.Components) > 0 {
+		x.Components = &xmlComponents{b.Components}
+	}
+
+	if len(b.Dependencies) > 0 {
+		x.Dependencies = &xmlDependencies{b.Dependencies}
+	}
+
+	if len(b.Vulnerabilities) > 0 {
+		x.Vulnerabilities = &xmlVulnerabilities{b.Vulnerabilities}
+	}
+
+	return enc.EncodeElement(x, start)
+}
+
+// MarshalXML writes metadata in its XML form
+func (m Metadata) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	x := struct {
+		Timestamp string                `xml:"timestamp,omitempty"`
+		Tools     *xmlTools             `xml:"tools,omitempty"`
+		Authors   *xmlAuthors           `xml:"authors,omitempty"`
+		Component *Component            `xml:"component,omitempty"`
+		Supplier  *OrganizationalEntity `xml:"supplier,omitempty"`
+	}{
+		Timestamp: m.Timestamp,
+		Component: m.Component,
+		Supplier:  m.Supplier,
+	}
+
+	if len(m.Tools) > 0 {
+		x.Tools = &xmlTools{m.Tools}
+	}
+
+	if len(m.Authors) > 0 {
+		x.Authors = &xmlAuthors{m.Authors}
+	}
+
+	return enc.EncodeElement(x, start)
+}
+
+// MarshalXML writes a component in its XML form
+func (c Component) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	// the wrapped lists come last in a component, so they can be replaced in
+	// place
+	type component Component
+	x := struct {
+		component
+		ExternalReferences *xmlReferences `xml:"externalReferences,omitempty"`
+		Components         *xmlComponents `xml:"components,omitempty"`
+	}{component: component(c)}
+
+	if len(c.ExternalReferences) > 0 {
+		x.ExternalReferences = &xmlReferences{c.ExternalReferences}
+	}
+
+	if len(c.Components) > 0 {
+		x.Components = &xmlComponents{c.Components}
+	}
+
+	return enc.EncodeElement(x, start)
+}
+
+// MarshalXML writes a vulnerability in its XML form
+func (v Vulnerability) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	x := struct {
+		BOMRef         string                 `xml:"bom-ref,attr,omitempty"`
+		ID             string                 `xml:"id,omitempty"`
+		Source         *VulnerabilitySource   `xml:"source,omitempty"`
+		Ratings        *xmlRatings            `xml:"ratings,omitempty"`
+		Description    string                 `xml:"description,omitempty"`
+		Recommendation string                 `xml:"recommendation,omitempty"`
+		Advisories     *xmlAdvisories         `xml:"advisories,omitempty"`
+		Published      string                 `xml:"published,omitempty"`
+		Updated        string                 `xml:"updated,omitempty"`
+		Analysis       *VulnerabilityAnalysis `xml:"analysis,omitempty"`
+		Affects        *xmlTargets            `xml:"affects,omitempty"`
+	}{
+		BOMRef:         v.BOMRef,
+		ID:             v.ID,
+		Source:         v.Source,
+		Description:    v.Description,
+		Recommendation: v.Recommendation,
+		Published:      v.Published,
+		Updated:        v.Updated,
+		Analysis:       v.Analysis,
+	}
+
+	if len(v.Ratings) > 0 {
+		x.Ratings = &xmlRatings{v.Ratings}
+	}
+
+	if len(v.Advisories) > 0 {
+		x.Advisories = &xmlAdvisories{v.Advisories}
+	}
+
+	if len(v.Affects) > 0 {
+		x.Affects = &xmlTargets{v.Affects}
+	}
+
+	return enc.EncodeElement(x, start)
+}
+
+// MarshalXML writes a vulnerability analysis in its XML form
+func (a VulnerabilityAnalysis) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	x := struct {
+		State         string        `xml:"state,omitempty"`
+		Justification string        `xml:"justification,omitempty"`
+		Responses     *xmlResponses `xml:"responses,omitempty"`
+		Detail        string        `xml:"detail,omitempty"`
+	}{
+		State:         a.State,
+		Justification: a.Justification,
+		Detail:        a.Detail,
+	}
+
+	if len(a.Response) > 0 {
+		x.Responses = &xmlResponses{a.Response}
+	}
+
+	return enc.EncodeElement(x, start)
+}
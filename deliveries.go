@@ -18,13 +18,13 @@ func (ic *IonClient) GetDeliveryDestinations(teamID, token string) ([]deliveries
 
 	b, _, err := ic.Get(deliveries.GetDestinationsEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get deliveries: %v", err.Error())
+		return nil, fmt.Errorf("failed to get deliveries: %w", err)
 	}
 
 	var d []deliveries.Destination
 	err = json.Unmarshal(b, &d)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get deliveries: %v", err.Error())
+		return nil, fmt.Errorf("failed to get deliveries: %w", err)
 	}
 
 	return d, nil
@@ -37,7 +37,7 @@ func (ic *IonClient) DeleteDeliveryDestination(destinationID, token string) erro
 
 	_, err := ic.Delete(deliveries.DeleteDestinationEndpoint, token, params, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete delivery destination: %v", err.Error())
+		return fmt.Errorf("failed to delete delivery destination: %w", err)
 	}
 	return err
 }
@@ -49,18 +49,18 @@ func (ic *IonClient) CreateDeliveryDestinations(dest *deliveries.CreateDestinati
 
 	b, err := json.Marshal(dest)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshall destination: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshall destination: %w", err)
 	}
 
 	b, err = ic.Post(deliveries.CreateDestinationEndpoint, token, params, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create destination: %v", err.Error())
+		return nil, fmt.Errorf("failed to create destination: %w", err)
 	}
 
 	var a deliveries.CreateDestination
 	err = json.Unmarshal(b, &a)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response from create destination: %v", err.Error())
+		return nil, fmt.Errorf("failed to read response from create destination: %w", err)
 	}
 
 	return &a, nil
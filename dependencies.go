@@ -39,17 +39,17 @@ func (ic *IonClient) ResolveDependenciesInFile(o dependencies.DependencyResoluti
 
 	fw, err := w.CreateFormFile("file", o.File)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %v", err.Error())
+		return nil, fmt.Errorf("failed to create form file: %w", err)
 	}
 
 	fh, err := os.Open(o.File)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %v", err.Error())
+		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 
 	_, err = io.Copy(fw, fh)
 	if err != nil {
-		return nil, fmt.Errorf("failed to copy file contents: %v", err.Error())
+		return nil, fmt.Errorf("failed to copy file contents: %w", err)
 	}
 
 	w.Close()
@@ -71,13 +71,13 @@ func (ic *IonClient) ResolveDependenciesInFile(o dependencies.DependencyResoluti
 
 	b, err := ic.Post(endpoint, token, params, buf, h)
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve dependencies: %v", err.Error())
+		return nil, fmt.Errorf("failed to resolve dependencies: %w", err)
 	}
 
 	var resp dependencies.DependencyResolutionResponse
 	err = json.Unmarshal(b, &resp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %v", err.Error())
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	return &resp, nil
@@ -94,13 +94,13 @@ func (ic *IonClient) GetLatestVersionForDependency(packageName, ecosystem, token
 
 	b, _, err := ic.Get(dependencies.GetLatestVersionForDependencyEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get latest version for dependency: %v", err.Error())
+		return nil, fmt.Errorf("failed to get latest version for dependency: %w", err)
 	}
 
 	var dep dependencies.Dependency
 	err = json.Unmarshal(b, &dep)
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse dependency: %v", err.Error())
+		return nil, fmt.Errorf("cannot parse dependency: %w", err)
 	}
 
 	dep.Name = packageName
@@ -118,13 +118,13 @@ func (ic *IonClient) GetVersionsForDependency(packageName, ecosystem, token stri
 
 	b, _, err := ic.Get(dependencies.GetVersionsForDependencyEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get latest version for dependency: %v", err.Error())
+		return nil, fmt.Errorf("failed to get latest version for dependency: %w", err)
 	}
 
 	var vs []string
 	err = json.Unmarshal(b, &vs)
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse dependency: %v", err.Error())
+		return nil, fmt.Errorf("cannot parse dependency: %w", err)
 	}
 
 	deps := []dependencies.Dependency{}
@@ -148,12 +148,12 @@ func (ic *IonClient) SearchDependencies(q, token string) ([]dependencies.Depende
 
 	b, _, err := ic.Get(dependencies.ResolveDependencySearchEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get dependencies: %v", err.Error())
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
 	}
 	var results []dependencies.Dependency
 	err = json.Unmarshal(b, &results)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal search results: %v (%v)", err.Error(), string(b))
+		return nil, fmt.Errorf("failed to unmarshal search results: %w (%v)", err, string(b))
 	}
 	return results, nil
 }
@@ -172,13 +172,13 @@ func (ic *IonClient) GetDependencyVersions(packageName, ecosystem, version, toke
 
 	b, _, err := ic.Get(dependencies.GetDependencyVersions, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get dependency versions: %v", err.Error())
+		return nil, fmt.Errorf("failed to get dependency versions: %w", err)
 	}
 
 	var deps []dependencies.Dependency
 	err = json.Unmarshal(b, &deps)
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse dependency: %v", err.Error())
+		return nil, fmt.Errorf("cannot parse dependency: %w", err)
 	}
 
 	return deps, nil
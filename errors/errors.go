@@ -1,30 +1,96 @@
 package errors
 
 import (
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"net/http"
+
+	"github.com/ion-channel/ionic/responses"
+)
+
+var (
+	// ErrNotFound is matched by IonErrors for responses with a status of 404
+	ErrNotFound = fmt.Errorf("not found")
+	// ErrUnauthorized is matched by IonErrors for responses with a status of
+	// 401
+	ErrUnauthorized = fmt.Errorf("unauthorized")
+	// ErrForbidden is matched by IonErrors for responses with a status of 403
+	ErrForbidden = fmt.Errorf("forbidden")
+	// ErrRateLimited is matched by IonErrors for responses with a status of
+	// 429
+	ErrRateLimited = fmt.Errorf("rate limited")
+	// ErrValidation is matched by IonErrors for responses with a status of 400
+	// or 422, or for any response that reports invalid fields
+	ErrValidation = fmt.Errorf("validation failed")
+	// ErrServer is matched by IonErrors for responses with a status of 500 or
+	// above
+	ErrServer = fmt.Errorf("server error")
 )
 
 // IonError represents an error from the API with the pertinent information
 // accessible
 type IonError struct {
-	Err            error  `json:"error"`
-	ResponseBody   string `json:"response_body"`
-	ResponseStatus int    `json:"response_status"`
+	Err            error             `json:"error"`
+	ResponseBody   string            `json:"response_body"`
+	ResponseStatus int               `json:"response_status"`
+	Message        string            `json:"message,omitempty"`
+	Fields         map[string]string `json:"fields,omitempty"`
 }
 
 // Errors takes a body, status, format, and any additional arguments to create
-// an IonError that includes details from the API
+// an IonError that includes details from the API.  If the body is an error
+// response from the API, its message and fields are parsed into the error.
+// The format supports the %w verb to wrap an underlying error.
 func Errors(body string, status int, format string, a ...interface{}) *IonError {
-	return &IonError{fmt.Errorf(format, a...), body, status}
+	ierr := &IonError{Err: fmt.Errorf(format, a...), ResponseBody: body, ResponseStatus: status}
+
+	var er responses.IonErrorResponse
+	if json.Unmarshal([]byte(body), &er) == nil {
+		ierr.Message = er.Message
+		if len(er.Fields) > 0 {
+			ierr.Fields = er.Fields
+		}
+	}
+
+	return ierr
 }
 
 func (e IonError) Error() string {
 	return fmt.Sprintf("ionic: (%v) %v", e.ResponseStatus, e.Err.Error())
 }
 
+// Unwrap returns the underlying error
+func (e *IonError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error matches the target sentinel error based on the
+// response status and fields
+func (e *IonError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.ResponseStatus == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.ResponseStatus == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.ResponseStatus == http.StatusForbidden
+	case ErrRateLimited:
+		return e.ResponseStatus == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.ResponseStatus == http.StatusBadRequest ||
+			e.ResponseStatus == http.StatusUnprocessableEntity ||
+			len(e.Fields) > 0
+	case ErrServer:
+		return e.ResponseStatus >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
 // Prepend takes a prefix and puts it on the front of the IonError.
 func (e *IonError) Prepend(prefix string) {
-	e.Err = fmt.Errorf("%v: %v", prefix, e.Err.Error())
+	e.Err = fmt.Errorf("%v: %w", prefix, e.Err)
 }
 
 // Prepend takes a prefix and an error, creates an IonError if the error is not
@@ -32,9 +98,82 @@ func (e *IonError) Prepend(prefix string) {
 func Prepend(prefix string, err error) *IonError {
 	ierr, ok := err.(*IonError)
 	if !ok {
-		return Errors("", 0, "%v: %v", prefix, err.Error())
+		return Errors("", 0, "%v: %w", prefix, err)
 	}
 
 	ierr.Prepend(prefix)
 	return ierr
 }
+
+// IsNotFound returns whether the error, or any error it wraps, is an IonError
+// for a resource that was not found
+func IsNotFound(err error) bool {
+	return Is(err, ErrNotFound)
+}
+
+// IsUnauthorized returns whether the error, or any error it wraps, is an
+// IonError for a request that was not authenticated
+func IsUnauthorized(err error) bool {
+	return Is(err, ErrUnauthorized)
+}
+
+// IsForbidden returns whether the error, or any error it wraps, is an IonError
+// for a request that was not permitted
+func IsForbidden(err error) bool {
+	return Is(err, ErrForbidden)
+}
+
+// IsRateLimited returns whether the error, or any error it wraps, is an
+// IonError for a request that was rate limited
+func IsRateLimited(err error) bool {
+	return Is(err, ErrRateLimited)
+}
+
+// IsValidation returns whether the error, or any error it wraps, is an
+// IonError for a request that failed validation
+func IsValidation(err error) bool {
+	return Is(err, ErrValidation)
+}
+
+// IsServer returns whether the error, or any error it wraps, is an IonError
+// for a request the API failed to handle
+func IsServer(err error) bool {
+	return Is(err, ErrServer)
+}
+
+// FieldsOf returns the invalid fields reported by the API for the error, or
+// any error it wraps, and nil if there are none
+func FieldsOf(err error) map[string]string {
+	var ierr *IonError
+	if !As(err, &ierr) {
+		return nil
+	}
+
+	return ierr.Fields
+}
+
+// StatusOf returns the response status of the error, or any error it wraps,
+// and zero if it is not an IonError
+func StatusOf(err error) int {
+	var ierr *IonError
+	if !As(err, &ierr) {
+		return 0
+	}
+
+	return ierr.ResponseStatus
+}
+
+// Is reports whether any error in err's chain matches target.  It is the
+// standard library's errors.Is, provided so callers importing this package do
+// not need to alias either package.
+func Is(err, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+// As finds the first error in err's chain that matches target, and if so, sets
+// target to that error value and returns true.  It is the standard library's
+// errors.As, provided so callers importing this package do not need to alias
+// either package.
+func As(err error, target interface{}) bool {
+	return stderrors.As(err, target)
+}
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -21,5 +22,62 @@ func TestErrors(t *testing.T) {
 			Expect(ierr.ResponseStatus).To(Equal(404))
 			Expect(ierr.Error()).To(Equal("ionic: (404) something went wrong: json: invalid key"))
 		})
+
+		g.It("should parse the message and fields from an error response", func() {
+			body := `{"message":"invalid project","fields":{"name":"is required"},"code":422}`
+			ierr := Errors(body, 422, "api: error response")
+
+			Expect(ierr.Message).To(Equal("invalid project"))
+			Expect(ierr.Fields).To(Equal(map[string]string{"name": "is required"}))
+			Expect(FieldsOf(fmt.Errorf("wrapped: %w", ierr))).To(Equal(ierr.Fields))
+		})
+
+		g.It("should leave the fields empty for other bodies", func() {
+			ierr := Errors("no body", 0, "http request: failed")
+
+			Expect(ierr.Message).To(Equal(""))
+			Expect(ierr.Fields).To(BeNil())
+			Expect(FieldsOf(fmt.Errorf("plain"))).To(BeNil())
+		})
+
+		g.It("should match sentinel errors by status", func() {
+			Expect(IsNotFound(Errors("", 404, "nope"))).To(BeTrue())
+			Expect(IsNotFound(Errors("", 400, "nope"))).To(BeFalse())
+			Expect(IsUnauthorized(Errors("", 401, "nope"))).To(BeTrue())
+			Expect(IsForbidden(Errors("", 403, "nope"))).To(BeTrue())
+			Expect(IsRateLimited(Errors("", 429, "nope"))).To(BeTrue())
+			Expect(IsValidation(Errors("", 400, "nope"))).To(BeTrue())
+			Expect(IsValidation(Errors("", 422, "nope"))).To(BeTrue())
+			Expect(IsValidation(Errors(`{"fields":{"id":"bad"}}`, 409, "nope"))).To(BeTrue())
+			Expect(IsServer(Errors("", 503, "nope"))).To(BeTrue())
+			Expect(IsServer(Errors("", 404, "nope"))).To(BeFalse())
+		})
+
+		g.It("should match through wrapped errors", func() {
+			ierr := Errors("", 404, "api: error response")
+			err := fmt.Errorf("failed to get project: %w", ierr)
+
+			Expect(IsNotFound(err)).To(BeTrue())
+			Expect(StatusOf(err)).To(Equal(404))
+
+			var target *IonError
+			Expect(As(err, &target)).To(BeTrue())
+			Expect(target).To(Equal(ierr))
+		})
+
+		g.It("should unwrap to the underlying error", func() {
+			ierr := Errors("no body", 0, "http request: failed: %w", context.Canceled)
+			ierr.Prepend("api: paging")
+
+			Expect(Is(ierr, context.Canceled)).To(BeTrue())
+			Expect(ierr.Error()).To(Equal("ionic: (0) api: paging: http request: failed: context canceled"))
+		})
+
+		g.It("should prepend onto other errors", func() {
+			ierr := Prepend("prefix", context.DeadlineExceeded)
+
+			Expect(Is(ierr, context.DeadlineExceeded)).To(BeTrue())
+			Expect(StatusOf(fmt.Errorf("plain"))).To(Equal(0))
+		})
 	})
 }
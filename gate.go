@@ -0,0 +1,332 @@
+package ionic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/ion-channel/ionic/projects"
+	"github.com/ion-channel/ionic/rulesets"
+	"github.com/ion-channel/ionic/scanner"
+)
+
+// ProjectGateResult represents the outcome of gating a single project
+type ProjectGateResult struct {
+	ProjectID   string `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	AnalysisID  string `json:"analysis_id"`
+	// Status is the last status seen for the project's analysis
+	Status string `json:"status"`
+	// Passed is whether the analysis finished and passed the project's
+	// ruleset
+	Passed bool `json:"passed"`
+	// FailedRules are the names of the rules the analysis failed
+	FailedRules []string `json:"failed_rules,omitempty"`
+	// Err is the error that kept the project from being gated, if any
+	Err error `json:"-"`
+}
+
+// GateResult represents the consolidated outcome of gating many projects
+type GateResult struct {
+	Projects []ProjectGateResult `json:"projects"`
+}
+
+// Passed returns whether every project's analysis finished and passed its
+// ruleset.  A result with no projects passes.
+func (r *GateResult) Passed() bool {
+	for _, p := range r.Projects {
+		if !p.Passed || p.Err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Failed returns the projects that did not pass, including those that could
+// not be gated
+func (r *GateResult) Failed() []ProjectGateResult {
+	var failed []ProjectGateResult
+	for _, p := range r.Projects {
+		if !p.Passed || p.Err != nil {
+			failed = append(failed, p)
+		}
+	}
+
+	return failed
+}
+
+// AnalyzeAndGate takes a context, team ID, token, project filter, and wait
+// options.  It starts the analyses of the team's projects matching the filter
+// in a single request, tracks the analyses until they are done, and returns
+// whether each project passed its ruleset along with the names of any rules it
+// failed.  A nil filter gates every project of the team.  Problems with a
+// single project, such as no analysis being started for it or its analysis
+// erroring, are recorded on that project's result.  If the context is done or
+// the timeout passes first, the projects still being analyzed are recorded
+// with the context's error, which is also returned.  The options' progress
+// callback is called for the scans of every analysis.
+func (ic *IonClient) AnalyzeAndGate(ctx context.Context, teamID, token string, filter *projects.Filter, opts *WaitOptions) (*GateResult, error) {
+	o := opts.withDefaults()
+
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	c := ic.WithContext(ctx)
+	result := &GateResult{}
+
+	it := c.IterateProjects(teamID, token, nil, filter)
+	for it.Next() {
+		p := it.Value()
+
+		r := ProjectGateResult{}
+		if p.ID != nil {
+			r.ProjectID = *p.ID
+		}
+
+		if p.Name != nil {
+			r.ProjectName = *p.Name
+		}
+
+		result.Projects = append(result.Projects, r)
+	}
+
+	if it.Err() != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", it.Err())
+	}
+
+	pending, err := c.start(teamID, token, filter, result)
+	if err != nil {
+		return result, err
+	}
+
+	scans := make(map[string]map[string]string)
+	for id := range pending {
+		scans[id] = make(map[string]string)
+	}
+
+	interval := o.Interval
+	for len(pending) > 0 {
+		statuses, err := c.track(teamID, token, result, pending)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+
+			return result, fmt.Errorf("failed to track analyses: %w", err)
+		}
+
+		var finished []int
+		for _, status := range statuses {
+			i := pending[status.ID]
+			r := &result.Projects[i]
+			r.Status = status.Status
+			progress(o.OnProgress, status, scans[status.ID])
+
+			if !status.Done() {
+				continue
+			}
+
+			delete(pending, status.ID)
+
+			if status.Status != scanner.AnalysisStatusFinished {
+				r.Err = fmt.Errorf("%w: %v: %v", ErrAnalysisErrored, status.Status, status.Message)
+				continue
+			}
+
+			finished = append(finished, i)
+		}
+
+		if len(finished) > 0 {
+			err := c.gate(teamID, token, result, finished)
+			if err != nil {
+				return result, fmt.Errorf("failed to get applied rulesets: %w", err)
+			}
+		}
+
+		if len(pending) == 0 || sleep(ctx, interval) != nil {
+			break
+		}
+
+		interval = o.next(interval)
+	}
+
+	if ctx.Err() != nil && len(pending) > 0 {
+		for _, i := range pending {
+			result.Projects[i].Err = ctx.Err()
+		}
+
+		return result, fmt.Errorf("failed waiting for analyses: %w", ctx.Err())
+	}
+
+	return result, nil
+}
+
+// start starts the analyses of the team's projects matching the filter in a
+// single request and returns a map of the ID of each analysis started to the
+// index of its project's result.  The projects are matched to their analyses
+// by the statuses of the analyses, and a project no analysis was started for,
+// such as an inactive project, is recorded with an error.
+func (ic *IonClient) start(teamID, token string, filter *projects.Filter, result *GateResult) (map[string]int, error) {
+	params := &url.Values{}
+	if filter != nil {
+		params.Set("filter_by", filter.Param())
+	}
+
+	ids, err := ic.AnalyzeProjects(teamID, token, params)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make(map[string]int)
+	for i, r := range result.Projects {
+		indexes[r.ProjectID] = i
+	}
+
+	pending := make(map[string]int)
+
+	if len(ids) > 0 {
+		statuses, err := ic.GetAnalysesStatuses(teamID, ids, token)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, status := range statuses {
+			i, ok := indexes[status.ProjectID]
+			if !ok {
+				continue
+			}
+
+			r := &result.Projects[i]
+			r.AnalysisID = status.AnalysisID
+			r.Status = status.Status
+			pending[status.AnalysisID] = i
+		}
+	}
+
+	for i := range result.Projects {
+		r := &result.Projects[i]
+		if r.AnalysisID == "" {
+			r.Err = fmt.Errorf("no analysis was started for project %v", r.ProjectID)
+		}
+	}
+
+	return pending, nil
+}
+
+// track returns the current statuses of the pending analyses.  The statuses
+// come from the latest analysis statuses of the team, falling back to the
+// statuses of the analyses, fetched together, for any pending analysis that
+// is no longer its project's latest.
+func (ic *IonClient) track(teamID, token string, result *GateResult, pending map[string]int) ([]*scanner.AnalysisStatus, error) {
+	latest, err := ic.GetLatestAnalysisStatuses(teamID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []*scanner.AnalysisStatus
+	seen := make(map[string]bool)
+
+	for i := range latest {
+		if _, ok := pending[latest[i].ID]; ok {
+			statuses = append(statuses, &latest[i])
+			seen[latest[i].ID] = true
+		}
+	}
+
+	var missing []string
+	for id := range pending {
+		if !seen[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) == 0 {
+		return statuses, nil
+	}
+
+	others, err := ic.GetAnalysesStatuses(teamID, missing, token)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, o := range others {
+		i, ok := pending[o.AnalysisID]
+		if !ok {
+			continue
+		}
+
+		statuses = append(statuses, &scanner.AnalysisStatus{
+			ID:        o.AnalysisID,
+			TeamID:    teamID,
+			ProjectID: result.Projects[i].ProjectID,
+			Status:    analysisStatus(o.Status),
+		})
+	}
+
+	return statuses, nil
+}
+
+// analysisStatus returns the analysis status of a status from the analyses
+// statuses, which report a finished analysis as having passed or failed its
+// ruleset
+func analysisStatus(status string) string {
+	if status == scanner.AnalysisStatusPassed || status == scanner.AnalysisStatusFailed {
+		return scanner.AnalysisStatusFinished
+	}
+
+	return status
+}
+
+// gate records whether the projects at the given indexes passed, based on the
+// applied rulesets of their finished analyses
+func (ic *IonClient) gate(teamID, token string, result *GateResult, finished []int) error {
+	batch := make([]*rulesets.AppliedRulesetRequest, 0, len(finished))
+	for _, i := range finished {
+		r := result.Projects[i]
+		batch = append(batch, &rulesets.AppliedRulesetRequest{
+			ProjectID:  r.ProjectID,
+			TeamID:     teamID,
+			AnalysisID: r.AnalysisID,
+		})
+	}
+
+	applied, err := ic.GetAppliedRuleSets(batch, token)
+	if err != nil {
+		return err
+	}
+
+	byAnalysis := make(map[string]*rulesets.AppliedRulesetSummary)
+	for i := range *applied {
+		byAnalysis[(*applied)[i].AnalysisID] = &(*applied)[i]
+	}
+
+	for _, i := range finished {
+		r := &result.Projects[i]
+
+		a, ok := byAnalysis[r.AnalysisID]
+		if !ok || a.RuleEvaluationSummary == nil {
+			r.Err = fmt.Errorf("no applied ruleset found for analysis %v", r.AnalysisID)
+			continue
+		}
+
+		r.Passed = a.RuleEvaluationSummary.Passed
+		for _, e := range a.RuleEvaluationSummary.Ruleresults {
+			if e.Passed {
+				continue
+			}
+
+			name := e.Name
+			if name == "" {
+				name = e.RuleID
+			}
+
+			r.FailedRules = append(r.FailedRules, name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package ionic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic/errors"
+	"github.com/ion-channel/ionic/iontest"
+	"github.com/ion-channel/ionic/projects"
+	"github.com/ion-channel/ionic/requests"
+	"github.com/ion-channel/ionic/rules"
+	"github.com/ion-channel/ionic/rulesets"
+	"github.com/ion-channel/ionic/scanner"
+	. "github.com/onsi/gomega"
+)
+
+func TestAnalyzeAndGate(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Analyzing and gating projects", func() {
+		var server *iontest.Server
+		var client *IonClient
+		var rule rules.Rule
+		var ps []projects.Project
+		var opts *WaitOptions
+
+		g.BeforeEach(func() {
+			server = iontest.NewServer()
+			client, _ = New(server.URL)
+
+			rule = server.AddRule(rules.Rule{Name: "No critical vulnerabilities", ScanType: "vulnerability"})
+			rs := server.AddRuleSet(rulesets.RuleSet{TeamID: "someteam", Name: "someruleset", RuleIDs: []string{rule.ID}})
+
+			ps = nil
+			for _, name := range []string{"passing", "failing", "erroring", "inactive"} {
+				team, n := "someteam", name
+				p := server.AddProject(projects.Project{TeamID: &team, RulesetID: &rs.ID, Name: &n, Active: name != "inactive"})
+				ps = append(ps, p)
+			}
+
+			server.SetOutcome(*ps[1].ID, iontest.Outcome{FailedRules: []string{rule.ID}})
+			server.SetOutcome(*ps[2].ID, iontest.Outcome{Error: "failed to clone"})
+
+			opts = &WaitOptions{Interval: time.Millisecond}
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+		})
+
+		g.It("should gate every project matching the filter", func() {
+			active := true
+			result, err := client.AnalyzeAndGate(context.Background(), "someteam", "sometoken", &projects.Filter{Active: &active}, opts)
+			Expect(err).To(BeNil())
+			Expect(result.Projects).To(HaveLen(3))
+			Expect(result.Passed()).To(BeFalse())
+
+			passing := result.Projects[0]
+			Expect(passing.ProjectName).To(Equal("passing"))
+			Expect(passing.Passed).To(BeTrue())
+			Expect(passing.Status).To(Equal(scanner.AnalysisStatusFinished))
+			Expect(passing.Err).To(BeNil())
+
+			failing := result.Projects[1]
+			Expect(failing.Passed).To(BeFalse())
+			Expect(failing.FailedRules).To(Equal([]string{"No critical vulnerabilities"}))
+			Expect(failing.Err).To(BeNil())
+
+			erroring := result.Projects[2]
+			Expect(erroring.Passed).To(BeFalse())
+			Expect(erroring.Status).To(Equal(scanner.AnalysisStatusErrored))
+			Expect(errors.Is(erroring.Err, ErrAnalysisErrored)).To(BeTrue())
+
+			Expect(result.Failed()).To(HaveLen(2))
+			Expect(server.Hits(scanner.ScannerAnalyzeProjectEndpoint)).To(Equal(1))
+			Expect(server.Hits(scanner.ScannerGetLatestAnalysisStatusesEndpoint)).To(BeNumerically(">=", 1))
+			Expect(server.Hits(scanner.ScannerGetAnalysisStatusEndpoint)).To(Equal(0))
+		})
+
+		g.It("should record projects no analysis was started for", func() {
+			result, err := client.AnalyzeAndGate(context.Background(), "someteam", "sometoken", nil, opts)
+			Expect(err).To(BeNil())
+			Expect(result.Projects).To(HaveLen(4))
+
+			inactive := result.Projects[3]
+			Expect(inactive.ProjectName).To(Equal("inactive"))
+			Expect(inactive.AnalysisID).To(Equal(""))
+			Expect(inactive.Err).NotTo(BeNil())
+		})
+
+		g.It("should track analyses that are no longer their project's latest", func() {
+			other, _ := New(server.URL)
+			client.AddHooks(requests.Hooks{
+				AfterResponse: func(c *requests.CallInfo) {
+					if c.Endpoint == scanner.ScannerAnalyzeProjectEndpoint {
+						_, err := other.AnalyzeProject(*ps[0].ID, "someteam", "", "sometoken")
+						Expect(err).To(BeNil())
+					}
+				},
+			})
+
+			result, err := client.AnalyzeAndGate(context.Background(), "someteam", "sometoken", &projects.Filter{ID: ps[0].ID}, opts)
+			Expect(err).To(BeNil())
+			Expect(result.Passed()).To(BeTrue())
+			Expect(server.Hits(rulesets.GetRulesetAnalysesStatuses)).To(BeNumerically(">=", 2))
+			Expect(server.Hits(scanner.ScannerGetAnalysisStatusEndpoint)).To(Equal(0))
+		})
+
+		g.It("should pass when every project passes", func() {
+			result, err := client.AnalyzeAndGate(context.Background(), "someteam", "sometoken", &projects.Filter{ID: ps[0].ID}, opts)
+			Expect(err).To(BeNil())
+			Expect(result.Projects).To(HaveLen(1))
+			Expect(result.Passed()).To(BeTrue())
+		})
+
+		g.It("should record the context's error on projects still being analyzed", func() {
+			server.PollsToFinish = 1000
+			opts.Timeout = 20 * time.Millisecond
+
+			result, err := client.AnalyzeAndGate(context.Background(), "someteam", "sometoken", &projects.Filter{ID: ps[0].ID}, opts)
+			Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+			Expect(result.Projects).To(HaveLen(1))
+			Expect(result.Projects[0].Status).To(Equal(scanner.AnalysisStatusAnalyzing))
+			Expect(errors.Is(result.Projects[0].Err, context.DeadlineExceeded)).To(BeTrue())
+			Expect(result.Passed()).To(BeFalse())
+		})
+	})
+}
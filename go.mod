@@ -11,8 +11,10 @@ require (
 	github.com/kr/pretty v0.1.0 // indirect
 	github.com/onsi/ginkgo v1.16.2 // indirect
 	github.com/onsi/gomega v1.10.1
+	github.com/spdx/gordf v0.0.0-20201111095634-7098f93598fb
 	github.com/spdx/tools-golang v0.0.0-20201122192914-a16d50ee1552
 	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
+	gopkg.in/yaml.v2 v2.3.0
 )
 
 replace github.com/spdx/tools-golang => github.com/ion-channel/tools-golang v0.0.0-20210615220006-88b94127213b
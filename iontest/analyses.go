@@ -0,0 +1,691 @@
+package iontest
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ion-channel/ionic/analyses"
+	"github.com/ion-channel/ionic/projects"
+	"github.com/ion-channel/ionic/reports"
+	"github.com/ion-channel/ionic/requests"
+	"github.com/ion-channel/ionic/responses"
+	"github.com/ion-channel/ionic/rules"
+	"github.com/ion-channel/ionic/rulesets"
+	"github.com/ion-channel/ionic/scanner"
+	"github.com/ion-channel/ionic/scans"
+)
+
+// defaultScans are the scans reported for analyses of projects whose ruleset
+// has no rules with a scan type
+var defaultScans = []string{"dependency", "license", "vulnerability"}
+
+// resultTypes are the scan types the SDK can read the results of
+var resultTypes = map[string]bool{
+	"about_yml":     true,
+	"buildsystems":  true,
+	"community":     true,
+	"coverage":      true,
+	"dependency":    true,
+	"ecosystems":    true,
+	"license":       true,
+	"secrets":       true,
+	"virus":         true,
+	"vulnerability": true,
+}
+
+// Outcome describes how the analyses of a project end
+type Outcome struct {
+	// FailedRules are the IDs of the rules of the project's ruleset that the
+	// analyses fail.  An analysis passes when none of its rules fail.
+	FailedRules []string
+	// Error, if set, makes the analyses error with it as their message rather
+	// than finish
+	Error string
+}
+
+type analysisRecord struct {
+	status   scanner.AnalysisStatus
+	polls    int
+	analysis *analyses.Analysis
+	applied  *rulesets.AppliedRulesetSummary
+}
+
+// SetOutcome sets how analyses of the project started from then on end.  By
+// default analyses pass every rule.
+func (s *Server) SetOutcome(projectID string, outcome Outcome) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.outcomes[projectID] = outcome
+}
+
+// FinishAnalyses finishes every analysis that is still queued or analyzing,
+// regardless of how many times its status has been polled
+func (s *Server) FinishAnalyses() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range s.analyses {
+		if !a.status.Done() {
+			s.finish(a)
+		}
+	}
+}
+
+// startAnalysis queues a new analysis of the project
+func (s *Server) startAnalysis(p *projects.Project, branch string) *analysisRecord {
+	if branch == "" {
+		branch = value(p.Branch)
+	}
+
+	t := now()
+	a := &analysisRecord{
+		status: scanner.AnalysisStatus{
+			ID:                  s.newID(),
+			TeamID:              value(p.TeamID),
+			ProjectID:           value(p.ID),
+			Branch:              branch,
+			Status:              scanner.AnalysisStatusQueued,
+			Message:             "Request for analysis has been queued.",
+			AnalysisEventSource: "api",
+			CreatedAt:           t,
+			UpdatedAt:           t,
+		},
+	}
+
+	for _, name := range s.scanNames(p) {
+		a.status.ScanStatus = append(a.status.ScanStatus, scanner.ScanStatus{
+			ID:               s.newID(),
+			AnalysisStatusID: a.status.ID,
+			ProjectID:        a.status.ProjectID,
+			TeamID:           a.status.TeamID,
+			Name:             name,
+			Status:           scanner.AnalysisStatusQueued,
+			CreatedAt:        t,
+			UpdatedAt:        t,
+		})
+	}
+
+	s.analyses = append(s.analyses, a)
+
+	if s.PollsToFinish <= 0 {
+		s.finish(a)
+	}
+
+	return a
+}
+
+// scanNames returns the names of the scans run for the project, one for each
+// scan type used by the rules of its ruleset
+func (s *Server) scanNames(p *projects.Project) []string {
+	rs := s.findRuleSet(value(p.RulesetID), value(p.TeamID))
+	if rs == nil {
+		return defaultScans
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, r := range rs.Rules {
+		if r.ScanType != "" && !seen[r.ScanType] {
+			seen[r.ScanType] = true
+			names = append(names, r.ScanType)
+		}
+	}
+
+	if len(names) == 0 {
+		return defaultScans
+	}
+
+	return names
+}
+
+// poll records a poll of the analysis's status, moving it along towards
+// finishing
+func (s *Server) poll(a *analysisRecord) {
+	if a.status.Done() {
+		return
+	}
+
+	a.polls++
+	if a.polls >= s.PollsToFinish {
+		s.finish(a)
+		return
+	}
+
+	t := now()
+	a.status.Status = scanner.AnalysisStatusAnalyzing
+	a.status.Message = "Analysis is in progress."
+	a.status.UpdatedAt = t
+
+	// scans finish one after another as the analysis is polled
+	finished := a.polls * len(a.status.ScanStatus) / s.PollsToFinish
+	for i := range a.status.ScanStatus {
+		status := scanner.AnalysisStatusAnalyzing
+		if i < finished {
+			status = scanner.AnalysisStatusFinished
+		}
+
+		if a.status.ScanStatus[i].Status != status {
+			a.status.ScanStatus[i].Status = status
+			a.status.ScanStatus[i].UpdatedAt = t
+		}
+	}
+}
+
+// finish completes the analysis according to its project's outcome
+func (s *Server) finish(a *analysisRecord) {
+	t := now()
+	outcome := s.outcomes[a.status.ProjectID]
+
+	status := scanner.AnalysisStatusFinished
+	a.status.Message = "Analysis has completed."
+	if outcome.Error != "" {
+		status = scanner.AnalysisStatusErrored
+		a.status.Message = outcome.Error
+	}
+
+	a.status.Status = status
+	a.status.UpdatedAt = t
+
+	for i := range a.status.ScanStatus {
+		if a.status.ScanStatus[i].Status != scanner.AnalysisStatusFinished {
+			a.status.ScanStatus[i].Status = status
+			a.status.ScanStatus[i].UpdatedAt = t
+		}
+	}
+
+	if status == scanner.AnalysisStatusErrored {
+		return
+	}
+
+	p := s.findProject(a.status.ProjectID, a.status.TeamID)
+	if p == nil {
+		p = &projects.Project{}
+	}
+
+	rs := s.findRuleSet(value(p.RulesetID), value(p.TeamID))
+	if rs == nil {
+		rs = &rulesets.RuleSet{ID: value(p.RulesetID)}
+	}
+
+	failed := make(map[string]bool)
+	for _, id := range outcome.FailedRules {
+		failed[id] = true
+	}
+
+	ruleIDs := append([]string{}, rs.RuleIDs...)
+	for _, id := range outcome.FailedRules {
+		if !contains(ruleIDs, id) {
+			ruleIDs = append(ruleIDs, id)
+		}
+	}
+
+	var results []scans.Evaluation
+	for _, id := range ruleIDs {
+		e, err := s.evaluate(a, s.rule(id), !failed[id])
+		if err == nil {
+			results = append(results, e)
+		}
+	}
+
+	passed := len(failed) == 0
+	risk, summary := "low", "pass"
+	if !passed {
+		risk, summary = "high", "fail"
+	}
+
+	a.analysis = &analyses.Analysis{
+		Summary: analyses.Summary{
+			ID:          a.status.ID,
+			AnalysisID:  a.status.ID,
+			TeamID:      a.status.TeamID,
+			ProjectID:   a.status.ProjectID,
+			Name:        value(p.Name),
+			Type:        value(p.Type),
+			Source:      value(p.Source),
+			Branch:      a.status.Branch,
+			Description: value(p.Description),
+			Risk:        risk,
+			Summary:     summary,
+			Passed:      passed,
+			RulesetID:   rs.ID,
+			RulesetName: rs.Name,
+			Status:      scanner.AnalysisStatusFinished,
+			CreatedAt:   a.status.CreatedAt,
+			UpdatedAt:   t,
+			Duration:    float64(t.Sub(a.status.CreatedAt)) / float64(time.Millisecond),
+		},
+	}
+
+	a.applied = &rulesets.AppliedRulesetSummary{
+		ProjectID:   a.status.ProjectID,
+		TeamID:      a.status.TeamID,
+		AnalysisID:  a.status.ID,
+		RulesetID:   rs.ID,
+		RulesetName: rs.Name,
+		RuleEvaluationSummary: &rulesets.RuleEvaluationSummary{
+			RulesetName: rs.Name,
+			Summary:     summary,
+			Risk:        risk,
+			Passed:      passed,
+			Ruleresults: results,
+		},
+		CreatedAt: t,
+		UpdatedAt: t,
+	}
+}
+
+// evaluate returns the evaluation of the rule for the analysis
+func (s *Server) evaluate(a *analysisRecord, r rules.Rule, passed bool) (scans.Evaluation, error) {
+	resultType := r.ScanType
+	if !resultTypes[resultType] {
+		resultType = "about_yml"
+	}
+
+	risk, summary := "low", "pass"
+	if !passed {
+		risk, summary = "high", "fail"
+	}
+
+	e := map[string]interface{}{
+		"id":          s.newID(),
+		"team_id":     a.status.TeamID,
+		"project_id":  a.status.ProjectID,
+		"analysis_id": a.status.ID,
+		"rule_id":     r.ID,
+		"name":        r.Name,
+		"description": r.Description,
+		"summary":     summary,
+		"risk":        risk,
+		"type":        "Not Applicable",
+		"passed":      passed,
+		"results":     map[string]interface{}{"type": resultType, "data": map[string]interface{}{}},
+		"created_at":  a.status.UpdatedAt,
+		"updated_at":  a.status.UpdatedAt,
+	}
+
+	var eval scans.Evaluation
+	b, err := json.Marshal(e)
+	if err != nil {
+		return eval, err
+	}
+
+	err = json.Unmarshal(b, &eval)
+	return eval, err
+}
+
+func contains(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) findAnalysis(id, teamID string) *analysisRecord {
+	for _, a := range s.analyses {
+		if a.status.ID == id && a.status.TeamID == teamID {
+			return a
+		}
+	}
+
+	return nil
+}
+
+// latest returns the most recently started analysis of the project.  If
+// finished is true, only finished analyses are considered.
+func (s *Server) latest(projectID, teamID string, finished bool) *analysisRecord {
+	for i := len(s.analyses) - 1; i >= 0; i-- {
+		a := s.analyses[i]
+		if a.status.ProjectID != projectID || a.status.TeamID != teamID {
+			continue
+		}
+
+		if !finished || a.analysis != nil {
+			return a
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) routeAnalyses(mux *http.ServeMux) {
+	s.handle(mux, scanner.ScannerAnalyzeProjectEndpoint, s.analyzeProject, http.MethodPost)
+	s.handle(mux, scanner.ScannerGetAnalysisStatusEndpoint, s.getAnalysisStatus, http.MethodGet)
+	s.handle(mux, scanner.ScannerGetLatestAnalysisStatusEndpoint, s.getLatestAnalysisStatus, http.MethodGet)
+	s.handle(mux, scanner.ScannerGetLatestAnalysisStatusesEndpoint, s.getLatestAnalysisStatuses, http.MethodGet)
+
+	s.handle(mux, analyses.AnalysisGetAnalysisEndpoint, s.getAnalysis, http.MethodGet)
+	s.handle(mux, analyses.AnalysisGetAnalysesEndpoint, s.getAnalyses, http.MethodGet)
+	s.handle(mux, analyses.AnalysisGetLatestAnalysisEndpoint, s.getLatestAnalysis, http.MethodGet)
+	s.handle(mux, analyses.AnalysisGetLatestAnalysisSummaryEndpoint, s.getLatestAnalysisSummary, http.MethodGet)
+	s.handle(mux, analyses.AnalysisGetLatestAnalysisIDsEndpoint, s.getLatestAnalysisIDs, http.MethodPost)
+	s.handle(mux, analyses.AnalysisGetLatestAnalysisSummariesEndpoint, s.getLatestAnalysisSummaries, http.MethodPost)
+
+	s.handle(mux, reports.ReportGetAnalysisReportEndpoint, s.getAnalysisReport, http.MethodGet)
+	s.handle(mux, reports.ReportGetProjectReportEndpoint, s.getProjectReport, http.MethodGet)
+}
+
+func (s *Server) analyzeProject(w http.ResponseWriter, r *http.Request) {
+	var req scanner.AnalyzeRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.TeamID == "" {
+		writeError(w, http.StatusBadRequest, "missing required fields", map[string]string{"team_id": "missing team id"})
+		return
+	}
+
+	if req.ProjectID != "" {
+		p := s.findProject(req.ProjectID, req.TeamID)
+		if p == nil {
+			writeNotFound(w, "project", req.ProjectID)
+			return
+		}
+
+		a := s.startAnalysis(p, req.Branch)
+		writeItem(w, a.status)
+		return
+	}
+
+	// without a project, every active project of the team is analyzed
+	var filter *projects.Filter
+	if f := r.URL.Query().Get("filter_by"); f != "" {
+		filter = projects.ParseParam(f)
+	}
+
+	ids := []string{}
+	for _, p := range s.teamProjects(req.TeamID) {
+		if !p.Active || (filter != nil && !matches(filter, p)) {
+			continue
+		}
+
+		a := s.startAnalysis(p, "")
+		ids = append(ids, a.status.ID)
+	}
+
+	writeData(w, ids, responses.Meta{TotalCount: len(ids)})
+}
+
+func (s *Server) getAnalysisStatus(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "id", "team_id", "project_id")
+	if !ok {
+		return
+	}
+
+	a := s.findAnalysis(v[0], v[1])
+	if a == nil || a.status.ProjectID != v[2] {
+		writeNotFound(w, "analysis", v[0])
+		return
+	}
+
+	s.poll(a)
+	writeItem(w, a.status)
+}
+
+func (s *Server) getLatestAnalysisStatus(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "team_id", "project_id")
+	if !ok {
+		return
+	}
+
+	a := s.latest(v[1], v[0], false)
+	if a == nil {
+		writeNotFound(w, "analysis for project", v[1])
+		return
+	}
+
+	s.poll(a)
+	writeItem(w, a.status)
+}
+
+func (s *Server) getLatestAnalysisStatuses(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "team_id")
+	if !ok {
+		return
+	}
+
+	statuses := []scanner.AnalysisStatus{}
+	for _, p := range s.teamProjects(v[0]) {
+		a := s.latest(value(p.ID), v[0], false)
+		if a != nil {
+			s.poll(a)
+			statuses = append(statuses, a.status)
+		}
+	}
+
+	writeData(w, statuses, responses.Meta{TotalCount: len(statuses)})
+}
+
+func (s *Server) getAnalysesStatuses(w http.ResponseWriter, r *http.Request) {
+	var req requests.ByIDsAndTeamID
+	if !decode(w, r, &req) {
+		return
+	}
+
+	statuses := []rulesets.Status{}
+	for _, id := range req.IDs {
+		a := s.findAnalysis(id, req.TeamID)
+		if a == nil {
+			continue
+		}
+
+		s.poll(a)
+
+		status := a.status.Status
+		if a.analysis != nil {
+			status = scanner.AnalysisStatusFailed
+			if a.analysis.Passed {
+				status = scanner.AnalysisStatusPassed
+			}
+		}
+
+		statuses = append(statuses, rulesets.Status{AnalysisID: id, ProjectID: a.status.ProjectID, Status: status})
+	}
+
+	writeData(w, statuses, responses.Meta{TotalCount: len(statuses)})
+}
+
+func (s *Server) getAnalysis(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "id", "team_id", "project_id")
+	if !ok {
+		return
+	}
+
+	a := s.findAnalysis(v[0], v[1])
+	if a == nil || a.analysis == nil || a.status.ProjectID != v[2] {
+		writeNotFound(w, "analysis", v[0])
+		return
+	}
+
+	writeItem(w, a.analysis)
+}
+
+func (s *Server) getAnalyses(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "team_id", "project_id")
+	if !ok {
+		return
+	}
+
+	as := []*analyses.Analysis{}
+	for i := len(s.analyses) - 1; i >= 0; i-- {
+		a := s.analyses[i]
+		if a.analysis != nil && a.status.TeamID == v[0] && a.status.ProjectID == v[1] {
+			as = append(as, a.analysis)
+		}
+	}
+
+	start, end, meta := page(r, len(as))
+	writeData(w, as[start:end], meta)
+}
+
+func (s *Server) getLatestAnalysis(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "team_id", "project_id")
+	if !ok {
+		return
+	}
+
+	a := s.latest(v[1], v[0], true)
+	if a == nil {
+		writeNotFound(w, "analysis for project", v[1])
+		return
+	}
+
+	writeItem(w, a.analysis)
+}
+
+func (s *Server) getLatestAnalysisSummary(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "team_id", "project_id")
+	if !ok {
+		return
+	}
+
+	a := s.latest(v[1], v[0], true)
+	if a == nil {
+		writeNotFound(w, "analysis for project", v[1])
+		return
+	}
+
+	writeItem(w, a.analysis.Summary)
+}
+
+func (s *Server) getLatestAnalysisIDs(w http.ResponseWriter, r *http.Request) {
+	var req requests.ByIDsAndTeamID
+	if !decode(w, r, &req) {
+		return
+	}
+
+	ids := make(map[string]string)
+	for _, id := range req.IDs {
+		a := s.latest(id, req.TeamID, true)
+		if a != nil {
+			ids[id] = a.status.ID
+		}
+	}
+
+	writeData(w, ids, responses.Meta{TotalCount: len(ids)})
+}
+
+func (s *Server) getLatestAnalysisSummaries(w http.ResponseWriter, r *http.Request) {
+	var req requests.ByIDsAndTeamID
+	if !decode(w, r, &req) {
+		return
+	}
+
+	summaries := []analyses.Summary{}
+	for _, id := range req.IDs {
+		a := s.latest(id, req.TeamID, true)
+		if a != nil {
+			summaries = append(summaries, a.analysis.Summary)
+		}
+	}
+
+	writeData(w, summaries, responses.Meta{TotalCount: len(summaries)})
+}
+
+func (s *Server) getAppliedRuleSet(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "project_id", "team_id")
+	if !ok {
+		return
+	}
+
+	var a *analysisRecord
+	if id := r.URL.Query().Get("analysis_id"); id != "" {
+		a = s.findAnalysis(id, v[1])
+	} else {
+		a = s.latest(v[0], v[1], true)
+	}
+
+	if a == nil || a.applied == nil || a.status.ProjectID != v[0] {
+		writeNotFound(w, "applied ruleset for project", v[0])
+		return
+	}
+
+	writeItem(w, a.applied)
+}
+
+func (s *Server) getAppliedRuleSets(w http.ResponseWriter, r *http.Request) {
+	var req []rulesets.AppliedRulesetRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	applied := []*rulesets.AppliedRulesetSummary{}
+	for _, ar := range req {
+		var a *analysisRecord
+		if ar.AnalysisID != "" {
+			a = s.findAnalysis(ar.AnalysisID, ar.TeamID)
+		} else {
+			a = s.latest(ar.ProjectID, ar.TeamID, true)
+		}
+
+		if a != nil && a.applied != nil && a.status.ProjectID == ar.ProjectID {
+			applied = append(applied, a.applied)
+		}
+	}
+
+	writeData(w, applied, responses.Meta{TotalCount: len(applied)})
+}
+
+func (s *Server) getAnalysisReport(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "analysis_id", "team_id", "project_id")
+	if !ok {
+		return
+	}
+
+	a := s.findAnalysis(v[0], v[1])
+	if a == nil || a.status.ProjectID != v[2] {
+		writeNotFound(w, "analysis", v[0])
+		return
+	}
+
+	// the report marks its analysis as passed or failed, so it is given a
+	// copy of the stored analysis
+	status := a.status
+	var analysis *analyses.Analysis
+	if a.analysis != nil {
+		c := *a.analysis
+		analysis = &c
+	}
+
+	report, err := reports.NewAnalysisReport(&status, analysis, a.applied, false)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+
+	writeItem(w, report)
+}
+
+func (s *Server) getProjectReport(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "project_id", "team_id")
+	if !ok {
+		return
+	}
+
+	p := s.findProject(v[0], v[1])
+	if p == nil {
+		writeNotFound(w, "project", v[0])
+		return
+	}
+
+	summaries := []analyses.Summary{}
+	for i := len(s.analyses) - 1; i >= 0; i-- {
+		a := s.analyses[i]
+		if a.analysis != nil && a.status.ProjectID == v[0] && a.status.TeamID == v[1] {
+			summaries = append(summaries, a.analysis.Summary)
+		}
+	}
+
+	c := *p
+	report := reports.NewProjectReport(&c, summaries)
+	if rs := s.findRuleSet(value(p.RulesetID), v[1]); rs != nil {
+		report.RulesetName = rs.Name
+	}
+
+	writeItem(w, report)
+}
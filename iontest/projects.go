@@ -0,0 +1,315 @@
+package iontest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ion-channel/ionic/projects"
+	"github.com/ion-channel/ionic/requests"
+	"github.com/ion-channel/ionic/responses"
+)
+
+var projectTypes = map[string]bool{
+	"artifact":           true,
+	"docker":             true,
+	"git":                true,
+	"s3":                 true,
+	"source_unavailable": true,
+	"svn":                true,
+}
+
+// AddProject adds a project to the server's state, assigning it an ID if it
+// does not have one.  It returns the project as stored.  Unlike creating a
+// project through the API, the project is not validated.
+func (s *Server) AddProject(project projects.Project) projects.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.addProject(project)
+}
+
+func (s *Server) addProject(project projects.Project) *projects.Project {
+	if project.ID == nil || *project.ID == "" {
+		id := s.newID()
+		project.ID = &id
+	}
+
+	if project.CreatedAt.IsZero() {
+		project.CreatedAt = now()
+		project.UpdatedAt = project.CreatedAt
+	}
+
+	p := &project
+	s.projects = append(s.projects, p)
+	return p
+}
+
+func (s *Server) findProject(id, teamID string) *projects.Project {
+	for _, p := range s.projects {
+		if value(p.ID) == id && value(p.TeamID) == teamID {
+			return p
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) teamProjects(teamID string) []*projects.Project {
+	ps := []*projects.Project{}
+	for _, p := range s.projects {
+		if value(p.TeamID) == teamID {
+			ps = append(ps, p)
+		}
+	}
+
+	return ps
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func (s *Server) routeProjects(mux *http.ServeMux) {
+	s.handle(mux, projects.CreateProjectEndpoint, s.createProject, http.MethodPost)
+	s.handle(mux, projects.GetProjectEndpoint, s.getProject, http.MethodGet)
+	s.handle(mux, projects.GetProjectByURLEndpoint, s.getProjectByURL, http.MethodGet)
+	s.handle(mux, projects.GetProjectsEndpoint, s.getProjects, http.MethodGet)
+	s.handle(mux, projects.UpdateProjectEndpoint, s.updateProject, http.MethodPut)
+	s.handle(mux, projects.GetUsedRulesetIdsEndpoint, s.getUsedRulesetIDs, http.MethodGet)
+	s.handle(mux, projects.GetProjectsNamesEndpoint, s.getProjectsNames, http.MethodPost)
+}
+
+// validateProject checks the project the same way the API does, returning
+// the invalid fields
+func (s *Server) validateProject(p *projects.Project) map[string]string {
+	fields := make(map[string]string)
+
+	if value(p.TeamID) == "" {
+		fields["team_id"] = "missing team id"
+	}
+
+	if value(p.Name) == "" {
+		fields["name"] = "missing name"
+	}
+
+	if p.Description == nil {
+		fields["description"] = "missing description"
+	}
+
+	switch {
+	case value(p.RulesetID) == "":
+		fields["ruleset_id"] = "missing ruleset id"
+	case s.findRuleSet(*p.RulesetID, value(p.TeamID)) == nil:
+		fields["ruleset_id"] = "ruleset id does not match to a valid ruleset"
+	}
+
+	t := strings.ToLower(value(p.Type))
+	switch {
+	case t == "":
+		fields["type"] = "missing type"
+	case !projectTypes[t]:
+		fields["type"] = "invalid type value"
+	case t == "source_unavailable" && value(p.Source) != "":
+		fields["source"] = "source cannot be specified for this project type"
+	case t != "source_unavailable" && value(p.Source) == "":
+		fields["source"] = "missing source"
+	case t == "git" && value(p.Branch) == "":
+		fields["branch"] = "missing branch"
+	}
+
+	return fields
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	var req projects.Project
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if teamID := r.URL.Query().Get("team_id"); teamID != "" {
+		req.TeamID = &teamID
+	}
+
+	fields := s.validateProject(&req)
+	if len(fields) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "invalid project", fields)
+		return
+	}
+
+	req.ID = nil
+	req.CreatedAt = now()
+	req.UpdatedAt = req.CreatedAt
+
+	p := s.addProject(req)
+	writeItem(w, p)
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "id", "team_id")
+	if !ok {
+		return
+	}
+
+	p := s.findProject(v[0], v[1])
+	if p == nil {
+		writeNotFound(w, "project", v[0])
+		return
+	}
+
+	writeItem(w, p)
+}
+
+func (s *Server) getProjectByURL(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "url", "team_id")
+	if !ok {
+		return
+	}
+
+	for _, p := range s.teamProjects(v[1]) {
+		if value(p.Source) == v[0] {
+			writeItem(w, p)
+			return
+		}
+	}
+
+	writeNotFound(w, "project with url", v[0])
+}
+
+func (s *Server) getProjects(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "team_id")
+	if !ok {
+		return
+	}
+
+	ps := s.teamProjects(v[0])
+
+	if f := r.URL.Query().Get("filter_by"); f != "" {
+		filter := projects.ParseParam(f)
+
+		matched := []*projects.Project{}
+		for _, p := range ps {
+			if matches(filter, p) {
+				matched = append(matched, p)
+			}
+		}
+
+		ps = matched
+	}
+
+	start, end, meta := page(r, len(ps))
+	writeData(w, ps[start:end], meta)
+}
+
+// matches reports whether the project satisfies every field set in the
+// filter
+func matches(f *projects.Filter, p *projects.Project) bool {
+	if f.ID != nil && *f.ID != value(p.ID) {
+		return false
+	}
+
+	if f.IDs != nil {
+		found := false
+		for _, id := range *f.IDs {
+			if id == value(p.ID) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if f.Source != nil && *f.Source != value(p.Source) {
+		return false
+	}
+
+	if f.Type != nil && !strings.EqualFold(*f.Type, value(p.Type)) {
+		return false
+	}
+
+	if f.Active != nil && *f.Active != p.Active {
+		return false
+	}
+
+	if f.Monitor != nil && *f.Monitor != p.Monitor {
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "id", "team_id")
+	if !ok {
+		return
+	}
+
+	var req projects.Project
+	if !decode(w, r, &req) {
+		return
+	}
+
+	p := s.findProject(v[0], v[1])
+	if p == nil {
+		writeNotFound(w, "project", v[0])
+		return
+	}
+
+	req.ID = p.ID
+	req.TeamID = p.TeamID
+	req.CreatedAt = p.CreatedAt
+
+	fields := s.validateProject(&req)
+	if len(fields) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "invalid project", fields)
+		return
+	}
+
+	req.UpdatedAt = now()
+	*p = req
+
+	writeItem(w, p)
+}
+
+func (s *Server) getUsedRulesetIDs(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "team_id")
+	if !ok {
+		return
+	}
+
+	seen := make(map[string]bool)
+	ids := []projects.RulesetID{}
+
+	for _, p := range s.teamProjects(v[0]) {
+		id := value(p.RulesetID)
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, projects.RulesetID{RulesetID: id})
+		}
+	}
+
+	writeData(w, ids, responses.Meta{TotalCount: len(ids)})
+}
+
+func (s *Server) getProjectsNames(w http.ResponseWriter, r *http.Request) {
+	var req requests.ByIDsAndTeamID
+	if !decode(w, r, &req) {
+		return
+	}
+
+	names := []projects.Name{}
+	for _, id := range req.IDs {
+		p := s.findProject(id, req.TeamID)
+		if p != nil {
+			names = append(names, projects.Name{ID: id, Name: value(p.Name)})
+		}
+	}
+
+	writeData(w, names, responses.Meta{TotalCount: len(names)})
+}
@@ -0,0 +1,184 @@
+package iontest
+
+import (
+	"net/http"
+
+	"github.com/ion-channel/ionic/requests"
+	"github.com/ion-channel/ionic/responses"
+	"github.com/ion-channel/ionic/rules"
+	"github.com/ion-channel/ionic/rulesets"
+)
+
+// AddRule adds a rule to the server's catalog of rules, assigning it an ID if
+// it does not have one.  Rulesets created with the rule's ID include the rule,
+// and analyses evaluated against those rulesets report it by name.  It returns
+// the rule as stored.
+func (s *Server) AddRule(rule rules.Rule) rules.Rule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rule.ID == "" {
+		rule.ID = s.newID()
+	}
+
+	if rule.CreatedAt.IsZero() {
+		rule.CreatedAt = now()
+		rule.UpdatedAt = rule.CreatedAt
+	}
+
+	s.rules = append(s.rules, rule)
+	return rule
+}
+
+// AddRuleSet adds a ruleset to the server's state, assigning it an ID if it
+// does not have one.  Any rules from the catalog named by its rule IDs are
+// included with it.  It returns the ruleset as stored.
+func (s *Server) AddRuleSet(ruleset rulesets.RuleSet) rulesets.RuleSet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.addRuleSet(ruleset)
+}
+
+func (s *Server) addRuleSet(ruleset rulesets.RuleSet) *rulesets.RuleSet {
+	if ruleset.ID == "" {
+		ruleset.ID = s.newID()
+	}
+
+	if ruleset.CreatedAt.IsZero() {
+		ruleset.CreatedAt = now()
+		ruleset.UpdatedAt = ruleset.CreatedAt
+	}
+
+	if len(ruleset.Rules) == 0 {
+		for _, id := range ruleset.RuleIDs {
+			ruleset.Rules = append(ruleset.Rules, s.rule(id))
+		}
+	}
+
+	rs := &ruleset
+	s.rulesets = append(s.rulesets, rs)
+	return rs
+}
+
+// rule returns the rule with the given ID from the catalog, or a rule named
+// after the ID if the catalog does not have it
+func (s *Server) rule(id string) rules.Rule {
+	for _, r := range s.rules {
+		if r.ID == id {
+			return r
+		}
+	}
+
+	return rules.Rule{ID: id, Name: id}
+}
+
+func (s *Server) findRuleSet(id, teamID string) *rulesets.RuleSet {
+	for _, rs := range s.rulesets {
+		if rs.ID == id && rs.TeamID == teamID {
+			return rs
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) routeRuleSets(mux *http.ServeMux) {
+	s.handle(mux, rulesets.CreateRuleSetEndpoint, s.createRuleSet, http.MethodPost)
+	s.handle(mux, rulesets.GetRuleSetEndpoint, s.getRuleSet, http.MethodGet)
+	s.handle(mux, rulesets.GetRuleSetsEndpoint, s.getRuleSets, http.MethodGet)
+	s.handle(mux, rulesets.RulesetsGetRulesEndpoint, s.getRules, http.MethodGet)
+	s.handle(mux, rulesets.RulesetsGetRulesetNames, s.getRuleSetNames, http.MethodPost)
+	s.handle(mux, rulesets.GetAppliedRuleSetEndpoint, s.getAppliedRuleSet, http.MethodGet)
+	s.handle(mux, rulesets.GetBatchAppliedRulesetEndpoint, s.getAppliedRuleSets, http.MethodPost)
+	s.handle(mux, rulesets.GetRulesetAnalysesStatuses, s.getAnalysesStatuses, http.MethodPost)
+}
+
+func (s *Server) createRuleSet(w http.ResponseWriter, r *http.Request) {
+	var req rulesets.CreateRuleSetOptions
+	if !decode(w, r, &req) {
+		return
+	}
+
+	fields := make(map[string]string)
+	if req.TeamID == "" {
+		fields["team_id"] = "missing team id"
+	}
+
+	if req.Name == "" {
+		fields["name"] = "missing name"
+	}
+
+	if len(req.RuleIDs) == 0 {
+		fields["rule_ids"] = "missing rule ids"
+	}
+
+	if len(fields) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "invalid ruleset", fields)
+		return
+	}
+
+	rs := s.addRuleSet(rulesets.RuleSet{
+		TeamID:      req.TeamID,
+		Name:        req.Name,
+		Description: req.Description,
+		RuleIDs:     req.RuleIDs,
+	})
+
+	writeItem(w, rs)
+}
+
+func (s *Server) getRuleSet(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "id", "team_id")
+	if !ok {
+		return
+	}
+
+	rs := s.findRuleSet(v[0], v[1])
+	if rs == nil {
+		writeNotFound(w, "ruleset", v[0])
+		return
+	}
+
+	writeItem(w, rs)
+}
+
+func (s *Server) getRuleSets(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "team_id")
+	if !ok {
+		return
+	}
+
+	rss := []*rulesets.RuleSet{}
+	for _, rs := range s.rulesets {
+		if rs.TeamID == v[0] {
+			rss = append(rss, rs)
+		}
+	}
+
+	start, end, meta := page(r, len(rss))
+	writeData(w, rss[start:end], meta)
+}
+
+func (s *Server) getRules(w http.ResponseWriter, r *http.Request) {
+	start, end, meta := page(r, len(s.rules))
+	writeData(w, append([]rules.Rule{}, s.rules[start:end]...), meta)
+}
+
+func (s *Server) getRuleSetNames(w http.ResponseWriter, r *http.Request) {
+	var req requests.ByIDs
+	if !decode(w, r, &req) {
+		return
+	}
+
+	names := []rulesets.NameForID{}
+	for _, id := range req.IDs {
+		for _, rs := range s.rulesets {
+			if rs.ID == id {
+				names = append(names, rulesets.NameForID{ID: rs.ID, Name: rs.Name, TeamID: rs.TeamID})
+			}
+		}
+	}
+
+	writeData(w, names, responses.Meta{TotalCount: len(names)})
+}
@@ -0,0 +1,241 @@
+/*
+Package iontest provides an in-memory fake of the Ion Channel API for testing
+code built on the SDK without a network connection.
+
+The fake keeps the teams, projects, tags, rulesets, and analyses created
+through it, serves them back from the endpoints named by the SDK's endpoint
+constants, and wraps every response the same way the API does.  Analyses
+started through the fake progress from queued to analyzing to finished as
+their status is polled, so whole flows such as creating a project, analyzing
+it, waiting on it, and fetching its report can be exercised offline.
+
+	server := iontest.NewServer()
+	defer server.Close()
+
+	client, _ := ionic.New(server.URL)
+*/
+package iontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ion-channel/ionic/pagination"
+	"github.com/ion-channel/ionic/projects"
+	"github.com/ion-channel/ionic/responses"
+	"github.com/ion-channel/ionic/rules"
+	"github.com/ion-channel/ionic/rulesets"
+	"github.com/ion-channel/ionic/tags"
+	"github.com/ion-channel/ionic/teams"
+)
+
+const (
+	// DefaultPollsToFinish is the number of times an analysis's status is
+	// polled before the analysis finishes, unless the server is configured
+	// otherwise
+	DefaultPollsToFinish = 2
+)
+
+// Server is a fake of the Ion Channel API backed by in-memory state.  It is
+// safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the server, suitable for creating a client
+	URL string
+
+	// Token is the bearer token every request must carry.  If empty, requests
+	// are not authenticated.
+	Token string
+
+	// PollsToFinish is the number of times an analysis's status is polled
+	// before the analysis finishes.  An analysis is queued when it is
+	// started, analyzing once it has been polled, and finished once it has
+	// been polled PollsToFinish times.  A value of zero or less finishes
+	// analyses as soon as they are started.
+	PollsToFinish int
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	seq      int
+	hits     map[string]int
+	teams    []*teams.Team
+	projects []*projects.Project
+	tags     []*tags.Tag
+	rules    []rules.Rule
+	rulesets []*rulesets.RuleSet
+	analyses []*analysisRecord
+	outcomes map[string]Outcome
+}
+
+// NewServer starts and returns a new fake server with no state.  The server
+// should be closed when it is no longer needed.
+func NewServer() *Server {
+	s := &Server{
+		PollsToFinish: DefaultPollsToFinish,
+		hits:          make(map[string]int),
+		outcomes:      make(map[string]Outcome),
+	}
+
+	mux := http.NewServeMux()
+	s.routeTeams(mux)
+	s.routeProjects(mux)
+	s.routeTags(mux)
+	s.routeRuleSets(mux)
+	s.routeAnalyses(mux)
+
+	s.server = httptest.NewServer(mux)
+	s.URL = s.server.URL
+
+	return s
+}
+
+// Close shuts down the server and blocks until all outstanding requests have
+// completed
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Hits returns the number of requests the server has received for the given
+// endpoint, such as projects.GetProjectEndpoint
+func (s *Server) Hits(endpoint string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.hits[strings.TrimPrefix(endpoint, "/")]
+}
+
+// handlerFunc handles a request to an endpoint while the server's lock is
+// held
+type handlerFunc func(w http.ResponseWriter, r *http.Request)
+
+// handle registers a handler for an endpoint accepting the given methods.
+// The handler is only called for authenticated requests using one of the
+// methods, and is called with the server's lock held.
+func (s *Server) handle(mux *http.ServeMux, endpoint string, h handlerFunc, methods ...string) {
+	mux.HandleFunc("/"+endpoint, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.hits[endpoint]++
+
+		if !allowed(r.Method, methods) {
+			w.Header().Set("Allow", strings.Join(methods, ", "))
+			writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %v not allowed", r.Method), nil)
+			return
+		}
+
+		if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+			writeError(w, http.StatusUnauthorized, "invalid or missing token", nil)
+			return
+		}
+
+		h(w, r)
+	})
+}
+
+func allowed(method string, methods []string) bool {
+	for _, m := range methods {
+		if method == m || (method == http.MethodHead && m == http.MethodGet) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newID returns a new unique ID in the format of the IDs used by the API
+func (s *Server) newID() string {
+	s.seq++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.seq)
+}
+
+func now() time.Time {
+	return time.Now().UTC()
+}
+
+// writeData writes the data wrapped in a response with the given metadata
+func writeData(w http.ResponseWriter, data interface{}, meta responses.Meta) {
+	resp, err := responses.NewResponse(data, meta, http.StatusOK)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+
+	resp.WriteResponse(w)
+}
+
+// writeItem writes a single item wrapped in a response
+func writeItem(w http.ResponseWriter, item interface{}) {
+	writeData(w, item, responses.Meta{TotalCount: 1})
+}
+
+// writeError writes an error response the same way the API does
+func writeError(w http.ResponseWriter, status int, message string, fields map[string]string) {
+	responses.NewErrorResponse(message, fields, status).WriteResponse(w)
+}
+
+func writeNotFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, fmt.Sprintf("%v %v not found", kind, id), nil)
+}
+
+// required returns the values of the named query params, writing a bad
+// request response and returning false if any are missing
+func required(w http.ResponseWriter, r *http.Request, names ...string) ([]string, bool) {
+	values := make([]string, len(names))
+	fields := make(map[string]string)
+
+	for i, name := range names {
+		values[i] = r.URL.Query().Get(name)
+		if values[i] == "" {
+			fields[name] = "missing " + strings.Replace(name, "_", " ", -1)
+		}
+	}
+
+	if len(fields) > 0 {
+		writeError(w, http.StatusBadRequest, "missing required params", fields)
+		return nil, false
+	}
+
+	return values, true
+}
+
+// decode reads the JSON body of the request into v, writing a bad request
+// response and returning false if it cannot be read
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("failed to read request body: %v", err.Error()), nil)
+		return false
+	}
+
+	return true
+}
+
+// page returns the bounds of the requested page of a list with the given
+// number of items, along with the metadata describing the page.  Requests
+// without pagination params receive every item.
+func page(r *http.Request, total int) (int, int, responses.Meta) {
+	q := r.URL.Query()
+	if q.Get("offset") == "" && q.Get("limit") == "" {
+		return 0, total, responses.Meta{TotalCount: total}
+	}
+
+	p := pagination.ParseFromRequest(r)
+
+	start := p.Offset
+	if start > total {
+		start = total
+	}
+
+	end := start + p.Limit
+	if end > total {
+		end = total
+	}
+
+	return start, end, responses.Meta{TotalCount: total, Limit: p.Limit, Offset: p.Offset}
+}
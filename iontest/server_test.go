@@ -0,0 +1,199 @@
+package iontest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic"
+	"github.com/ion-channel/ionic/errors"
+	"github.com/ion-channel/ionic/pagination"
+	"github.com/ion-channel/ionic/projects"
+	"github.com/ion-channel/ionic/rules"
+	"github.com/ion-channel/ionic/rulesets"
+	"github.com/ion-channel/ionic/scanner"
+	"github.com/ion-channel/ionic/teams"
+	. "github.com/onsi/gomega"
+)
+
+func TestServer(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Server", func() {
+		var server *Server
+		var client *ionic.IonClient
+		var team teams.Team
+		var ruleset rulesets.RuleSet
+
+		g.BeforeEach(func() {
+			server = NewServer()
+			server.Token = "sometoken"
+			client, _ = ionic.New(server.URL)
+
+			team = server.AddTeam(teams.Team{Name: "someteam"})
+			rule := server.AddRule(rules.Rule{Name: "No critical vulnerabilities", ScanType: "vulnerability"})
+			ruleset = server.AddRuleSet(rulesets.RuleSet{TeamID: team.ID, Name: "someruleset", RuleIDs: []string{rule.ID}})
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+		})
+
+		newProject := func(name string) *projects.Project {
+			typ, source, branch, desc := "git", "git@github.com:ion-channel/"+name+".git", "master", "a project"
+			return &projects.Project{
+				TeamID:      &team.ID,
+				RulesetID:   &ruleset.ID,
+				Name:        &name,
+				Type:        &typ,
+				Source:      &source,
+				Branch:      &branch,
+				Description: &desc,
+				Active:      true,
+			}
+		}
+
+		g.It("should support a project through analysis to its report", func() {
+			p, err := client.CreateProject(newProject("ionic"), team.ID, "sometoken")
+			Expect(err).To(BeNil())
+			Expect(*p.ID).NotTo(BeEmpty())
+
+			status, err := client.AnalyzeProject(*p.ID, team.ID, "", "sometoken")
+			Expect(err).To(BeNil())
+			Expect(status.Status).To(Equal(scanner.AnalysisStatusQueued))
+			Expect(status.ScanStatus).To(HaveLen(1))
+
+			status, err = client.GetAnalysisStatus(status.ID, team.ID, *p.ID, "sometoken")
+			Expect(err).To(BeNil())
+			Expect(status.Status).To(Equal(scanner.AnalysisStatusAnalyzing))
+
+			status, err = client.GetAnalysisStatus(status.ID, team.ID, *p.ID, "sometoken")
+			Expect(err).To(BeNil())
+			Expect(status.Done()).To(BeTrue())
+			Expect(status.Status).To(Equal(scanner.AnalysisStatusFinished))
+
+			report, err := client.GetAnalysisReport(status.ID, team.ID, *p.ID, "sometoken")
+			Expect(err).To(BeNil())
+			Expect(report.Analysis.Passed).To(BeTrue())
+			Expect(report.Analysis.Status).To(Equal(scanner.AnalysisStatusPassed))
+
+			applied, err := client.GetAppliedRuleSet(*p.ID, team.ID, status.ID, "sometoken")
+			Expect(err).To(BeNil())
+			Expect(applied.RuleEvaluationSummary.Ruleresults).To(HaveLen(1))
+			Expect(applied.RuleEvaluationSummary.Ruleresults[0].Name).To(Equal("No critical vulnerabilities"))
+
+			projectReport, err := client.GetProjectReport(*p.ID, team.ID, "sometoken")
+			Expect(err).To(BeNil())
+			Expect(projectReport.RulesetName).To(Equal("someruleset"))
+			Expect(projectReport.AnalysisSummaries).To(HaveLen(1))
+		})
+
+		g.It("should fail analyses according to the outcome of the project", func() {
+			p := server.AddProject(*newProject("ionic"))
+			server.SetOutcome(*p.ID, Outcome{FailedRules: ruleset.RuleIDs})
+			server.PollsToFinish = 0
+
+			status, err := client.AnalyzeProject(*p.ID, team.ID, "", "sometoken")
+			Expect(err).To(BeNil())
+			Expect(status.Status).To(Equal(scanner.AnalysisStatusFinished))
+
+			a, err := client.GetLatestAnalysis(team.ID, *p.ID, "sometoken")
+			Expect(err).To(BeNil())
+			Expect(a.Passed).To(BeFalse())
+			Expect(a.RulesetName).To(Equal("someruleset"))
+		})
+
+		g.It("should error analyses according to the outcome of the project", func() {
+			p := server.AddProject(*newProject("ionic"))
+			server.SetOutcome(*p.ID, Outcome{Error: "failed to clone"})
+
+			status, err := client.AnalyzeProject(*p.ID, team.ID, "", "sometoken")
+			Expect(err).To(BeNil())
+
+			server.FinishAnalyses()
+
+			status, err = client.GetAnalysisStatus(status.ID, team.ID, *p.ID, "sometoken")
+			Expect(err).To(BeNil())
+			Expect(status.Status).To(Equal(scanner.AnalysisStatusErrored))
+			Expect(status.Message).To(Equal("failed to clone"))
+
+			_, err = client.GetAnalysis(status.ID, team.ID, *p.ID, "sometoken")
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		g.It("should honour pagination", func() {
+			for _, name := range []string{"a", "b", "c"} {
+				server.AddProject(*newProject(name))
+			}
+
+			ps, err := client.GetProjects(team.ID, "sometoken", pagination.New(1, 1), nil)
+			Expect(err).To(BeNil())
+			Expect(ps).To(HaveLen(1))
+			Expect(*ps[0].Name).To(Equal("b"))
+
+			it := client.IterateProjects(team.ID, "sometoken", pagination.New(0, 2), nil)
+			var names []string
+			for it.Next() {
+				names = append(names, *it.Value().Name)
+			}
+
+			Expect(it.Err()).To(BeNil())
+			Expect(names).To(Equal([]string{"a", "b", "c"}))
+			Expect(server.Hits(projects.GetProjectsEndpoint)).To(Equal(3))
+		})
+
+		g.It("should filter projects", func() {
+			server.AddProject(*newProject("a"))
+			inactive := newProject("b")
+			inactive.Active = false
+			server.AddProject(*inactive)
+
+			active := true
+			ps, err := client.GetProjects(team.ID, "sometoken", nil, &projects.Filter{Active: &active})
+			Expect(err).To(BeNil())
+			Expect(ps).To(HaveLen(1))
+			Expect(*ps[0].Name).To(Equal("a"))
+		})
+
+		g.It("should return validation errors", func() {
+			p := newProject("ionic")
+			p.Name = nil
+
+			b, _ := json.Marshal(p)
+			_, err := client.Post(projects.CreateProjectEndpoint, "sometoken", nil, *bytes.NewBuffer(b), nil)
+			Expect(errors.IsValidation(err)).To(BeTrue())
+			Expect(errors.FieldsOf(err)).To(HaveKeyWithValue("name", "missing name"))
+		})
+
+		g.It("should return not found errors", func() {
+			_, err := client.GetProject("missing", team.ID, "sometoken")
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(errors.StatusOf(err)).To(Equal(http.StatusNotFound))
+		})
+
+		g.It("should reject requests without the token", func() {
+			_, err := client.GetTeams("wrongtoken")
+			Expect(errors.IsUnauthorized(err)).To(BeTrue())
+		})
+
+		g.It("should keep tags and teams", func() {
+			tag, err := client.CreateTag(team.ID, "sometag", "a tag", "sometoken")
+			Expect(err).To(BeNil())
+
+			tag, err = client.UpdateTag(tag.ID, team.ID, "othertag", "a tag", "sometoken")
+			Expect(err).To(BeNil())
+
+			ts, err := client.GetTags(team.ID, "sometoken")
+			Expect(err).To(BeNil())
+			Expect(ts).To(HaveLen(1))
+			Expect(ts[0].Name).To(Equal("othertag"))
+
+			got, err := client.GetTeam(team.ID, "sometoken")
+			Expect(err).To(BeNil())
+			Expect(got.Name).To(Equal("someteam"))
+		})
+	})
+}
@@ -0,0 +1,135 @@
+package iontest
+
+import (
+	"net/http"
+
+	"github.com/ion-channel/ionic/tags"
+)
+
+// AddTag adds a tag to the server's state, assigning it an ID if it does not
+// have one.  It returns the tag as stored.
+func (s *Server) AddTag(tag tags.Tag) tags.Tag {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.addTag(tag)
+}
+
+func (s *Server) addTag(tag tags.Tag) *tags.Tag {
+	if tag.ID == "" {
+		tag.ID = s.newID()
+	}
+
+	if tag.CreatedAt.IsZero() {
+		tag.CreatedAt = now()
+		tag.UpdatedAt = tag.CreatedAt
+	}
+
+	t := &tag
+	s.tags = append(s.tags, t)
+	return t
+}
+
+func (s *Server) findTag(id, teamID string) *tags.Tag {
+	for _, t := range s.tags {
+		if t.ID == id && t.TeamID == teamID {
+			return t
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) routeTags(mux *http.ServeMux) {
+	s.handle(mux, tags.CreateTagEndpoint, s.createTag, http.MethodPost)
+	s.handle(mux, tags.GetTagEndpoint, s.getTag, http.MethodGet)
+	s.handle(mux, tags.GetTagsEndpoint, s.getTags, http.MethodGet)
+	s.handle(mux, tags.UpdateTagEndpoint, s.updateTag, http.MethodPut)
+}
+
+func validateTag(t *tags.Tag) map[string]string {
+	fields := make(map[string]string)
+
+	if t.TeamID == "" {
+		fields["team_id"] = "missing team id"
+	}
+
+	if t.Name == "" {
+		fields["name"] = "missing name"
+	}
+
+	return fields
+}
+
+func (s *Server) createTag(w http.ResponseWriter, r *http.Request) {
+	var req tags.Tag
+	if !decode(w, r, &req) {
+		return
+	}
+
+	fields := validateTag(&req)
+	if len(fields) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "invalid tag", fields)
+		return
+	}
+
+	t := s.addTag(tags.Tag{TeamID: req.TeamID, Name: req.Name, Description: req.Description})
+	writeItem(w, t)
+}
+
+func (s *Server) getTag(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "id", "team_id")
+	if !ok {
+		return
+	}
+
+	t := s.findTag(v[0], v[1])
+	if t == nil {
+		writeNotFound(w, "tag", v[0])
+		return
+	}
+
+	writeItem(w, t)
+}
+
+func (s *Server) getTags(w http.ResponseWriter, r *http.Request) {
+	v, ok := required(w, r, "team_id")
+	if !ok {
+		return
+	}
+
+	ts := []*tags.Tag{}
+	for _, t := range s.tags {
+		if t.TeamID == v[0] {
+			ts = append(ts, t)
+		}
+	}
+
+	start, end, meta := page(r, len(ts))
+	writeData(w, ts[start:end], meta)
+}
+
+func (s *Server) updateTag(w http.ResponseWriter, r *http.Request) {
+	var req tags.Tag
+	if !decode(w, r, &req) {
+		return
+	}
+
+	fields := validateTag(&req)
+	if len(fields) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "invalid tag", fields)
+		return
+	}
+
+	t := s.findTag(req.ID, req.TeamID)
+	if t == nil {
+		writeNotFound(w, "tag", req.ID)
+		return
+	}
+
+	t.Name = req.Name
+	t.Description = req.Description
+	t.UpdatedAt = now()
+
+	writeItem(w, t)
+}
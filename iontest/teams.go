@@ -0,0 +1,94 @@
+package iontest
+
+import (
+	"net/http"
+
+	"github.com/ion-channel/ionic/teams"
+)
+
+type createTeamRequest struct {
+	Name     string `json:"name"`
+	POCName  string `json:"poc_name"`
+	POCEmail string `json:"poc_email"`
+}
+
+// AddTeam adds a team to the server's state, assigning it an ID if it does
+// not have one.  It returns the team as stored.
+func (s *Server) AddTeam(team teams.Team) teams.Team {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.addTeam(team)
+}
+
+func (s *Server) addTeam(team teams.Team) *teams.Team {
+	if team.ID == "" {
+		team.ID = s.newID()
+	}
+
+	if team.CreatedAt.IsZero() {
+		team.CreatedAt = now()
+		team.UpdatedAt = team.CreatedAt
+	}
+
+	t := &team
+	s.teams = append(s.teams, t)
+	return t
+}
+
+func (s *Server) findTeam(id string) *teams.Team {
+	for _, t := range s.teams {
+		if t.ID == id {
+			return t
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) routeTeams(mux *http.ServeMux) {
+	s.handle(mux, teams.TeamsCreateTeamEndpoint, s.createTeam, http.MethodPost)
+	s.handle(mux, teams.TeamsGetTeamEndpoint, s.getTeam, http.MethodGet)
+	s.handle(mux, teams.TeamsGetTeamsEndpoint, s.getTeams, http.MethodGet)
+}
+
+func (s *Server) createTeam(w http.ResponseWriter, r *http.Request) {
+	var req createTeamRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "invalid team", map[string]string{"name": "missing name"})
+		return
+	}
+
+	t := s.addTeam(teams.Team{Name: req.Name, POCName: req.POCName, POCEmail: req.POCEmail})
+	writeItem(w, t)
+}
+
+func (s *Server) getTeam(w http.ResponseWriter, r *http.Request) {
+	// the SDK has always sent the team's ID as someid
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		id = r.URL.Query().Get("someid")
+	}
+
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "missing required params", map[string]string{"id": "missing id"})
+		return
+	}
+
+	t := s.findTeam(id)
+	if t == nil {
+		writeNotFound(w, "team", id)
+		return
+	}
+
+	writeItem(w, t)
+}
+
+func (s *Server) getTeams(w http.ResponseWriter, r *http.Request) {
+	start, end, meta := page(r, len(s.teams))
+	writeData(w, append([]*teams.Team{}, s.teams[start:end]...), meta)
+}
@@ -0,0 +1,227 @@
+package ionic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/ion-channel/ionic/analyses"
+	"github.com/ion-channel/ionic/pagination"
+	"github.com/ion-channel/ionic/products"
+	"github.com/ion-channel/ionic/projects"
+	"github.com/ion-channel/ionic/requests"
+	"github.com/ion-channel/ionic/responses"
+	"github.com/ion-channel/ionic/rulesets"
+	"github.com/ion-channel/ionic/vulnerabilities"
+)
+
+// iterator holds the behaviour shared by the typed iterators.  Each typed
+// iterator decodes the current item into its own value.
+type iterator struct {
+	it  *requests.Iterator
+	err error
+}
+
+// iterate takes an endpoint, token, params, and pagination params, and returns
+// an iterator over the items of the endpoint.
+func (ic *IonClient) iterate(endpoint, token string, params *url.Values, page *pagination.Pagination) iterator {
+	ctx := ic.Context()
+	headers := ic.headersFor(nil)
+
+	token, err := ic.tokenFor(ctx, token, headers)
+	if err != nil {
+		return iterator{err: err}
+	}
+
+	ctx = requests.WithHooks(ctx, ic.hooks...)
+
+	return iterator{it: requests.NewIterator(ctx, ic.client, ic.baseURL, endpoint, token, params, headers, page)}
+}
+
+func (i *iterator) next(v interface{}) bool {
+	if i.err != nil || !i.it.Next() {
+		return false
+	}
+
+	err := i.it.Scan(v)
+	if err != nil {
+		i.err = fmt.Errorf("failed to unmarshal item: %w", err)
+		return false
+	}
+
+	return true
+}
+
+// Err returns the error encountered while iterating, if any
+func (i *iterator) Err() error {
+	if i.err != nil {
+		return i.err
+	}
+
+	return i.it.Err()
+}
+
+// Meta returns the metadata of the most recently fetched page, or nil if no
+// page has been fetched
+func (i *iterator) Meta() *responses.Meta {
+	if i.it == nil {
+		return nil
+	}
+
+	return i.it.Meta()
+}
+
+// Raw returns the raw JSON of the current item
+func (i *iterator) Raw() json.RawMessage {
+	if i.it == nil {
+		return nil
+	}
+
+	return i.it.Value()
+}
+
+// ProjectIterator iterates over projects, fetching them a page at a time
+type ProjectIterator struct {
+	iterator
+	value projects.Project
+}
+
+// Next advances to the next project.  It returns false once there are no more
+// projects or an error has been encountered.
+func (i *ProjectIterator) Next() bool {
+	i.value = projects.Project{}
+	return i.next(&i.value)
+}
+
+// Value returns the current project
+func (i *ProjectIterator) Value() projects.Project {
+	return i.value
+}
+
+// IterateProjects takes a team ID, token, pagination params, and filter.  It
+// returns an iterator over the projects for that team.  The pagination params
+// set the offset of the first project and the size of each page fetched.
+func (ic *IonClient) IterateProjects(teamID, token string, page *pagination.Pagination, filter *projects.Filter) *ProjectIterator {
+	params := &url.Values{}
+	params.Set("team_id", teamID)
+
+	if filter != nil {
+		params.Set("filter_by", filter.Param())
+	}
+
+	return &ProjectIterator{iterator: ic.iterate(projects.GetProjectsEndpoint, token, params, page)}
+}
+
+// AnalysisIterator iterates over analyses, fetching them a page at a time
+type AnalysisIterator struct {
+	iterator
+	value analyses.Analysis
+}
+
+// Next advances to the next analysis.  It returns false once there are no more
+// analyses or an error has been encountered.
+func (i *AnalysisIterator) Next() bool {
+	i.value = analyses.Analysis{}
+	return i.next(&i.value)
+}
+
+// Value returns the current analysis
+func (i *AnalysisIterator) Value() analyses.Analysis {
+	return i.value
+}
+
+// IterateAnalyses takes a team ID, project ID, token, and pagination params.
+// It returns an iterator over the analyses for the project.
+func (ic *IonClient) IterateAnalyses(teamID, projectID, token string, page *pagination.Pagination) *AnalysisIterator {
+	params := &url.Values{}
+	params.Set("team_id", teamID)
+	params.Set("project_id", projectID)
+
+	return &AnalysisIterator{iterator: ic.iterate(analyses.AnalysisGetAnalysesEndpoint, token, params, page)}
+}
+
+// RuleSetIterator iterates over rulesets, fetching them a page at a time
+type RuleSetIterator struct {
+	iterator
+	value rulesets.RuleSet
+}
+
+// Next advances to the next ruleset.  It returns false once there are no more
+// rulesets or an error has been encountered.
+func (i *RuleSetIterator) Next() bool {
+	i.value = rulesets.RuleSet{}
+	return i.next(&i.value)
+}
+
+// Value returns the current ruleset
+func (i *RuleSetIterator) Value() rulesets.RuleSet {
+	return i.value
+}
+
+// IterateRuleSets takes a team ID, token, and pagination params.  It returns
+// an iterator over the rulesets for the team.
+func (ic *IonClient) IterateRuleSets(teamID, token string, page *pagination.Pagination) *RuleSetIterator {
+	params := &url.Values{}
+	params.Set("team_id", teamID)
+
+	return &RuleSetIterator{iterator: ic.iterate(rulesets.GetRuleSetsEndpoint, token, params, page)}
+}
+
+// VulnerabilityIterator iterates over vulnerabilities, fetching them a page at
+// a time
+type VulnerabilityIterator struct {
+	iterator
+	value vulnerabilities.Vulnerability
+}
+
+// Next advances to the next vulnerability.  It returns false once there are no
+// more vulnerabilities or an error has been encountered.
+func (i *VulnerabilityIterator) Next() bool {
+	i.value = vulnerabilities.Vulnerability{}
+	return i.next(&i.value)
+}
+
+// Value returns the current vulnerability
+func (i *VulnerabilityIterator) Value() vulnerabilities.Vulnerability {
+	return i.value
+}
+
+// IterateVulnerabilities takes a product, version, token, and pagination
+// params.  It returns an iterator over the vulnerabilities for the product.
+// If version is left blank, it will not be considered in the search query.
+func (ic *IonClient) IterateVulnerabilities(product, version, token string, page *pagination.Pagination) *VulnerabilityIterator {
+	params := &url.Values{}
+	params.Set("product", product)
+	if version != "" {
+		params.Set("version", version)
+	}
+
+	return &VulnerabilityIterator{iterator: ic.iterate(vulnerabilities.GetVulnerabilitiesEndpoint, token, params, page)}
+}
+
+// ProductIterator iterates over products, fetching them a page at a time
+type ProductIterator struct {
+	iterator
+	value products.Product
+}
+
+// Next advances to the next product.  It returns false once there are no more
+// products or an error has been encountered.
+func (i *ProductIterator) Next() bool {
+	i.value = products.Product{}
+	return i.next(&i.value)
+}
+
+// Value returns the current product
+func (i *ProductIterator) Value() products.Product {
+	return i.value
+}
+
+// IterateProductSearch takes a search query, pagination params, and token.  It
+// returns an iterator over the matching products.
+func (ic *IonClient) IterateProductSearch(query string, page *pagination.Pagination, token string) *ProductIterator {
+	params := &url.Values{}
+	params.Set("q", query)
+
+	return &ProductIterator{iterator: ic.iterate(products.ProductSearchEndpoint, token, params, page)}
+}
@@ -0,0 +1,87 @@
+package ionic
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic/pagination"
+	. "github.com/onsi/gomega"
+)
+
+func TestIterators(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Iterators", func() {
+		var server *httptest.Server
+		var client *IonClient
+		var query string
+
+		g.BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.RawQuery
+
+				switch r.URL.Query().Get("offset") {
+				case "0":
+					fmt.Fprint(w, `{"data":[{"id":"one"},{"id":"two"}],"meta":{"total_count":3}}`)
+				default:
+					fmt.Fprint(w, `{"data":[{"id":"three"}],"meta":{"total_count":3}}`)
+				}
+			}))
+
+			client, _ = New(server.URL)
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+		})
+
+		g.It("should iterate over projects", func() {
+			it := client.IterateProjects("someteam", "sometoken", &pagination.Pagination{Limit: 2}, nil)
+
+			var ids []string
+			for it.Next() {
+				ids = append(ids, *it.Value().ID)
+			}
+
+			Expect(it.Err()).To(BeNil())
+			Expect(ids).To(Equal([]string{"one", "two", "three"}))
+			Expect(it.Meta().TotalCount).To(Equal(3))
+			Expect(query).To(ContainSubstring("team_id=someteam"))
+		})
+
+		g.It("should iterate over rulesets", func() {
+			it := client.IterateRuleSets("someteam", "sometoken", &pagination.Pagination{Limit: 2})
+
+			var ids []string
+			for it.Next() {
+				ids = append(ids, it.Value().ID)
+			}
+
+			Expect(it.Err()).To(BeNil())
+			Expect(ids).To(Equal([]string{"one", "two", "three"}))
+		})
+
+		g.It("should return malformed items as an error", func() {
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"data":[{"id":1}],"meta":{"total_count":1}}`)
+			})
+
+			it := client.IterateAnalyses("someteam", "someproject", "sometoken", nil)
+			Expect(it.Next()).To(BeFalse())
+			Expect(it.Err()).NotTo(BeNil())
+		})
+
+		g.It("should return token errors", func() {
+			client.SetTokenSource(EnvToken("IONIC_TEST_MISSING_TOKEN"))
+
+			it := client.IterateVulnerabilities("ruby", "", "", nil)
+			Expect(it.Next()).To(BeFalse())
+			Expect(it.Err()).NotTo(BeNil())
+			Expect(it.Meta()).To(BeNil())
+		})
+	})
+}
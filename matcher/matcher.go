@@ -0,0 +1,339 @@
+// Package matcher finds the vulnerabilities of dependencies locally, rather
+// than asking the API, so air-gapped build agents can get a first-pass answer
+// without network access.  Vulnerabilities are loaded from JSON dumps of the
+// API's vulnerabilities, OSV entries, or NVD CVE feeds, and the versions of
+// the products they affect are compared with the rules of each product's
+// ecosystem.
+//
+// The version of an affected product is a range expression, as read by
+// versions.ParseRanges, so a product can name a single version or the ranges
+// of versions a vulnerability affects.  The ecosystem of a product is the
+// type of the package URL in its external ID, if it has one.  Products
+// without an ecosystem, such as the CPE products of NVD, match dependencies of
+// any type by name alone.
+package matcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/ion-channel/ionic/dependencies"
+	"github.com/ion-channel/ionic/products"
+	"github.com/ion-channel/ionic/scans"
+	"github.com/ion-channel/ionic/versions"
+	"github.com/ion-channel/ionic/vulnerabilities"
+)
+
+var (
+	separatorRegex = regexp.MustCompile(`[-_.]+`)
+)
+
+// Matcher matches dependencies against a set of vulnerabilities
+type Matcher struct {
+	vulnerabilities []vulnerabilities.Vulnerability
+	affected        map[string][]affected
+}
+
+// affected is a product affected by a vulnerability
+type affected struct {
+	vulnerability int
+	ecosystem     string
+	org           string
+	name          string
+	ranges        []versions.Range
+}
+
+// New returns a matcher for the given vulnerabilities
+func New(vulns ...vulnerabilities.Vulnerability) *Matcher {
+	m := &Matcher{
+		affected: make(map[string][]affected),
+	}
+
+	m.Add(vulns...)
+
+	return m
+}
+
+// Add adds vulnerabilities and the products they affect to the matcher.  The
+// version of a product that is not a valid range expression is taken as an
+// exact version.  A product without a version has no version data and matches
+// nothing; only * or - affects every version.
+func (m *Matcher) Add(vulns ...vulnerabilities.Vulnerability) {
+	for _, v := range vulns {
+		m.vulnerabilities = append(m.vulnerabilities, v)
+
+		for _, p := range v.Dependencies {
+			a := affectedProduct(p)
+			a.vulnerability = len(m.vulnerabilities) - 1
+
+			key := nameKey(a.name)
+			m.affected[key] = append(m.affected[key], a)
+		}
+	}
+}
+
+// Len returns the number of vulnerabilities in the matcher
+func (m *Matcher) Len() int {
+	return len(m.vulnerabilities)
+}
+
+// LoadJSON adds the vulnerabilities of a JSON dump to the matcher.  The dump
+// is a vulnerability or an array of them, as the API returns.
+func (m *Matcher) LoadJSON(r io.Reader) error {
+	entries, err := decodeOneOrMany(r)
+	if err != nil {
+		return err
+	}
+
+	vulns := make([]vulnerabilities.Vulnerability, 0, len(entries))
+	for _, entry := range entries {
+		var v vulnerabilities.Vulnerability
+		if err := json.Unmarshal(entry, &v); err != nil {
+			return fmt.Errorf("failed to unmarshal vulnerability: %v", err.Error())
+		}
+
+		vulns = append(vulns, v)
+	}
+
+	m.Add(vulns...)
+
+	return nil
+}
+
+// Match returns the vulnerabilities affecting a version of a package of the
+// given ecosystem, the same as the API's GetVulnerabilities does for a
+// product and version.  Nothing matches a package without a version.
+func (m *Matcher) Match(ecosystem, org, name, version string) []vulnerabilities.Vulnerability {
+	version = strings.TrimSpace(version)
+	if name == "" || version == "" {
+		return nil
+	}
+
+	ecosystem = normalizeEcosystem(ecosystem)
+
+	names := []string{name}
+	if org != "" {
+		names = append(names, org+"/"+name, org+":"+name)
+	}
+
+	matched := make(map[int]bool)
+	vulns := []vulnerabilities.Vulnerability{}
+
+	for _, candidate := range names {
+		for _, a := range m.affected[nameKey(candidate)] {
+			if matched[a.vulnerability] || !a.matches(ecosystem, names, version) {
+				continue
+			}
+
+			matched[a.vulnerability] = true
+			vulns = append(vulns, m.vulnerabilities[a.vulnerability])
+		}
+	}
+
+	return vulns
+}
+
+// MatchDependencies returns the vulnerabilities affecting a list of
+// dependencies and their dependencies, in the shape of a vulnerability scan's
+// results
+func (m *Matcher) MatchDependencies(deps []dependencies.Dependency) scans.VulnerabilityResults {
+	results := scans.DependencyResults{}
+	for _, d := range deps {
+		results.Dependencies = append(results.Dependencies, scanDependency(d))
+	}
+
+	return m.MatchDependencyResults(results)
+}
+
+// MatchDependencyResults returns the vulnerabilities affecting the
+// dependencies of a dependency scan's results, in the shape of a
+// vulnerability scan's results.  Each distinct dependency found in the tree
+// of dependencies is matched once, and only dependencies with vulnerabilities
+// are included.
+func (m *Matcher) MatchDependencyResults(results scans.DependencyResults) scans.VulnerabilityResults {
+	vr := scans.VulnerabilityResults{}
+	seen := make(map[string]bool)
+
+	var walk func(deps []scans.Dependency)
+	walk = func(deps []scans.Dependency) {
+		for _, d := range deps {
+			key := strings.ToLower(strings.Join([]string{normalizeEcosystem(d.Type), d.Org, d.Name, d.Version}, "\x00"))
+			if !seen[key] {
+				seen[key] = true
+
+				if vulns := m.Match(d.Type, d.Org, d.Name, d.Version); len(vulns) > 0 {
+					query := d
+					query.Dependencies = nil
+
+					p := scans.VulnerabilityResultsProduct{
+						Name:    d.Name,
+						Org:     d.Org,
+						Version: d.Version,
+						Query:   query,
+					}
+
+					for _, v := range vulns {
+						p.Vulnerabilities = append(p.Vulnerabilities, scans.VulnerabilityResultsVulnerability{Vulnerability: v})
+					}
+
+					vr.Vulnerabilities = append(vr.Vulnerabilities, p)
+					vr.Meta.VulnerabilityCount += len(vulns)
+				}
+			}
+
+			walk(d.Dependencies)
+		}
+	}
+
+	walk(results.Dependencies)
+
+	return vr
+}
+
+// matches returns whether a version of a package of an ecosystem, known by
+// any of the given names, is affected
+func (a affected) matches(ecosystem string, names []string, version string) bool {
+	if a.ecosystem != "" && ecosystem != "" && a.ecosystem != ecosystem {
+		return false
+	}
+
+	if ecosystem == "" {
+		ecosystem = a.ecosystem
+	}
+
+	affectedName := a.name
+	if a.org != "" && a.ecosystem != "" {
+		affectedName = a.org + "/" + a.name
+		if a.ecosystem == versions.EcosystemMaven {
+			affectedName = a.org + ":" + a.name
+		}
+	}
+
+	named := false
+	for _, name := range names {
+		if sameName(ecosystem, name, affectedName) {
+			named = true
+			break
+		}
+	}
+
+	if !named {
+		return false
+	}
+
+	for _, r := range a.ranges {
+		if r.Contains(ecosystem, version) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// affectedProduct returns the package and range of versions of an affected
+// product.  Maven products named group:artifact are split into their org and
+// name.
+func affectedProduct(p products.Product) affected {
+	a := affected{
+		org:  p.Org,
+		name: p.Name,
+	}
+
+	if strings.HasPrefix(p.ExternalID, "pkg:") {
+		purlType := strings.SplitN(strings.TrimPrefix(p.ExternalID, "pkg:"), "/", 2)[0]
+		a.ecosystem = normalizeEcosystem(purlType)
+	}
+
+	if a.ecosystem == versions.EcosystemMaven && a.org == "" {
+		if parts := strings.SplitN(a.name, ":", 2); len(parts) == 2 {
+			a.org, a.name = parts[0], parts[1]
+		}
+	}
+
+	ranges, err := versions.ParseRanges(p.Version)
+	if err != nil {
+		ranges = []versions.Range{{{Operator: "=", Version: strings.TrimSpace(p.Version)}}}
+	}
+
+	a.ranges = ranges
+
+	return a
+}
+
+// scanDependency returns a dependency and its dependencies as the
+// dependencies of a dependency scan's results
+func scanDependency(d dependencies.Dependency) scans.Dependency {
+	sd := scans.Dependency{
+		LatestVersion: d.LatestVersion,
+		Org:           d.Org,
+		Name:          d.Name,
+		Type:          d.Type,
+		Package:       d.Package,
+		Version:       d.Version,
+		Scope:         d.Scope,
+		Requirement:   d.Requirement,
+	}
+
+	for _, child := range d.Dependencies {
+		sd.Dependencies = append(sd.Dependencies, scanDependency(child))
+	}
+
+	return sd
+}
+
+// normalizeEcosystem returns the ecosystem of a dependency type or package
+// URL type, or the type itself in lower case if it is not a known ecosystem
+func normalizeEcosystem(name string) string {
+	if ecosystem := versions.Ecosystem(name); ecosystem != "" {
+		return ecosystem
+	}
+
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// sameName returns whether two package names are the same in an ecosystem.
+// Names are compared ignoring case, and PyPI names also treat runs of -, _,
+// and . as the same.
+func sameName(ecosystem, a, b string) bool {
+	if ecosystem == versions.EcosystemPyPI {
+		return nameKey(a) == nameKey(b)
+	}
+
+	return strings.EqualFold(a, b)
+}
+
+// nameKey returns the key products are indexed by, which is loose enough for
+// the names of every ecosystem
+func nameKey(name string) string {
+	return separatorRegex.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+}
+
+// decodeOneOrMany reads a JSON document that is either a single object or an
+// array of them
+func decodeOneOrMany(r io.Reader) ([]json.RawMessage, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document: %v", err.Error())
+	}
+
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	if b[0] != '[' {
+		return []json.RawMessage{b}, nil
+	}
+
+	var entries []json.RawMessage
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal document: %v", err.Error())
+	}
+
+	return entries, nil
+}
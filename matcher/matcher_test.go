@@ -0,0 +1,257 @@
+package matcher
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic/dependencies"
+	"github.com/ion-channel/ionic/products"
+	"github.com/ion-channel/ionic/scans"
+	"github.com/ion-channel/ionic/vulnerabilities"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	sampleVulnerabilitiesJSON = `[{
+		"external_id": "CVE-2020-15250",
+		"title": "TemporaryFolder is shared",
+		"score": "5.5",
+		"dependencies": [
+			{"name": "junit", "org": "junit", "version": "4.13"},
+			{"name": "junit", "org": "junit", "version": "4.12"}
+		]
+	}, {
+		"external_id": "CVE-2021-0001",
+		"title": "Every version",
+		"dependencies": [{"name": "leftpad", "org": "", "version": "*", "external_id": "pkg:npm/leftpad"}]
+	}]`
+
+	sampleOSV = `[{
+		"id": "GHSA-57j2-w4cx-62h2",
+		"modified": "2023-01-10T05:04:30Z",
+		"summary": "Deeply nested json in jackson-databind",
+		"severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H"}],
+		"affected": [{
+			"package": {"ecosystem": "Maven", "name": "com.fasterxml.jackson.core:jackson-databind"},
+			"ranges": [{"type": "ECOSYSTEM", "events": [
+				{"introduced": "2.13.0"}, {"fixed": "2.13.2.1"},
+				{"introduced": "0"}, {"fixed": "2.12.6.1"}
+			]}]
+		}]
+	}, {
+		"id": "GHSA-jfh8-c2jp-5v3q",
+		"summary": "Remote code injection in Log4j",
+		"published": "2021-12-10T00:40:56Z",
+		"modified": "2023-01-10T05:04:30Z",
+		"severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"}],
+		"affected": [{
+			"package": {"ecosystem": "Maven", "name": "org.apache.logging.log4j:log4j-core"},
+			"ranges": [{"type": "ECOSYSTEM", "events": [
+				{"introduced": "2.13.0"}, {"fixed": "2.15.0"},
+				{"introduced": "2.0-beta9"}, {"fixed": "2.12.2"}
+			]}]
+		}],
+		"references": [{"type": "ADVISORY", "url": "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"}]
+	}, {
+		"id": "PYSEC-2024-0001",
+		"modified": "2024-01-02T00:00:00Z",
+		"affected": [{
+			"package": {"ecosystem": "PyPI", "name": "Some_Package"},
+			"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"last_affected": "1.0.post1"}]}]
+		}, {
+			"package": {"ecosystem": "Go", "name": "github.com/example/module"},
+			"ranges": [{"type": "GIT", "repo": "https://github.com/example/module", "events": [{"introduced": "0"}, {"fixed": "abcdef"}]}],
+			"versions": ["v1.0.0", "v1.0.1"]
+		}]
+	}]`
+
+	sampleNVD = `{"resultsPerPage": 1, "format": "NVD_CVE", "version": "2.0", "vulnerabilities": [{"cve": {
+		"id": "CVE-2021-41773",
+		"published": "2021-10-05T09:15:07.593",
+		"lastModified": "2023-11-07T03:39:13.017",
+		"descriptions": [{"lang": "es", "value": "Una falla"}, {"lang": "en", "value": "A flaw was found in a change made to path normalization in Apache HTTP Server 2.4.49."}],
+		"metrics": {
+			"cvssMetricV31": [
+				{"source": "other@example.com", "type": "Secondary", "cvssData": {"vectorString": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:N"}},
+				{"source": "nvd@nist.gov", "type": "Primary", "cvssData": {"vectorString": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:N"}}
+			],
+			"cvssMetricV2": [{"source": "nvd@nist.gov", "type": "Primary", "cvssData": {"vectorString": "AV:N/AC:L/Au:N/C:P/I:N/A:N"}}]
+		},
+		"references": [{"url": "https://httpd.apache.org/security/vulnerabilities_24.html", "source": "security@apache.org"}],
+		"configurations": [{"nodes": [{"operator": "OR", "negate": false, "cpeMatch": [
+			{"vulnerable": true, "criteria": "cpe:2.3:a:apache:http_server:2.4.49:*:*:*:*:*:*:*"},
+			{"vulnerable": true, "criteria": "cpe:2.3:a:apache:tomcat:*:*:*:*:*:*:*:*", "versionStartIncluding": "9.0.0", "versionEndExcluding": "9.0.54"},
+			{"vulnerable": false, "criteria": "cpe:2.3:o:fedoraproject:fedora:35:*:*:*:*:*:*:*"}
+		]}]}]
+	}}]}`
+)
+
+func TestMatcher(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Matcher", func() {
+		g.It("should match products of a JSON dump", func() {
+			m := New()
+			Expect(m.LoadJSON(strings.NewReader(sampleVulnerabilitiesJSON))).To(BeNil())
+			Expect(m.Len()).To(Equal(2))
+
+			vulns := m.Match("maven", "junit", "junit", "4.13")
+			Expect(vulns).To(HaveLen(1))
+			Expect(vulns[0].ExternalID).To(Equal("CVE-2020-15250"))
+
+			Expect(m.Match("maven", "junit", "junit", "4.13.1")).To(BeEmpty())
+			Expect(m.Match("maven", "junit", "junit", "")).To(BeEmpty())
+
+			Expect(m.Match("npm", "", "leftpad", "0.0.1")).To(HaveLen(1))
+			Expect(m.Match("yarn", "", "LeftPad", "99.0.0")).To(HaveLen(1))
+			Expect(m.Match("pypi", "", "leftpad", "1.0.0")).To(BeEmpty())
+		})
+
+		g.It("should match the ranges of OSV entries", func() {
+			m := New()
+			Expect(m.LoadOSV(strings.NewReader(sampleOSV))).To(BeNil())
+			Expect(m.Len()).To(Equal(3))
+
+			vulns := m.Match("maven", "com.fasterxml.jackson.core", "jackson-databind", "2.13.1")
+			Expect(vulns).To(HaveLen(1))
+			Expect(vulns[0].ExternalID).To(Equal("GHSA-57j2-w4cx-62h2"))
+			Expect(vulns[0].Dependencies[0].Version).To(Equal(">=2.13.0, <2.13.2.1 || <2.12.6.1"))
+			Expect(vulns[0].Dependencies[0].ExternalID).To(Equal("pkg:maven/com.fasterxml.jackson.core/jackson-databind"))
+
+			Expect(m.Match("maven", "com.fasterxml.jackson.core", "jackson-databind", "2.13.2")).To(HaveLen(1))
+			Expect(m.Match("maven", "com.fasterxml.jackson.core", "jackson-databind", "2.12.6")).To(HaveLen(1))
+			Expect(m.Match("maven", "com.fasterxml.jackson.core", "jackson-databind", "2.12.6.1")).To(BeEmpty())
+			Expect(m.Match("maven", "com.fasterxml.jackson.core", "jackson-databind", "2.12.7")).To(BeEmpty())
+			Expect(m.Match("maven", "com.fasterxml.jackson.core", "jackson-databind", "2.13.2.1")).To(BeEmpty())
+			Expect(m.Match("maven", "com.fasterxml.jackson", "jackson-databind", "2.13.1")).To(BeEmpty())
+			Expect(m.Match("npm", "com.fasterxml.jackson.core", "jackson-databind", "2.13.1")).To(BeEmpty())
+
+			vulns = m.Match("maven", "org.apache.logging.log4j", "log4j-core", "2.14.1")
+			Expect(vulns).To(HaveLen(1))
+
+			v := vulns[0]
+			Expect(v.ExternalID).To(Equal("GHSA-jfh8-c2jp-5v3q"))
+			Expect(v.Title).To(Equal("Remote code injection in Log4j"))
+			Expect(v.Score).To(Equal("10.0"))
+			Expect(v.ScoreVersion).To(Equal("3.1"))
+			Expect(v.Severity()).To(Equal(vulnerabilities.SeverityCritical))
+			Expect(v.PublishedAt).To(Equal(time.Date(2021, 12, 10, 0, 40, 56, 0, time.UTC)))
+			Expect(v.References).To(HaveLen(1))
+			Expect(v.References[0].Type).To(Equal("advisory"))
+			Expect(v.Dependencies[0].Version).To(Equal(">=2.13.0, <2.15.0 || >=2.0-beta9, <2.12.2"))
+			Expect(v.Dependencies[0].ExternalID).To(Equal("pkg:maven/org.apache.logging.log4j/log4j-core"))
+
+			Expect(m.Match("maven", "org.apache.logging.log4j", "log4j-core", "2.0-beta9")).To(HaveLen(1))
+			Expect(m.Match("maven", "org.apache.logging.log4j", "log4j-core", "2.0-beta8")).To(BeEmpty())
+			Expect(m.Match("maven", "org.apache.logging.log4j", "log4j-core", "2.12.2")).To(BeEmpty())
+			Expect(m.Match("maven", "org.apache.logging.log4j", "log4j-core", "2.15.0")).To(BeEmpty())
+
+			Expect(m.Match("pip", "", "some-package", "1.0.post1")).To(HaveLen(1))
+			Expect(m.Match("pip", "", "some.package", "1.0")).To(HaveLen(1))
+			Expect(m.Match("pip", "", "some-package", "1.0.post2")).To(BeEmpty())
+
+			Expect(m.Match("golang", "", "github.com/example/module", "v1.0.1")).To(HaveLen(1))
+			Expect(m.Match("golang", "github.com/example", "module", "v1.0.0")).To(HaveLen(1))
+			Expect(m.Match("golang", "", "github.com/example/module", "v1.0.2")).To(BeEmpty())
+		})
+
+		g.It("should match the CPEs of NVD feeds", func() {
+			m := New()
+			Expect(m.LoadNVD(strings.NewReader(sampleNVD))).To(BeNil())
+			Expect(m.Len()).To(Equal(1))
+
+			vulns := m.Match("", "", "http_server", "2.4.49")
+			Expect(vulns).To(HaveLen(1))
+
+			v := vulns[0]
+			Expect(v.ExternalID).To(Equal("CVE-2021-41773"))
+			Expect(v.Summary).To(HavePrefix("A flaw was found"))
+			Expect(v.Vector).To(Equal("CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:N"))
+			Expect(v.Score).To(Equal("9.1"))
+			Expect(v.ScoreDetails.CVSSv2.BaseScore).To(Equal(5.0))
+			Expect(v.PublishedAt).To(Equal(time.Date(2021, 10, 5, 9, 15, 7, 593000000, time.UTC)))
+			Expect(v.References[0].Source).To(Equal("security@apache.org"))
+			Expect(v.Dependencies).To(HaveLen(2))
+			Expect(v.Dependencies[1].Org).To(Equal("apache"))
+			Expect(v.Dependencies[1].Version).To(Equal(">=9.0.0, <9.0.54"))
+
+			Expect(m.Match("", "", "http_server", "2.4.50")).To(BeEmpty())
+			Expect(m.Match("maven", "org.apache.tomcat", "tomcat", "9.0.53")).To(HaveLen(1))
+			Expect(m.Match("maven", "org.apache.tomcat", "tomcat", "9.0.54")).To(BeEmpty())
+			Expect(m.Match("", "", "fedora", "35")).To(BeEmpty())
+		})
+
+		g.It("should match dependency trees", func() {
+			m := New()
+			Expect(m.LoadJSON(strings.NewReader(sampleVulnerabilitiesJSON))).To(BeNil())
+			Expect(m.LoadOSV(strings.NewReader(sampleOSV))).To(BeNil())
+			Expect(m.Len()).To(Equal(5))
+
+			deps := []dependencies.Dependency{{
+				Name:    "jackson-databind",
+				Org:     "com.fasterxml.jackson.core",
+				Version: "2.13.1",
+				Type:    "maven",
+				Dependencies: []dependencies.Dependency{
+					{Name: "junit", Org: "junit", Version: "4.12", Type: "maven"},
+					{Name: "jackson-core", Org: "com.fasterxml.jackson.core", Version: "2.13.1", Type: "maven"},
+				},
+			}, {
+				Name: "junit", Org: "junit", Version: "4.12", Type: "maven",
+			}}
+
+			results := m.MatchDependencies(deps)
+			Expect(results.Vulnerabilities).To(HaveLen(2))
+			Expect(results.Meta.VulnerabilityCount).To(Equal(2))
+			Expect(results.SeverityCounts()[vulnerabilities.SeverityHigh]).To(Equal(1))
+
+			jackson := results.Vulnerabilities[0]
+			Expect(jackson.Name).To(Equal("jackson-databind"))
+			Expect(jackson.Query.Type).To(Equal("maven"))
+			Expect(jackson.Query.Dependencies).To(BeNil())
+			Expect(jackson.Vulnerabilities[0].ExternalID).To(Equal("GHSA-57j2-w4cx-62h2"))
+
+			junit := results.Vulnerabilities[1]
+			Expect(junit.Version).To(Equal("4.12"))
+			Expect(junit.Vulnerabilities[0].ExternalID).To(Equal("CVE-2020-15250"))
+
+			results = m.MatchDependencyResults(scans.DependencyResults{
+				Dependencies: []scans.Dependency{{Name: "leftpad", Version: "1.0.0", Type: "npm"}},
+			})
+			Expect(results.Vulnerabilities).To(HaveLen(1))
+			Expect(results.Vulnerabilities[0].Vulnerabilities[0].ExternalID).To(Equal("CVE-2021-0001"))
+		})
+
+		g.It("should match vulnerabilities added directly", func() {
+			m := New(vulnerabilities.Vulnerability{
+				ExternalID:   "CVE-2021-0002",
+				Dependencies: []products.Product{{Name: "rack", Version: "< 2.2.3.1 || 3.0.0.beta1"}},
+			})
+			m.Add(vulnerabilities.Vulnerability{
+				ExternalID:   "CVE-2021-0003",
+				Dependencies: []products.Product{{Name: "rack", Version: "2.2.3"}},
+			})
+
+			Expect(m.Match("gem", "", "rack", "2.2.3")).To(HaveLen(2))
+			Expect(m.Match("gem", "", "rack", "2.2.3.1")).To(BeEmpty())
+			Expect(m.Match("gem", "", "rack", "3.0.0.beta1")).To(HaveLen(1))
+		})
+
+		g.It("should not match products without a version", func() {
+			m := New(vulnerabilities.Vulnerability{
+				ExternalID:   "CVE-2021-0004",
+				Dependencies: []products.Product{{Name: "openssl", Version: ""}},
+			}, vulnerabilities.Vulnerability{
+				ExternalID:   "CVE-2021-0005",
+				Dependencies: []products.Product{{Name: "openssl", Version: "*"}},
+			})
+
+			vulns := m.Match("", "openssl", "openssl", "3.0.0")
+			Expect(vulns).To(HaveLen(1))
+			Expect(vulns[0].ExternalID).To(Equal("CVE-2021-0005"))
+		})
+	})
+}
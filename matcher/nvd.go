@@ -0,0 +1,254 @@
+package matcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ion-channel/ionic/products"
+	"github.com/ion-channel/ionic/versions"
+	"github.com/ion-channel/ionic/vulnerabilities"
+)
+
+var (
+	// nvdTimeLayouts are the layouts of the times in NVD feeds, which are
+	// given without a time zone and are in UTC
+	nvdTimeLayouts = []string{
+		"2006-01-02T15:04:05.999",
+		"2006-01-02T15:04:05",
+		time.RFC3339Nano,
+	}
+)
+
+// nvdFeed is the part of an NVD CVE API response or 2.0 data feed needed to
+// match its vulnerabilities
+type nvdFeed struct {
+	Vulnerabilities []struct {
+		CVE nvdCVE `json:"cve"`
+	} `json:"vulnerabilities"`
+}
+
+// nvdCVE is the part of an NVD CVE needed to match its vulnerability
+type nvdCVE struct {
+	ID           string  `json:"id"`
+	Published    nvdTime `json:"published"`
+	LastModified nvdTime `json:"lastModified"`
+	Descriptions []struct {
+		Lang  string `json:"lang"`
+		Value string `json:"value"`
+	} `json:"descriptions"`
+	Metrics struct {
+		CVSSMetricV40 []nvdMetric `json:"cvssMetricV40"`
+		CVSSMetricV31 []nvdMetric `json:"cvssMetricV31"`
+		CVSSMetricV30 []nvdMetric `json:"cvssMetricV30"`
+		CVSSMetricV2  []nvdMetric `json:"cvssMetricV2"`
+	} `json:"metrics"`
+	References []struct {
+		URL    string `json:"url"`
+		Source string `json:"source"`
+	} `json:"references"`
+	Configurations []struct {
+		Nodes []struct {
+			CPEMatch []nvdCPEMatch `json:"cpeMatch"`
+		} `json:"nodes"`
+	} `json:"configurations"`
+}
+
+// nvdMetric is a CVSS score of an NVD CVE
+type nvdMetric struct {
+	Type     string `json:"type"`
+	CVSSData struct {
+		VectorString string `json:"vectorString"`
+	} `json:"cvssData"`
+}
+
+// nvdCPEMatch is a CPE and range of versions an NVD CVE applies to
+type nvdCPEMatch struct {
+	Vulnerable            bool   `json:"vulnerable"`
+	Criteria              string `json:"criteria"`
+	VersionStartIncluding string `json:"versionStartIncluding"`
+	VersionStartExcluding string `json:"versionStartExcluding"`
+	VersionEndIncluding   string `json:"versionEndIncluding"`
+	VersionEndExcluding   string `json:"versionEndExcluding"`
+}
+
+// nvdTime is a time in an NVD feed
+type nvdTime struct {
+	time.Time
+}
+
+// UnmarshalJSON parses a time in any of the layouts of NVD feeds
+func (t *nvdTime) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	if s == "" {
+		return nil
+	}
+
+	for _, layout := range nvdTimeLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid time %q", s)
+}
+
+// LoadNVD adds the vulnerabilities of an NVD CVE API response or JSON 2.0
+// data feed to the matcher.  The products a CVE affects are the vulnerable
+// CPEs of its configurations, named by their vendor and product, which match
+// dependencies of any ecosystem.
+func (m *Matcher) LoadNVD(r io.Reader) error {
+	var feed nvdFeed
+	if err := json.NewDecoder(r).Decode(&feed); err != nil {
+		return fmt.Errorf("failed to unmarshal NVD feed: %v", err.Error())
+	}
+
+	vulns := make([]vulnerabilities.Vulnerability, 0, len(feed.Vulnerabilities))
+	for _, entry := range feed.Vulnerabilities {
+		vulns = append(vulns, entry.CVE.vulnerability())
+	}
+
+	m.Add(vulns...)
+
+	return nil
+}
+
+// vulnerability returns the NVD CVE as a vulnerability
+func (c nvdCVE) vulnerability() vulnerabilities.Vulnerability {
+	v := vulnerabilities.Vulnerability{
+		ExternalID:  c.ID,
+		Title:       c.ID,
+		Source:      []vulnerabilities.Source{{Name: "NVD"}},
+		PublishedAt: c.Published.Time,
+		ModifiedAt:  c.LastModified.Time,
+	}
+
+	for _, d := range c.Descriptions {
+		if d.Lang == "en" {
+			v.Summary = d.Value
+			break
+		}
+	}
+
+	if vector := primaryVector(c.Metrics.CVSSMetricV40); vector != "" {
+		v.ScoreDetails.CVSSv4 = vulnerabilities.NewV4FromShorthand(vector)
+	}
+
+	if vector := primaryVector(c.Metrics.CVSSMetricV31); vector != "" {
+		v.ScoreDetails.CVSSv3 = vulnerabilities.NewV3FromShorthand(vector)
+	} else if vector := primaryVector(c.Metrics.CVSSMetricV30); vector != "" {
+		v.ScoreDetails.CVSSv3 = vulnerabilities.NewV3FromShorthand(vector)
+	}
+
+	if vector := primaryVector(c.Metrics.CVSSMetricV2); vector != "" {
+		v.ScoreDetails.CVSSv2 = vulnerabilities.NewV2FromShorthand(vector)
+	}
+
+	v.SetScoreFromDetails()
+
+	for _, ref := range c.References {
+		v.References = append(v.References, vulnerabilities.Reference{
+			Source: ref.Source,
+			URL:    ref.URL,
+		})
+	}
+
+	for _, config := range c.Configurations {
+		for _, node := range config.Nodes {
+			for _, match := range node.CPEMatch {
+				if p, ok := match.product(); ok {
+					v.Dependencies = append(v.Dependencies, p)
+				}
+			}
+		}
+	}
+
+	return v
+}
+
+// product returns the product a vulnerable CPE match names, with the range
+// of its versions as the product's version
+func (cm nvdCPEMatch) product() (products.Product, bool) {
+	parts := splitCPE(cm.Criteria)
+	if !cm.Vulnerable || len(parts) < 7 || parts[0] != "cpe" || parts[1] != "2.3" {
+		return products.Product{}, false
+	}
+
+	p := products.Product{
+		Part:       parts[2],
+		Org:        parts[3],
+		Name:       parts[4],
+		ExternalID: cm.Criteria,
+	}
+
+	version, update := parts[5], parts[6]
+	if version != "*" && version != "-" {
+		if update != "*" && update != "-" {
+			version += "-" + update
+		}
+
+		p.Version = versions.FormatRanges([]versions.Range{{{Operator: "=", Version: version}}})
+		return p, true
+	}
+
+	r := versions.Range{}
+	for _, bound := range []versions.Constraint{
+		{Operator: ">=", Version: cm.VersionStartIncluding},
+		{Operator: ">", Version: cm.VersionStartExcluding},
+		{Operator: "<=", Version: cm.VersionEndIncluding},
+		{Operator: "<", Version: cm.VersionEndExcluding},
+	} {
+		if bound.Version != "" {
+			r = append(r, bound)
+		}
+	}
+
+	p.Version = versions.FormatRanges([]versions.Range{r})
+
+	return p, true
+}
+
+// primaryVector returns the vector of the primary score of a CVSS version,
+// or of the first score if none is primary
+func primaryVector(metrics []nvdMetric) string {
+	for _, m := range metrics {
+		if m.Type == "Primary" {
+			return m.CVSSData.VectorString
+		}
+	}
+
+	if len(metrics) > 0 {
+		return metrics[0].CVSSData.VectorString
+	}
+
+	return ""
+}
+
+// splitCPE splits a CPE 2.3 formatted string into its components, removing
+// the escaping of quoted characters
+func splitCPE(cpe string) []string {
+	parts := []string{}
+	var b strings.Builder
+
+	for i := 0; i < len(cpe); i++ {
+		switch {
+		case cpe[i] == '\\' && i+1 < len(cpe):
+			i++
+			b.WriteByte(cpe[i])
+		case cpe[i] == ':':
+			parts = append(parts, b.String())
+			b.Reset()
+		default:
+			b.WriteByte(cpe[i])
+		}
+	}
+
+	return append(parts, b.String())
+}
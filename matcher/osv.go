@@ -0,0 +1,33 @@
+package matcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ion-channel/ionic/vulnerabilities"
+)
+
+// LoadOSV adds the vulnerabilities of OSV entries to the matcher.  The
+// document is an OSV entry or an array of them, which are read as
+// vulnerabilities.OSV.Vulnerability reads them.
+func (m *Matcher) LoadOSV(r io.Reader) error {
+	entries, err := decodeOneOrMany(r)
+	if err != nil {
+		return err
+	}
+
+	vulns := make([]vulnerabilities.Vulnerability, 0, len(entries))
+	for _, raw := range entries {
+		var entry vulnerabilities.OSV
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return fmt.Errorf("failed to unmarshal OSV entry: %v", err.Error())
+		}
+
+		vulns = append(vulns, *entry.Vulnerability())
+	}
+
+	m.Add(vulns...)
+
+	return nil
+}
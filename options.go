@@ -0,0 +1,367 @@
+package ionic
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ion-channel/ionic/requests"
+)
+
+const (
+	defaultDialTimeout         = 30 * time.Second
+	defaultKeepAlive           = 30 * time.Second
+	defaultTLSHandshakeTimeout = 10 * time.Second
+	defaultIdleConnTimeout     = 90 * time.Second
+)
+
+// Option represents a setting applied to a client created with NewWithOptions
+type Option func(*options) error
+
+type options struct {
+	tokens  TokenSource
+	headers http.Header
+
+	timeout               time.Duration
+	dialTimeout           time.Duration
+	tlsHandshakeTimeout   time.Duration
+	responseHeaderTimeout time.Duration
+	idleConnTimeout       time.Duration
+	maxIdleConns          int
+	maxIdleConnsPerHost   int
+
+	tlsConfig *tls.Config
+	caCerts   [][]byte
+	proxy     func(*http.Request) (*url.URL, error)
+
+	retry       *requests.RetryPolicy
+	limiter     requests.Limiter
+	maxInFlight int
+	logger      requests.Logger
+	pageWorkers int
+	hooks       []requests.Hooks
+	wraps       []func(http.RoundTripper) http.RoundTripper
+}
+
+// NewWithOptions takes the base URL of the API and any number of options.  It
+// returns a client for talking to the API configured with the options and an
+// error if any issues instantiating the client or applying the options are
+// encountered.  Settings not covered by an option use the same defaults as
+// New, along with the standard library's defaults for proxies and dialing.
+func NewWithOptions(baseURL string, opts ...Option) (*IonClient, error) {
+	o := &options{
+		headers:             http.Header{},
+		dialTimeout:         defaultDialTimeout,
+		tlsHandshakeTimeout: defaultTLSHandshakeTimeout,
+		idleConnTimeout:     defaultIdleConnTimeout,
+		maxIdleConns:        maxIdleConns,
+		maxIdleConnsPerHost: maxIdleConnsPerHost,
+		proxy:               http.ProxyFromEnvironment,
+	}
+
+	for _, opt := range opts {
+		err := opt(o)
+		if err != nil {
+			return nil, fmt.Errorf("ionic: client initialization: %w", err)
+		}
+	}
+
+	t, err := o.transport()
+	if err != nil {
+		return nil, fmt.Errorf("ionic: client initialization: %w", err)
+	}
+
+	ic, err := NewWithClient(baseURL, &http.Client{Transport: t, Timeout: o.timeout})
+	if err != nil {
+		return nil, err
+	}
+
+	ic.tokens = o.tokens
+	ic.headers = o.headers
+	ic.transport.Retry = o.retry
+	ic.transport.Limiter = o.limiter
+	ic.transport.Logger = o.logger
+	ic.SetMaxInFlight(o.maxInFlight)
+	ic.SetPageWorkers(o.pageWorkers)
+	ic.AddHooks(o.hooks...)
+	for _, wrap := range o.wraps {
+		ic.WrapTransport(wrap)
+	}
+
+	return ic, nil
+}
+
+func (o *options) transport() (*http.Transport, error) {
+	t := &http.Transport{
+		Proxy: o.proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   o.dialTimeout,
+			KeepAlive: defaultKeepAlive,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          o.maxIdleConns,
+		MaxIdleConnsPerHost:   o.maxIdleConnsPerHost,
+		IdleConnTimeout:       o.idleConnTimeout,
+		TLSHandshakeTimeout:   o.tlsHandshakeTimeout,
+		ResponseHeaderTimeout: o.responseHeaderTimeout,
+	}
+
+	if o.tlsConfig != nil {
+		t.TLSClientConfig = o.tlsConfig.Clone()
+	}
+
+	if len(o.caCerts) > 0 {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+
+		pool := t.TLSClientConfig.RootCAs
+		if pool == nil {
+			var err error
+			pool, err = x509.SystemCertPool()
+			if err != nil || pool == nil {
+				pool = x509.NewCertPool()
+			}
+		}
+
+		for _, pem := range o.caCerts {
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no valid certificates found in CA bundle")
+			}
+		}
+
+		t.TLSClientConfig.RootCAs = pool
+	}
+
+	return t, nil
+}
+
+// WithToken sets a static token used for calls made with an empty token
+func WithToken(token string) Option {
+	return WithTokenSource(StaticToken(token))
+}
+
+// WithTokenSource sets the source of the token used for calls made with an
+// empty token.  See SetTokenSource for details.
+func WithTokenSource(source TokenSource) Option {
+	return func(o *options) error {
+		o.tokens = source
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) error {
+		o.headers.Set("User-Agent", userAgent)
+		return nil
+	}
+}
+
+// WithHeader sets a header sent with every request.  Headers passed to an
+// individual call take precedence over it.
+func WithHeader(key, value string) Option {
+	return func(o *options) error {
+		o.headers.Set(key, value)
+		return nil
+	}
+}
+
+// WithHeaders sets all of the given headers to be sent with every request.
+// Headers passed to an individual call take precedence over them.
+func WithHeaders(headers http.Header) Option {
+	return func(o *options) error {
+		for k, vs := range headers {
+			o.headers.Del(k)
+			for _, v := range vs {
+				o.headers.Add(k, v)
+			}
+		}
+		return nil
+	}
+}
+
+// WithTimeout sets the overall time limit for each request, including reading
+// the response body.  A timeout of zero means no timeout, which is the
+// default.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) error {
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithDialTimeout sets the time limit for establishing a connection
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(o *options) error {
+		o.dialTimeout = timeout
+		return nil
+	}
+}
+
+// WithTLSHandshakeTimeout sets the time limit for the TLS handshake
+func WithTLSHandshakeTimeout(timeout time.Duration) Option {
+	return func(o *options) error {
+		o.tlsHandshakeTimeout = timeout
+		return nil
+	}
+}
+
+// WithResponseHeaderTimeout sets the time limit for waiting on the response
+// headers once a request has been written
+func WithResponseHeaderTimeout(timeout time.Duration) Option {
+	return func(o *options) error {
+		o.responseHeaderTimeout = timeout
+		return nil
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used for connections to the API.
+// The configuration is cloned when the client is created.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(o *options) error {
+		o.tlsConfig = config
+		return nil
+	}
+}
+
+// WithCABundle takes the path to a PEM encoded CA bundle whose certificates are
+// trusted in addition to the system's certificates, or the RootCAs of the TLS
+// configuration when one is provided.
+func WithCABundle(path string) Option {
+	return func(o *options) error {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+
+		o.caCerts = append(o.caCerts, b)
+		return nil
+	}
+}
+
+// WithCACerts takes PEM encoded certificates to trust in the same manner as
+// WithCABundle
+func WithCACerts(pem []byte) Option {
+	return func(o *options) error {
+		o.caCerts = append(o.caCerts, pem)
+		return nil
+	}
+}
+
+// WithProxy takes the URL of a proxy to send all requests through.  An empty
+// URL disables proxying, including any proxy set in the environment.
+func WithProxy(proxyURL string) Option {
+	return func(o *options) error {
+		if proxyURL == "" {
+			o.proxy = nil
+			return nil
+		}
+
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy url: %w", err)
+		}
+
+		o.proxy = http.ProxyURL(u)
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry transient failures.  See
+// SetRetryPolicy for details.
+func WithRetryPolicy(policy *requests.RetryPolicy) Option {
+	return func(o *options) error {
+		o.retry = policy
+		return nil
+	}
+}
+
+// WithRateLimiter sets the limiter used to throttle requests.  See
+// SetRateLimiter for details.
+func WithRateLimiter(limiter requests.Limiter) Option {
+	return func(o *options) error {
+		o.limiter = limiter
+		return nil
+	}
+}
+
+// WithMaxInFlight caps the number of requests in flight.  See SetMaxInFlight
+// for details.
+func WithMaxInFlight(max int) Option {
+	return func(o *options) error {
+		o.maxInFlight = max
+		return nil
+	}
+}
+
+// WithPageWorkers opts in to fetching pages concurrently when getting all
+// items.  See SetPageWorkers for details.
+func WithPageWorkers(workers int) Option {
+	return func(o *options) error {
+		o.pageWorkers = workers
+		return nil
+	}
+}
+
+// WithHooks adds hooks called around every request.  See AddHooks for
+// details.
+func WithHooks(hooks ...requests.Hooks) Option {
+	return func(o *options) error {
+		o.hooks = append(o.hooks, hooks...)
+		return nil
+	}
+}
+
+// WithRoundTripper wraps the RoundTripper used to send requests.  Wrappers are
+// applied in the order given, so the last one given is the outermost.  See
+// WrapTransport for details.
+func WithRoundTripper(wrap func(http.RoundTripper) http.RoundTripper) Option {
+	return func(o *options) error {
+		if wrap == nil {
+			return fmt.Errorf("round tripper wrapper is nil")
+		}
+
+		o.wraps = append(o.wraps, wrap)
+		return nil
+	}
+}
+
+// WithLogger sets the logger the client reports retried requests to
+func WithLogger(logger requests.Logger) Option {
+	return func(o *options) error {
+		o.logger = logger
+		return nil
+	}
+}
+
+// WithMaxIdleConns sets the maximum number of idle connections kept open
+// across all hosts
+func WithMaxIdleConns(max int) Option {
+	return func(o *options) error {
+		o.maxIdleConns = max
+		return nil
+	}
+}
+
+// WithMaxIdleConnsPerHost sets the maximum number of idle connections kept
+// open to each host
+func WithMaxIdleConnsPerHost(max int) Option {
+	return func(o *options) error {
+		o.maxIdleConnsPerHost = max
+		return nil
+	}
+}
+
+// WithIdleConnTimeout sets how long an idle connection is kept open
+func WithIdleConnTimeout(timeout time.Duration) Option {
+	return func(o *options) error {
+		o.idleConnTimeout = timeout
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+package ionic
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic/requests"
+	. "github.com/onsi/gomega"
+)
+
+func TestOptions(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Options", func() {
+		var server *httptest.Server
+		var received http.Header
+
+		g.BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r.Header
+				fmt.Fprint(w, `{"data":{"id":"someteam"}}`)
+			}))
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+		})
+
+		g.It("should return a new client with defaults", func() {
+			cli, err := NewWithOptions(server.URL)
+
+			Expect(err).To(BeNil())
+			Expect(cli).NotTo(BeNil())
+			Expect(cli.client.Timeout).To(Equal(time.Duration(0)))
+
+			_, err = cli.GetTeam("someteam", "sometoken")
+			Expect(err).To(BeNil())
+			Expect(received.Get("Authorization")).To(Equal("Bearer sometoken"))
+		})
+
+		g.It("should apply the client settings", func() {
+			policy := requests.DefaultRetryPolicy()
+			limiter := requests.NewTokenBucket(10, 1)
+
+			cli, err := NewWithOptions(server.URL,
+				WithTimeout(5*time.Second),
+				WithRetryPolicy(policy),
+				WithRateLimiter(limiter),
+				WithMaxInFlight(2),
+				WithMaxIdleConnsPerHost(3),
+			)
+
+			Expect(err).To(BeNil())
+			Expect(cli.client.Timeout).To(Equal(5 * time.Second))
+			Expect(cli.transport.Retry).To(Equal(policy))
+			Expect(cli.transport.Limiter).To(Equal(limiter))
+			Expect(cli.transport.InFlight).NotTo(BeNil())
+			Expect(cli.transport.Base.(*http.Transport).MaxIdleConnsPerHost).To(Equal(3))
+		})
+
+		g.It("should send the default token and headers", func() {
+			cli, err := NewWithOptions(server.URL,
+				WithToken("defaulttoken"),
+				WithUserAgent("ionic-test/1.0"),
+				WithHeader("X-Trace", "abc"),
+			)
+			Expect(err).To(BeNil())
+
+			_, err = cli.GetTeam("someteam", "")
+			Expect(err).To(BeNil())
+			Expect(received.Get("Authorization")).To(Equal("Bearer defaulttoken"))
+			Expect(received.Get("User-Agent")).To(Equal("ionic-test/1.0"))
+			Expect(received.Get("X-Trace")).To(Equal("abc"))
+
+			_, err = cli.GetTeam("someteam", "calltoken")
+			Expect(err).To(BeNil())
+			Expect(received.Get("Authorization")).To(Equal("Bearer calltoken"))
+		})
+
+		g.It("should let call headers override the default headers", func() {
+			cli, _ := NewWithOptions(server.URL, WithHeader("X-Trace", "abc"))
+
+			h := http.Header{}
+			h.Set("X-Trace", "def")
+			_, _, err := cli.Get("v1/teams/getTeam", "", nil, h, nil)
+			Expect(err).To(BeNil())
+			Expect(received.Get("X-Trace")).To(Equal("def"))
+		})
+
+		g.It("should return an error for an invalid CA bundle", func() {
+			cli, err := NewWithOptions(server.URL, WithCACerts([]byte("not a cert")))
+			Expect(err).NotTo(BeNil())
+			Expect(cli).To(BeNil())
+
+			cli, err = NewWithOptions(server.URL, WithCABundle("/does/not/exist.pem"))
+			Expect(err).NotTo(BeNil())
+			Expect(cli).To(BeNil())
+		})
+
+		g.It("should return an error for an invalid proxy", func() {
+			cli, err := NewWithOptions(server.URL, WithProxy("://noscheme"))
+			Expect(err).NotTo(BeNil())
+			Expect(cli).To(BeNil())
+		})
+	})
+}
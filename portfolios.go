@@ -19,20 +19,20 @@ func (ic *IonClient) GetVulnerabilityStats(ids []string, token string) (*portfol
 
 	b, err := json.Marshal(p)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	r, err := ic.Post(portfolios.VulnerabilityStatsEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to request vulnerability list: %v", err.Error())
+		return nil, fmt.Errorf("failed to request vulnerability list: %w", err)
 	}
 
 	var vs portfolios.VulnerabilityStat
 	err = json.Unmarshal(r, &vs)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal vunlerability stats response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal vunlerability stats response: %w", err)
 	}
 
 	return &vs, nil
@@ -48,12 +48,12 @@ func (ic *IonClient) GetRawVulnerabilityList(ids []string, listType, limit, toke
 
 	b, err := json.Marshal(p)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	resp, err := ic.Post(portfolios.VulnerabilityListEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to request vulnerability list: %v", err.Error())
+		return nil, fmt.Errorf("failed to request vulnerability list: %w", err)
 	}
 
 	return resp, nil
@@ -69,12 +69,12 @@ func (ic *IonClient) GetRawVulnerabilityMetrics(ids []string, metric, token stri
 
 	b, err := json.Marshal(mb)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	resp, err := ic.Post(portfolios.VulnerabilityMetricsEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to request vulnerability metrics: %v", err.Error())
+		return nil, fmt.Errorf("failed to request vulnerability metrics: %w", err)
 	}
 
 	return resp, nil
@@ -88,18 +88,18 @@ func (ic *IonClient) GetPortfolioPassFailSummary(ids []string, token string) (*p
 
 	b, err := json.Marshal(ri)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	r, err := ic.Post(portfolios.PortfolioPassFailSummaryEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to request portfolio status summary: %v", err.Error())
+		return nil, fmt.Errorf("failed to request portfolio status summary: %w", err)
 	}
 
 	var ps portfolios.PortfolioPassingFailingSummary
 	err = json.Unmarshal(r, &ps)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return &ps, nil
@@ -113,18 +113,18 @@ func (ic *IonClient) GetPortfolioStartedErroredSummary(ids []string, token strin
 
 	b, err := json.Marshal(ri)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	r, err := ic.Post(portfolios.PortfolioStartedErroredSummaryEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to request portfolio status summary: %v", err.Error())
+		return nil, fmt.Errorf("failed to request portfolio status summary: %w", err)
 	}
 
 	var ps portfolios.PortfolioStartedErroredSummary
 	err = json.Unmarshal(r, &ps)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return &ps, nil
@@ -138,13 +138,13 @@ func (ic *IonClient) GetPortfolioAffectedProjects(teamID, externalID, token stri
 
 	r, _, err := ic.Get(portfolios.PortfolioGetAffectedProjectIdsEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to request portfolio affected projects: %v", err.Error())
+		return nil, fmt.Errorf("failed to request portfolio affected projects: %w", err)
 	}
 
 	var aps []portfolios.AffectedProject
 	err = json.Unmarshal(r, &aps)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return aps, nil
@@ -158,18 +158,18 @@ func (ic *IonClient) GetPortfolioAffectedProjectsInfo(ids []string, token string
 
 	b, err := json.Marshal(ri)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	r, err := ic.Post(portfolios.PortfolioGetAffectedProjectsInfoEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to request portfolio affected projects info: %v", err.Error())
+		return nil, fmt.Errorf("failed to request portfolio affected projects info: %w", err)
 	}
 
 	var aps []portfolios.AffectedProject
 	err = json.Unmarshal(r, &aps)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return aps, nil
@@ -185,19 +185,19 @@ func (ic *IonClient) GetDependencyStats(ids []string, token string) (*portfolios
 
 	b, err := json.Marshal(p)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	r, err := ic.Post(portfolios.DependencyStatsEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to request dependency list: %v", err.Error())
+		return nil, fmt.Errorf("failed to request dependency list: %w", err)
 	}
 
 	var ds portfolios.DependencyStat
 	err = json.Unmarshal(r, &ds)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal dependency stats response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal dependency stats response: %w", err)
 	}
 
 	return &ds, nil
@@ -213,12 +213,12 @@ func (ic *IonClient) GetRawDependencyList(ids []string, listType, limit, token s
 
 	b, err := json.Marshal(p)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	resp, err := ic.Post(portfolios.DependencyListEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to request dependency list: %v", err.Error())
+		return nil, fmt.Errorf("failed to request dependency list: %w", err)
 	}
 
 	return resp, nil
@@ -234,20 +234,20 @@ func (ic *IonClient) GetProjectsStatusHistory(ids []string, token string) ([]por
 
 	b, err := json.Marshal(p)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	r, err := ic.Post(portfolios.RulesetsGetStatusesHistoryEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to request status history: %v", err.Error())
+		return nil, fmt.Errorf("failed to request status history: %w", err)
 	}
 
 	var sh []portfolios.StatusesHistory
 	err = json.Unmarshal(r, &sh)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal status history response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal status history response: %w", err)
 	}
 
 	return sh, nil
@@ -263,14 +263,14 @@ func (ic *IonClient) GetMttr(teamID, projectID string, token string) (*portfolio
 	r, _, err := ic.Get(portfolios.ReportsGetMttrEndpoint, token, params, nil, nil)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to request mttr: %v", err.Error())
+		return nil, fmt.Errorf("failed to request mttr: %w", err)
 	}
 
 	var mttr portfolios.Mttr
 	err = json.Unmarshal(r, &mttr)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal mttr response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal mttr response: %w", err)
 	}
 
 	return &mttr, nil
@@ -286,13 +286,13 @@ func (ic *IonClient) GetProjectIdsByDependency(teamID, name, org, version, token
 
 	r, _, err := ic.Get(portfolios.PortfolioGetProjectIdsByDependencyEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to request portfolio get projects by dependency: %v", err.Error())
+		return nil, fmt.Errorf("failed to request portfolio get projects by dependency: %w", err)
 	}
 
 	var aps portfolios.ProjectsByDependency
 	err = json.Unmarshal(r, &aps)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return &aps, nil
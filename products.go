@@ -19,13 +19,13 @@ func (ic *IonClient) GetProducts(idSearch, token string) ([]products.Product, er
 
 	b, _, err := ic.Get(products.GetProductEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get raw product: %v", err.Error())
+		return nil, fmt.Errorf("failed to get raw product: %w", err)
 	}
 
 	var ps []products.Product
 	err = json.Unmarshal(b, &ps)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get products: %v", err.Error())
+		return nil, fmt.Errorf("failed to get products: %w", err)
 	}
 
 	return ps, nil
@@ -42,13 +42,13 @@ func (ic *IonClient) GetProductVersions(name, version, token string) ([]products
 
 	b, _, err := ic.Get(products.GetProductVersionsEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get product versions: %v", err.Error())
+		return nil, fmt.Errorf("failed to get product versions: %w", err)
 	}
 
 	var ps []products.Product
 	err = json.Unmarshal(b, &ps)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get product versions: %v", err.Error())
+		return nil, fmt.Errorf("failed to get product versions: %w", err)
 	}
 
 	return ps, nil
@@ -88,7 +88,7 @@ func (ic *IonClient) GetRawProducts(idSearch, token string) (json.RawMessage, er
 
 	b, _, err := ic.Get(products.GetProductEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get raw product: %v", err.Error())
+		return nil, fmt.Errorf("failed to get raw product: %w", err)
 	}
 
 	return b, nil
@@ -102,12 +102,12 @@ func (ic *IonClient) GetProductSearch(query string, page *pagination.Pagination,
 
 	b, m, err := ic.Get(products.ProductSearchEndpoint, token, params, nil, page)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to GetProductSearch: %v", err.Error())
+		return nil, nil, fmt.Errorf("failed to GetProductSearch: %w", err)
 	}
 	var products []products.Product
 	err = json.Unmarshal(b, &products)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to parse products: %v", err.Error())
+		return nil, nil, fmt.Errorf("failed to parse products: %w", err)
 	}
 	return products, m, nil
 }
@@ -35,27 +35,23 @@ func (ic *IonClient) CreateProject(project *projects.Project, teamID, token stri
 
 	b, err := json.Marshal(project)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshall project: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshall project: %w", err)
 	}
 
 	b, err = ic.Post(projects.CreateProjectEndpoint, token, params, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create project: %v", err.Error())
+		return nil, fmt.Errorf("failed to create project: %w", err)
 	}
 
 	var p projects.Project
 	err = json.Unmarshal(b, &p)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response from create: %v", err.Error())
+		return nil, fmt.Errorf("failed to read response from create: %w", err)
 	}
 
-	fields, err := p.Validate(ic.client, ic.baseURL, token)
+	fields, err := ic.validateProject(&p, token)
 	if err != nil {
-		var errs []string
-		for _, msg := range fields {
-			errs = append(errs, msg)
-		}
-		return nil, fmt.Errorf("%v: %v", projects.ErrInvalidProject, strings.Join(errs, ", "))
+		return nil, validationError(fields, err)
 	}
 
 	return &p, nil
@@ -74,17 +70,17 @@ func (ic *IonClient) CreateProjectsFromCSV(csvFile, teamID, token string) (*Crea
 
 	fw, err := w.CreateFormFile("file", csvFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %v", err.Error())
+		return nil, fmt.Errorf("failed to create form file: %w", err)
 	}
 
 	fh, err := os.Open(csvFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %v", err.Error())
+		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 
 	_, err = io.Copy(fw, fh)
 	if err != nil {
-		return nil, fmt.Errorf("failed to copy file contents: %v", err.Error())
+		return nil, fmt.Errorf("failed to copy file contents: %w", err)
 	}
 
 	w.Close()
@@ -94,13 +90,13 @@ func (ic *IonClient) CreateProjectsFromCSV(csvFile, teamID, token string) (*Crea
 
 	b, err := ic.Post(projects.CreateProjectsFromCSVEndpoint, token, params, buf, h)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create projects: %v", err.Error())
+		return nil, fmt.Errorf("failed to create projects: %w", err)
 	}
 
 	var resp CreateProjectsResponse
 	err = json.Unmarshal(b, &resp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %v", err.Error())
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	return &resp, nil
@@ -116,13 +112,13 @@ func (ic *IonClient) GetProject(id, teamID, token string) (*projects.Project, er
 
 	b, _, err := ic.Get(projects.GetProjectEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project: %v", err.Error())
+		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
 	var p projects.Project
 	err = json.Unmarshal(b, &p)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project: %v", err.Error())
+		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
 	return &p, nil
@@ -137,7 +133,7 @@ func (ic *IonClient) GetRawProject(id, teamID, token string) (json.RawMessage, e
 
 	b, _, err := ic.Get(projects.GetProjectEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project: %v", err.Error())
+		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
 	return b, nil
@@ -155,13 +151,13 @@ func (ic *IonClient) GetProjects(teamID, token string, page *pagination.Paginati
 
 	b, _, err := ic.Get(projects.GetProjectsEndpoint, token, params, nil, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get projects: %v", err.Error())
+		return nil, fmt.Errorf("failed to get projects: %w", err)
 	}
 
 	var pList []projects.Project
 	err = json.Unmarshal(b, &pList)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal projects: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal projects: %w", err)
 	}
 
 	return pList, nil
@@ -177,13 +173,13 @@ func (ic *IonClient) GetProjectByURL(uri, teamID, token string) (*projects.Proje
 
 	b, _, err := ic.Get(projects.GetProjectByURLEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get projects by url: %v", err.Error())
+		return nil, fmt.Errorf("failed to get projects by url: %w", err)
 	}
 
 	var p projects.Project
 	err = json.Unmarshal(b, &p)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal projects: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal projects: %w", err)
 	}
 
 	return &p, nil
@@ -195,16 +191,12 @@ func (ic *IonClient) UpdateProject(project *projects.Project, token string) (*pr
 	params := &url.Values{}
 
 	if project.ID == nil {
-		return nil, fmt.Errorf("%v: %v", projects.ErrInvalidProject, "missing id")
+		return nil, fmt.Errorf("%w: %v", projects.ErrInvalidProject, "missing id")
 	}
 
-	fields, err := project.Validate(ic.client, ic.baseURL, token)
+	fields, err := ic.validateProject(project, token)
 	if err != nil {
-		var errs []string
-		for _, msg := range fields {
-			errs = append(errs, msg)
-		}
-		return nil, fmt.Errorf("%v: %v", projects.ErrInvalidProject, strings.Join(errs, ", "))
+		return nil, validationError(fields, err)
 	}
 
 	params.Set("id", *project.ID)
@@ -222,18 +214,18 @@ func (ic *IonClient) UpdateProject(project *projects.Project, token string) (*pr
 
 	b, err := json.Marshal(project)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshall project: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshall project: %w", err)
 	}
 
 	b, err = ic.Put(projects.UpdateProjectEndpoint, token, params, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update projects: %v", err.Error())
+		return nil, fmt.Errorf("failed to update projects: %w", err)
 	}
 
 	var p projects.Project
 	err = json.Unmarshal(b, &p)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response from update: %v", err.Error())
+		return nil, fmt.Errorf("failed to read response from update: %w", err)
 	}
 
 	return &p, nil
@@ -246,13 +238,13 @@ func (ic *IonClient) GetUsedRulesetIds(teamID, token string) ([]projects.Ruleset
 
 	b, _, err := ic.Get(projects.GetUsedRulesetIdsEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get team's ruleset ids: %v", err.Error())
+		return nil, fmt.Errorf("failed to get team's ruleset ids: %w", err)
 	}
 
 	var rList []projects.RulesetID
 	err = json.Unmarshal(b, &rList)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal team's ruleset ids: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal team's ruleset ids: %w", err)
 	}
 
 	return rList, nil
@@ -267,19 +259,51 @@ func (ic *IonClient) GetProjectsNames(teamID string, ids []string, token string)
 
 	b, err := json.Marshal(p)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	r, err := ic.Post(projects.GetProjectsNamesEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get projects names and versions: %v", err.Error())
+		return nil, fmt.Errorf("failed to get projects names and versions: %w", err)
 	}
 
 	var list []projects.Name
 	err = json.Unmarshal(r, &list)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal projects names: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal projects names: %w", err)
 	}
 
 	return list, nil
 }
+
+// validateProject validates a project, checking its ruleset through the
+// client's pipeline so the check uses the client's token source, default
+// headers, and hooks like any other call.
+func (ic *IonClient) validateProject(p *projects.Project, token string) (map[string]string, error) {
+	headers := ic.headersFor(nil)
+	token, err := ic.tokenFor(ic.Context(), token, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := requests.WithHeaders(requests.WithHooks(ic.Context(), ic.hooks...), headers)
+
+	return p.ValidateWithContext(ctx, ic.client, ic.baseURL, token)
+}
+
+// validationError returns the error for a project that failed validation.  A
+// project with invalid fields is an ErrInvalidProject listing them, while any
+// other failure, such as an error checking its ruleset, is wrapped so its
+// cause can still be inspected.
+func validationError(fields map[string]string, err error) error {
+	if len(fields) == 0 {
+		return fmt.Errorf("failed to validate project: %w", err)
+	}
+
+	var errs []string
+	for _, msg := range fields {
+		errs = append(errs, msg)
+	}
+
+	return fmt.Errorf("%w: %v", projects.ErrInvalidProject, strings.Join(errs, ", "))
+}
@@ -0,0 +1,60 @@
+package projects
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// commitHashRegex matches a git commit hash, which is exactly 40 lower-case
+	// hex characters
+	commitHashRegex = regexp.MustCompile(`^[a-f0-9]{40}$`)
+)
+
+// GitSource splits a git location, such as an SPDX download location or a
+// CycloneDX VCS reference, into the repository to clone, the branch to
+// monitor, and the commit it pins, if any.  A leading "git+" is removed.  The
+// revision may follow a trailing '@', as SPDX gives it, or be the URL
+// fragment, as npm and CycloneDX give it; a fragment after an '@' revision is
+// an SPDX sub-path and is dropped.  The branch is HEAD, the remote's default
+// branch, when no revision is given or the revision is a commit.
+func GitSource(location string) (source, branch, commit string) {
+	source = strings.TrimPrefix(strings.TrimSpace(location), "git+")
+
+	revision := ""
+	if i := strings.Index(source, "#"); i != -1 {
+		source, revision = source[:i], source[i+1:]
+	}
+
+	// the '@' of a user in the host, as in git@github.com:org/repo.git, comes
+	// before the path, and branch names cannot contain colons
+	if i := strings.LastIndex(source, "@"); i > pathIndex(source) {
+		if possible := source[i+1:]; !strings.Contains(possible, ":") {
+			source, revision = source[:i], possible
+		}
+	}
+
+	if commitHashRegex.MatchString(revision) {
+		return source, "HEAD", revision
+	}
+
+	if revision == "" {
+		return source, "HEAD", ""
+	}
+
+	return source, revision, ""
+}
+
+// pathIndex returns the index at which the path of a git location begins,
+// after the host of a URL or the colon of an scp-like location
+func pathIndex(location string) int {
+	if i := strings.Index(location, "://"); i != -1 {
+		if j := strings.Index(location[i+3:], "/"); j != -1 {
+			return i + 3 + j
+		}
+
+		return len(location)
+	}
+
+	return strings.Index(location, ":")
+}
@@ -1,6 +1,7 @@
 package projects
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -151,6 +152,12 @@ func (p *Project) ProjectReachable(client *http.Client, baseURL *url.URL, token
 
 // ValidateRequiredFields verifies the project contains the fields required
 func (p *Project) ValidateRequiredFields(client *http.Client, baseURL *url.URL, token string) (map[string]string, error) {
+	return p.ValidateRequiredFieldsWithContext(context.Background(), client, baseURL, token)
+}
+
+// ValidateRequiredFieldsWithContext performs the same validation as
+// ValidateRequiredFields, bound to the provided context.
+func (p *Project) ValidateRequiredFieldsWithContext(ctx context.Context, client *http.Client, baseURL *url.URL, token string) (map[string]string, error) {
 	invalidFields := make(map[string]string)
 	var projErr error
 
@@ -190,9 +197,9 @@ func (p *Project) ValidateRequiredFields(client *http.Client, baseURL *url.URL,
 	}
 
 	if p.RulesetID != nil && p.TeamID != nil {
-		exists, err := rulesets.RuleSetExists(client, baseURL, *p.RulesetID, *p.TeamID, token)
+		exists, err := rulesets.RuleSetExistsWithContext(ctx, client, baseURL, *p.RulesetID, *p.TeamID, token)
 		if err != nil {
-			return nil, fmt.Errorf("failed to determine if ruleset exists: %v", err.Error())
+			return nil, fmt.Errorf("failed to determine if ruleset exists: %w", err)
 		}
 
 		if !exists {
@@ -250,6 +257,12 @@ func (p *Project) ValidateRequiredFields(client *http.Client, baseURL *url.URL,
 // Since this also checks for project reachability, ValidateRequiredFields
 // can be used to skip that check.
 func (p *Project) Validate(client *http.Client, baseURL *url.URL, token string) (map[string]string, error) {
+	return p.ValidateWithContext(context.Background(), client, baseURL, token)
+}
+
+// ValidateWithContext performs the same validation as Validate, bound to the
+// provided context.
+func (p *Project) ValidateWithContext(ctx context.Context, client *http.Client, baseURL *url.URL, token string) (map[string]string, error) {
 	invalidFields := make(map[string]string)
 	var projErr error
 
@@ -289,9 +302,9 @@ func (p *Project) Validate(client *http.Client, baseURL *url.URL, token string)
 	}
 
 	if p.RulesetID != nil && p.TeamID != nil {
-		exists, err := rulesets.RuleSetExists(client, baseURL, *p.RulesetID, *p.TeamID, token)
+		exists, err := rulesets.RuleSetExistsWithContext(ctx, client, baseURL, *p.RulesetID, *p.TeamID, token)
 		if err != nil {
-			return nil, fmt.Errorf("failed to determine if ruleset exists: %v", err.Error())
+			return nil, fmt.Errorf("failed to determine if ruleset exists: %w", err)
 		}
 
 		if !exists {
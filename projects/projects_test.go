@@ -354,6 +354,36 @@ func TestProject(t *testing.T) {
 		})
 	})
 
+	g.Describe("Git Source", func() {
+		g.It("should split git locations into source, branch, and commit", func() {
+			hash := "0123456789abcdef0123456789abcdef01234567"
+			table := []struct {
+				Location string
+				Source   string
+				Branch   string
+				Commit   string
+			}{
+				{"https://github.com/some-org/pkg.git", "https://github.com/some-org/pkg.git", "HEAD", ""},
+				{"git+https://github.com/some-org/pkg.git@main", "https://github.com/some-org/pkg.git", "main", ""},
+				{"https://github.com/some-org/pkg.git@ian/some-branch", "https://github.com/some-org/pkg.git", "ian/some-branch", ""},
+				{"git+https://github.com/some-org/pkg.git#main", "https://github.com/some-org/pkg.git", "main", ""},
+				{"git+https://github.com/some-org/pkg.git@v1.2.3#lib/pkg.go", "https://github.com/some-org/pkg.git", "v1.2.3", ""},
+				{"git+https://github.com/some-org/pkg.git@" + hash, "https://github.com/some-org/pkg.git", "HEAD", hash},
+				{"git+https://github.com/some-org/pkg.git@release-" + hash + "-fix", "https://github.com/some-org/pkg.git", "release-" + hash + "-fix", ""},
+				{"git+https://git@github.com/some-org/pkg.git", "https://git@github.com/some-org/pkg.git", "HEAD", ""},
+				{"git@github.com:some-org/pkg.git", "git@github.com:some-org/pkg.git", "HEAD", ""},
+				{"git@github.com:some-org/pkg.git@main", "git@github.com:some-org/pkg.git", "main", ""},
+			}
+
+			for _, row := range table {
+				source, branch, commit := GitSource(row.Location)
+				Expect(source).To(Equal(row.Source), row.Location)
+				Expect(branch).To(Equal(row.Branch), row.Location)
+				Expect(commit).To(Equal(row.Commit), row.Location)
+			}
+		})
+	})
+
 	g.Describe("Project Filters", func() {
 		g.Describe("To Param String", func() {
 			g.It("should convert the filter to params", func() {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/franela/goblin"
 	"github.com/gomicro/bogus"
+	"github.com/ion-channel/ionic/errors"
 	"github.com/ion-channel/ionic/projects"
 	. "github.com/onsi/gomega"
 )
@@ -43,6 +44,22 @@ func TestProjects(t *testing.T) {
 			Expect(*project.Name).To(Equal("Statler"))
 		})
 
+		g.It("should keep the cause of a failed ruleset check", func() {
+			server.AddPath("/v1/project/createProject").
+				SetMethods("POST").
+				SetPayload([]byte(SampleValidProject)).
+				SetStatus(http.StatusCreated)
+			server.AddPath("/v1/ruleset/getRuleset").
+				SetMethods("HEAD").
+				SetStatus(http.StatusUnauthorized)
+
+			project, err := client.CreateProject(&projects.Project{}, "bef86653-1926-4990-8ef8-5f26cd59d6fc", "")
+			Expect(project).To(BeNil())
+			Expect(errors.IsUnauthorized(err)).To(BeTrue())
+			Expect(errors.Is(err, projects.ErrInvalidProject)).To(BeFalse())
+			Expect(err.Error()).To(ContainSubstring("failed to validate project"))
+		})
+
 		g.It("should get a project", func() {
 			server.AddPath("/v1/project/getProject").
 				SetMethods("GET").
@@ -55,6 +72,22 @@ func TestProjects(t *testing.T) {
 			Expect(*project.Name).To(Equal("Statler"))
 		})
 
+		g.It("should return a typed error for a missing project", func() {
+			server.AddPath("/v1/project/getProject").
+				SetMethods("GET").
+				SetPayload([]byte(`{"message":"project not found","code":404}`)).
+				SetStatus(http.StatusNotFound)
+
+			project, err := client.GetProject("334c183d-4d37-4515-84c4-0d0ed0fb8db0", "bef86653-1926-4990-8ef8-5f26cd59d6fc", "")
+			Expect(project).To(BeNil())
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			var ierr *errors.IonError
+			Expect(errors.As(err, &ierr)).To(BeTrue())
+			Expect(ierr.ResponseStatus).To(Equal(http.StatusNotFound))
+			Expect(ierr.Message).To(Equal("project not found"))
+		})
+
 		g.It("should get a raw project", func() {
 			server.AddPath("/v1/project/getProject").
 				SetMethods("GET").
@@ -22,13 +22,13 @@ func (ic *IonClient) GetAnalysisReport(analysisID, teamID, projectID, token stri
 
 	b, _, err := ic.Get(reports.ReportGetAnalysisReportEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis report: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis report: %w", err)
 	}
 
 	var r reports.AnalysisReport
 	err = json.Unmarshal(b, &r)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal analysis report: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal analysis report: %w", err)
 	}
 
 	return &r, nil
@@ -45,7 +45,7 @@ func (ic *IonClient) GetRawAnalysisReport(analysisID, teamID, projectID, token s
 
 	b, _, err := ic.Get(reports.ReportGetAnalysisReportEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis report: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis report: %w", err)
 	}
 
 	return b, nil
@@ -60,13 +60,13 @@ func (ic *IonClient) GetProjectReport(projectID, teamID, token string) (*reports
 
 	b, _, err := ic.Get(reports.ReportGetProjectReportEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project report: %v", err.Error())
+		return nil, fmt.Errorf("failed to get project report: %w", err)
 	}
 
 	var r reports.ProjectReport
 	err = json.Unmarshal(b, &r)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal project report: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal project report: %w", err)
 	}
 
 	return &r, nil
@@ -81,7 +81,7 @@ func (ic *IonClient) GetRawProjectReport(projectID, teamID, token string) (json.
 
 	b, _, err := ic.Get(reports.ReportGetProjectReportEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project report: %v", err.Error())
+		return nil, fmt.Errorf("failed to get project report: %w", err)
 	}
 
 	return b, nil
@@ -98,13 +98,13 @@ func (ic *IonClient) GetAnalysisNavigation(analysisID, teamID, projectID, token
 
 	b, _, err := ic.Get(reports.ReportGetAnalysisNavigationEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis navigation: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis navigation: %w", err)
 	}
 
 	var n scanner.Navigation
 	err = json.Unmarshal(b, &n)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis: %w", err)
 	}
 
 	return &n, nil
@@ -120,20 +120,20 @@ func (ic *IonClient) GetExportedProjectsData(ids []string, teamID, token string)
 
 	b, err := json.Marshal(p)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	r, err := ic.Post(reports.ReportGetExportedDataEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to request exported data: %v", err.Error())
+		return nil, fmt.Errorf("failed to request exported data: %w", err)
 	}
 
 	var ed reports.ExportedData
 	err = json.Unmarshal(r, &ed)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal exported projects data response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal exported projects data response: %w", err)
 	}
 
 	return &ed, nil
@@ -149,28 +149,34 @@ func (ic *IonClient) GetExportedVulnerabilityData(ids []string, teamID, token st
 
 	b, err := json.Marshal(p)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	r, err := ic.Post(reports.ReportGetExportedVulnerabilityDataEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to request exported data: %v", err.Error())
+		return nil, fmt.Errorf("failed to request exported data: %w", err)
 	}
 
 	var ed []analyses.VulnerabilityExportData
 	err = json.Unmarshal(r, &ed)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal exported projects data response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal exported projects data response: %w", err)
 	}
 
 	return &ed, nil
 }
 
-// GetSBOM takes slice of project ids, team id, SBOM format, and token.
-// Returns one or more SBOMs for the requested project(s).
-func (ic *IonClient) GetSBOM(ids []string, teamID string, options reports.SBOMExportOptions, token string) (string, error) {
+// GetSBOM takes slice of project ids, team id, SBOM export options, and token.
+// Returns the SBOM for the requested project(s), along with its content type,
+// spec version, and the projects that could not be exported.
+func (ic *IonClient) GetSBOM(ids []string, teamID string, options reports.SBOMExportOptions, token string) (*reports.SBOMExport, error) {
+	err := options.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body := requests.ByIDsAndTeamID{
 		TeamID: teamID,
 		IDs:    ids,
@@ -178,7 +184,7 @@ func (ic *IonClient) GetSBOM(ids []string, teamID string, options reports.SBOMEx
 
 	b, err := json.Marshal(body)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	params := options.Params()
@@ -186,8 +192,8 @@ func (ic *IonClient) GetSBOM(ids []string, teamID string, options reports.SBOMEx
 	r, err := ic.Post(reports.ReportGetSBOMEndpoint, token, params, *bytes.NewBuffer(b), nil)
 
 	if err != nil {
-		return "", fmt.Errorf("failed to request SBOM: %v", err.Error())
+		return nil, fmt.Errorf("failed to request SBOM: %w", err)
 	}
 
-	return string(r), nil
+	return reports.NewSBOMExport(r, options)
 }
@@ -1,8 +1,15 @@
 package reports
 
 import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
 	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -20,10 +27,42 @@ const (
 	SBOMFormatCycloneDX SBOMFormat = "CycloneDX"
 )
 
+// SBOMSerialization is a string enum for the encodings an SBOM can be exported in
+type SBOMSerialization string
+
+const (
+	// SBOMSerializationJSON is the enum value for JSON SBOMs, the default
+	SBOMSerializationJSON SBOMSerialization = "json"
+	// SBOMSerializationXML is the enum value for XML SBOMs
+	SBOMSerializationXML SBOMSerialization = "xml"
+	// SBOMSerializationTagValue is the enum value for SPDX tag-value SBOMs
+	SBOMSerializationTagValue SBOMSerialization = "tag-value"
+)
+
+var (
+	// ErrInvalidSBOMOptions is returned when SBOM export options name an
+	// unknown format or serialization, or a serialization the format does not
+	// have
+	ErrInvalidSBOMOptions = fmt.Errorf("invalid SBOM export options")
+
+	tagValueVersionRegex = regexp.MustCompile(`(?m)^\s*SPDXVersion\s*:\s*(\S+)`)
+	cycloneDXNamespace   = "http://cyclonedx.org/schema/bom/"
+)
+
 // SBOMExportOptions represents all of the different settings a user can specify for how the SBOM is exported.
 type SBOMExportOptions struct {
+	// Format is the format of the SBOM.  The API's default is used if it is
+	// not given.
 	Format              SBOMFormat
 	IncludeDependencies bool
+	// Serialization is the encoding of the SBOM, which defaults to JSON
+	Serialization SBOMSerialization
+	// SpecVersion is the version of the format's specification to export,
+	// such as 2.2 for SPDX or 1.4 for CycloneDX.  The API's default is used
+	// if it is not given.
+	SpecVersion            string
+	IncludeVulnerabilities bool
+	IncludeLicenses        bool
 }
 
 // Params converts an SBOMExportOptions object into a URL param object for use in making an API request
@@ -32,5 +71,222 @@ func (options SBOMExportOptions) Params() *url.Values {
 	params.Set("sbom_type", string(options.Format))
 	params.Set("include_dependencies", strconv.FormatBool(options.IncludeDependencies))
 
+	if options.Serialization != "" {
+		params.Set("serialization", string(options.Serialization))
+	}
+
+	if options.SpecVersion != "" {
+		params.Set("spec_version", options.SpecVersion)
+	}
+
+	if options.IncludeVulnerabilities {
+		params.Set("include_vulnerabilities", "true")
+	}
+
+	if options.IncludeLicenses {
+		params.Set("include_licenses", "true")
+	}
+
 	return params
 }
+
+// Validate returns an ErrInvalidSBOMOptions error if the options name an
+// unknown format or serialization, or a serialization the format does not
+// have.  Options without a format are left to the API's default format.
+func (options SBOMExportOptions) Validate() error {
+	switch options.Format {
+	case "", SBOMFormatSPDX, SBOMFormatCycloneDX:
+	default:
+		return fmt.Errorf("%w: unknown SBOM format %q", ErrInvalidSBOMOptions, options.Format)
+	}
+
+	switch options.serialization() {
+	case SBOMSerializationJSON, SBOMSerializationXML:
+	case SBOMSerializationTagValue:
+		if options.Format == SBOMFormatCycloneDX {
+			return fmt.Errorf("%w: %v SBOMs cannot be serialized as %v", ErrInvalidSBOMOptions, options.Format, options.Serialization)
+		}
+	default:
+		return fmt.Errorf("%w: unknown SBOM serialization %q", ErrInvalidSBOMOptions, options.Serialization)
+	}
+
+	return nil
+}
+
+func (options SBOMExportOptions) serialization() SBOMSerialization {
+	if options.Serialization == "" {
+		return SBOMSerializationJSON
+	}
+
+	return options.Serialization
+}
+
+// ContentType returns the media type of SBOMs exported with the options
+func (options SBOMExportOptions) ContentType() string {
+	switch options.Format {
+	case SBOMFormatSPDX:
+		switch options.serialization() {
+		case SBOMSerializationXML:
+			return "application/spdx+xml"
+		case SBOMSerializationTagValue:
+			return "text/spdx"
+		default:
+			return "application/spdx+json"
+		}
+	case SBOMFormatCycloneDX:
+		if options.serialization() == SBOMSerializationXML {
+			return "application/vnd.cyclonedx+xml"
+		}
+
+		return "application/vnd.cyclonedx+json"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// SBOMProjectError is the reason a project could not be included in an SBOM
+// export
+type SBOMProjectError struct {
+	ProjectID string `json:"project_id"`
+	Message   string `json:"message"`
+}
+
+// Error meets the error interface
+func (e SBOMProjectError) Error() string {
+	return fmt.Sprintf("project %v: %v", e.ProjectID, e.Message)
+}
+
+// SBOMExport is an SBOM exported for one or more projects, along with how it
+// is encoded and the projects that could not be exported
+type SBOMExport struct {
+	Format        SBOMFormat
+	Serialization SBOMSerialization
+	// SpecVersion is the version of the format's specification the SBOM
+	// follows, as given in the SBOM
+	SpecVersion string
+	ContentType string
+	Content     []byte
+	Errors      []SBOMProjectError
+}
+
+// sbomExportResponse is the data of an SBOM export response that reports
+// errors alongside the SBOM
+type sbomExportResponse struct {
+	SBOM   json.RawMessage    `json:"sbom"`
+	Errors []SBOMProjectError `json:"errors"`
+}
+
+// NewSBOMExport creates an SBOMExport from the data of an SBOM export response
+// for the options it was requested with.  The data may be the SBOM itself,
+// either as a JSON document or as a JSON string of its encoding, or an object
+// holding the SBOM under "sbom" along with per project errors under "errors".
+func NewSBOMExport(data json.RawMessage, options SBOMExportOptions) (*SBOMExport, error) {
+	export := &SBOMExport{
+		Format:        options.Format,
+		Serialization: options.serialization(),
+		ContentType:   options.ContentType(),
+	}
+
+	data = bytes.TrimSpace(data)
+
+	var resp sbomExportResponse
+	if len(data) > 0 && data[0] == '{' {
+		err := json.Unmarshal(data, &resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read SBOM export: %w", err)
+		}
+	}
+
+	if resp.SBOM != nil || resp.Errors != nil {
+		data = bytes.TrimSpace(resp.SBOM)
+		export.Errors = resp.Errors
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var content string
+		err := json.Unmarshal(data, &content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read SBOM export: %w", err)
+		}
+
+		data = []byte(content)
+	}
+
+	if string(data) == "null" {
+		data = nil
+	}
+
+	export.Content = data
+	export.SpecVersion = specVersion(data)
+	if export.SpecVersion == "" {
+		export.SpecVersion = options.SpecVersion
+	}
+
+	return export, nil
+}
+
+// specVersion returns the specification version given in an SBOM, if it can
+// be found
+func specVersion(content []byte) string {
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) == 0 {
+		return ""
+	}
+
+	switch trimmed[0] {
+	case '{':
+		var doc struct {
+			SPDXVersion string `json:"spdxVersion"`
+			SpecVersion string `json:"specVersion"`
+		}
+
+		if json.Unmarshal(trimmed, &doc) != nil {
+			return ""
+		}
+
+		if doc.SPDXVersion != "" {
+			return strings.TrimPrefix(doc.SPDXVersion, "SPDX-")
+		}
+
+		return doc.SpecVersion
+	case '<':
+		dec := xml.NewDecoder(bytes.NewReader(trimmed))
+		for {
+			t, err := dec.Token()
+			if err != nil {
+				return ""
+			}
+
+			if start, ok := t.(xml.StartElement); ok {
+				if strings.HasPrefix(start.Name.Space, cycloneDXNamespace) {
+					return strings.TrimPrefix(start.Name.Space, cycloneDXNamespace)
+				}
+
+				return ""
+			}
+		}
+	default:
+		if m := tagValueVersionRegex.FindSubmatch(trimmed); m != nil {
+			return strings.TrimPrefix(string(m[1]), "SPDX-")
+		}
+
+		return ""
+	}
+}
+
+// Reader returns a reader of the SBOM's content
+func (e *SBOMExport) Reader() io.Reader {
+	return bytes.NewReader(e.Content)
+}
+
+// WriteTo writes the SBOM's content to the writer, meeting the io.WriterTo
+// interface
+func (e *SBOMExport) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(e.Content)
+	return int64(n), err
+}
+
+// String returns the SBOM's content
+func (e *SBOMExport) String() string {
+	return string(e.Content)
+}
@@ -0,0 +1,107 @@
+package reports
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestSBOMExport(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("SBOM Export Options", func() {
+		g.It("should only add the params that are set", func() {
+			params := SBOMExportOptions{Format: SBOMFormatSPDX}.Params()
+			Expect(params.Encode()).To(Equal("include_dependencies=false&sbom_type=SPDX"))
+
+			params = SBOMExportOptions{
+				Format:                 SBOMFormatCycloneDX,
+				IncludeDependencies:    true,
+				Serialization:          SBOMSerializationXML,
+				SpecVersion:            "1.4",
+				IncludeVulnerabilities: true,
+				IncludeLicenses:        true,
+			}.Params()
+			Expect(params.Get("serialization")).To(Equal("xml"))
+			Expect(params.Get("spec_version")).To(Equal("1.4"))
+			Expect(params.Get("include_vulnerabilities")).To(Equal("true"))
+			Expect(params.Get("include_licenses")).To(Equal("true"))
+		})
+
+		g.It("should validate formats and serializations", func() {
+			Expect(SBOMExportOptions{Format: SBOMFormatSPDX}.Validate()).To(BeNil())
+			Expect(SBOMExportOptions{Format: SBOMFormatSPDX, Serialization: SBOMSerializationTagValue}.Validate()).To(BeNil())
+			Expect(SBOMExportOptions{Format: SBOMFormatCycloneDX, Serialization: SBOMSerializationTagValue}.Validate()).NotTo(BeNil())
+			Expect(SBOMExportOptions{Format: SBOMFormatCycloneDX, Serialization: "yaml"}.Validate()).NotTo(BeNil())
+			Expect(SBOMExportOptions{Format: "SWID"}.Validate()).NotTo(BeNil())
+			Expect(errors.Is(SBOMExportOptions{Format: "SWID"}.Validate(), ErrInvalidSBOMOptions)).To(BeTrue())
+		})
+
+		g.It("should leave options without a format to the API's default", func() {
+			opts := SBOMExportOptions{IncludeDependencies: true}
+			Expect(opts.Validate()).To(BeNil())
+			Expect(opts.Params().Encode()).To(Equal("include_dependencies=true&sbom_type="))
+		})
+
+		g.It("should give content types", func() {
+			Expect(SBOMExportOptions{Format: SBOMFormatSPDX}.ContentType()).To(Equal("application/spdx+json"))
+			Expect(SBOMExportOptions{Format: SBOMFormatSPDX, Serialization: SBOMSerializationTagValue}.ContentType()).To(Equal("text/spdx"))
+			Expect(SBOMExportOptions{Format: SBOMFormatCycloneDX, Serialization: SBOMSerializationXML}.ContentType()).To(Equal("application/vnd.cyclonedx+xml"))
+		})
+	})
+
+	g.Describe("SBOM Export", func() {
+		g.It("should read an SBOM given as a document", func() {
+			data := json.RawMessage(`{"bomFormat": "CycloneDX", "specVersion": "1.4", "version": 1}`)
+
+			e, err := NewSBOMExport(data, SBOMExportOptions{Format: SBOMFormatCycloneDX})
+			Expect(err).To(BeNil())
+			Expect(e.Serialization).To(Equal(SBOMSerializationJSON))
+			Expect(e.ContentType).To(Equal("application/vnd.cyclonedx+json"))
+			Expect(e.SpecVersion).To(Equal("1.4"))
+			Expect(e.Content).To(Equal([]byte(data)))
+			Expect(e.Errors).To(BeEmpty())
+		})
+
+		g.It("should read an SBOM given as a string", func() {
+			data := json.RawMessage(`"<?xml version=\"1.0\"?>\n<bom xmlns=\"http://cyclonedx.org/schema/bom/1.3\" version=\"1\"></bom>"`)
+
+			e, err := NewSBOMExport(data, SBOMExportOptions{Format: SBOMFormatCycloneDX, Serialization: SBOMSerializationXML})
+			Expect(err).To(BeNil())
+			Expect(e.SpecVersion).To(Equal("1.3"))
+			Expect(e.String()).To(HavePrefix("<?xml"))
+		})
+
+		g.It("should read per project errors", func() {
+			data := json.RawMessage(`{"sbom": {"spdxVersion": "SPDX-2.3"}, "errors": [{"project_id": "p1", "message": "not found"}]}`)
+
+			e, err := NewSBOMExport(data, SBOMExportOptions{Format: SBOMFormatSPDX, SpecVersion: "2.2"})
+			Expect(err).To(BeNil())
+			Expect(e.SpecVersion).To(Equal("2.3"))
+			Expect(e.String()).To(Equal(`{"spdxVersion": "SPDX-2.3"}`))
+			Expect(e.Errors).To(HaveLen(1))
+			Expect(e.Errors[0].Error()).To(Equal("project p1: not found"))
+
+			e, err = NewSBOMExport(json.RawMessage(`{"sbom": null, "errors": [{"project_id": "p1", "message": "not found"}]}`), SBOMExportOptions{Format: SBOMFormatSPDX, SpecVersion: "2.2"})
+			Expect(err).To(BeNil())
+			Expect(e.Content).To(BeEmpty())
+			Expect(e.SpecVersion).To(Equal("2.2"))
+		})
+
+		g.It("should write the SBOM as a stream", func() {
+			e, _ := NewSBOMExport(json.RawMessage(`"SPDXVersion: SPDX-2.1\n"`), SBOMExportOptions{Format: SBOMFormatSPDX, Serialization: SBOMSerializationTagValue})
+			Expect(e.SpecVersion).To(Equal("2.1"))
+
+			var b bytes.Buffer
+			n, err := e.WriteTo(&b)
+			Expect(err).To(BeNil())
+			Expect(n).To(Equal(int64(22)))
+			Expect(b.String()).To(Equal("SPDXVersion: SPDX-2.1\n"))
+		})
+	})
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/franela/goblin"
 	"github.com/gomicro/bogus"
+	"github.com/ion-channel/ionic/errors"
+	"github.com/ion-channel/ionic/reports"
 	. "github.com/onsi/gomega"
 )
 
@@ -118,6 +120,53 @@ func TestReports(t *testing.T) {
 			Expect(*d.Projects[1].HighVulnCount).To(Equal(9))
 			Expect(*d.Projects[1].VirusCount).To(Equal(1))
 		})
+
+		g.It("should get an SBOM", func() {
+			server.AddPath("/v1/report/getSBOM").
+				SetMethods("POST").
+				SetPayload([]byte(SampleSBOMExport)).
+				SetStatus(http.StatusOK)
+
+			opts := reports.SBOMExportOptions{
+				Format:        reports.SBOMFormatSPDX,
+				Serialization: reports.SBOMSerializationTagValue,
+			}
+
+			sbom, err := client.GetSBOM([]string{"foo_id", "bar_id"}, "someTeamID", opts, "atoken")
+			Expect(err).To(BeNil())
+			Expect(sbom.ContentType).To(Equal("text/spdx"))
+			Expect(sbom.SpecVersion).To(Equal("2.2"))
+			Expect(sbom.String()).To(HavePrefix("SPDXVersion: SPDX-2.2\n"))
+			Expect(sbom.Errors).To(Equal([]reports.SBOMProjectError{{ProjectID: "bar_id", Message: "no analysis found"}}))
+
+			hr := server.HitRecords()
+			Expect(hr[len(hr)-1].Query.Get("serialization")).To(Equal("tag-value"))
+		})
+
+		g.It("should request an SBOM in the API's default format", func() {
+			server.AddPath("/v1/report/getSBOM").
+				SetMethods("POST").
+				SetPayload([]byte(SampleSBOMExport)).
+				SetStatus(http.StatusOK)
+
+			sbom, err := client.GetSBOM([]string{"foo_id"}, "someTeamID", reports.SBOMExportOptions{}, "atoken")
+			Expect(err).To(BeNil())
+			Expect(sbom.SpecVersion).To(Equal("2.2"))
+
+			hr := server.HitRecords()
+			Expect(hr[len(hr)-1].Query.Get("sbom_type")).To(Equal(""))
+		})
+
+		g.It("should not request an SBOM with invalid options", func() {
+			opts := reports.SBOMExportOptions{
+				Format:        reports.SBOMFormatCycloneDX,
+				Serialization: reports.SBOMSerializationTagValue,
+			}
+
+			_, err := client.GetSBOM([]string{"foo_id"}, "someTeamID", opts, "atoken")
+			Expect(err).NotTo(BeNil())
+			Expect(errors.Is(err, reports.ErrInvalidSBOMOptions)).To(BeTrue())
+		})
 	})
 }
 
@@ -126,5 +175,7 @@ const (
 	SampleValidProjectReport  = `{"data":{"id":"AB3DC2C7-4BB8-4211-8F42-158C8AD4BAE3","team_id":"28FB6CD7-2F18-444F-9925-BAB75CFD4A04","ruleset_id":"25174480-5C8F-4C12-8E8D-3E9F125660BE","name":"Pepe","type":"git","source":"git@github.com:ion-channel/pepe.git","branch":"master","description":"","active":true,"chat_channel":"","created_at":"2017-05-26T21:18:28.667Z","updated_at":"2017-07-19T20:02:07.010Z","deploy_key":null,"should_monitor":false,"poc_name":"Daniel","poc_email":"","username":null,"password":null,"key_fingerprint":"","poc_name_hash":"","poc_email_hash":"","aliases":[],"tags":[],"ruleset_name":"Go Project Ruleset","analysis_summaries":[{"analysis_id":"F9D328A5-53E2-4D17-B0E3-09ED60CB1CA2","description":"","branch":"master","risk":"high","summary":"","passed":false,"ruleset_id":"25174480-5C8F-4C12-8E8D-3E9F125660BE","ruleset_name":"Go Project Ruleset","duration":34011.3134330059,"created_at":"2017-09-26T18:23:46.000Z","trigger_hash":"798627047292aa4342cb706c0a5507cd7340a39e","trigger_text":"Merge pull request #151 from ion-channel/test-for-new-account-emails\n\nAdd a test for ensuring we send the right link via email.","trigger_author":"Daniel Hess","trigger":"source commit"},{"analysis_id":"B1454451-C3F0-4226-B2A6-5427E3213116","description":"","branch":"master","risk":"high","summary":"","passed":false,"ruleset_id":"25174480-5C8F-4C12-8E8D-3E9F125660BE","ruleset_name":"Go Project Ruleset","duration":24763.7515759998,"created_at":"2017-07-19T23:19:51.000Z","trigger_hash":"bbd96df8639568106e5ef2fe4a2a7954a587ceb8","trigger_text":"Merge pull request #137 from ion-channel/testing-notice\n\nupdating to notify of running against testing","trigger_author":"Matthew Mayer","trigger":"source commit"}]}}`
 	SampleAnalysisNav         = `{"data":{"analysis":{"branch": "master","created_at": "2017-09-25T21:43:11.069Z","id": "analysis-id","message": "Request for analysis analysis-id on Amazon Web Services SDK has been accepted.","project_id": "93e2f31e-b579-4490-864d-7c630ac49720","status": "accepted","team_id": "team-id","updated_at": "2017-09-25T21:43:11.069Z"}, "latest_analysis":{"branch": "master","created_at": "2017-09-25T21:43:11.069Z","id": "analysis-id","message": "Request for analysis analysis-id on Amazon Web Services SDK has been accepted.","project_id": "93e2f31e-b579-4490-864d-7c630ac49720","status": "accepted","team_id": "team-id","updated_at": "2017-09-25T21:43:11.069Z"}}}`
 
+	SampleSBOMExport = `{"data":{"sbom":"SPDXVersion: SPDX-2.2\nDataLicense: CC0-1.0\n","errors":[{"project_id":"bar_id","message":"no analysis found"}]}}`
+
 	SampleExportedProjectsData = `{"data":{"created_at":"2020-10-14T18:44:36.1740216Z","projects":[{"project_name":"foo","project_id":"foo_id","product_name":"baz","version":"2","org":"org/foo","current_status":"failed","vuln_count":23,"critical_vuln_count":1,"high_vuln_count":5,"virus_count":0},{"project_name":"bar","project_id":"bar_id","product_name":"","version":"","org":"","current_status":"failed","vuln_count":26,"critical_vuln_count":3,"high_vuln_count":9,"virus_count":1}]},"meta":{"total_count":2,"offset":0,"last_update":"2020-10-14T18:44:36.1740346Z"}}`
 )
@@ -0,0 +1,120 @@
+package requests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ion-channel/ionic/errors"
+)
+
+type hooksKey struct{}
+type endpointKey struct{}
+type headersKey struct{}
+
+// CallInfo describes a single request made to the API.  It is passed to the
+// hooks before the request is sent and again once its response has been
+// handled.
+type CallInfo struct {
+	// Endpoint is the endpoint constant the request was made for, such as
+	// projects.GetProjectEndpoint
+	Endpoint string
+	// Method is the HTTP method of the request
+	Method string
+	// Request is the request about to be sent.  A before request hook may
+	// modify its headers, for example to add tracing headers.
+	Request *http.Request
+	// Status is the status code of the response, or zero if no response was
+	// received.  It is only set for the after response hook.
+	Status int
+	// Duration is how long the request took, including reading the response
+	// body.  It is only set for the after response hook.
+	Duration time.Duration
+	// Err is the error the request resulted in, if any.  It is only set for
+	// the after response hook.
+	Err *errors.IonError
+}
+
+// Hooks represents functions called around each request made to the API,
+// including each page fetched while paging.  Either function may be nil.
+type Hooks struct {
+	BeforeRequest func(call *CallInfo)
+	AfterResponse func(call *CallInfo)
+}
+
+// WithHooks returns a new context based on the provided context with the given
+// hooks added to any hooks it already carries.  Requests made with the context
+// call the before request hooks in the order they were added and the after
+// response hooks in the reverse order.
+func WithHooks(ctx context.Context, hooks ...Hooks) context.Context {
+	if len(hooks) == 0 {
+		return ctx
+	}
+
+	existing := hooksFromContext(ctx)
+	all := make([]Hooks, 0, len(existing)+len(hooks))
+	all = append(all, existing...)
+	all = append(all, hooks...)
+
+	return context.WithValue(ctx, hooksKey{}, all)
+}
+
+func hooksFromContext(ctx context.Context) []Hooks {
+	hooks, _ := ctx.Value(hooksKey{}).([]Hooks)
+	return hooks
+}
+
+// WithHeaders returns a new context based on the provided context carrying the
+// given headers.  Requests made with the context send the headers unless the
+// call sets a header of the same name itself.  It lets the headers of a client
+// reach requests made on its behalf by helpers that take no headers, such as
+// rulesets.RuleSetExistsWithContext.
+func WithHeaders(ctx context.Context, headers http.Header) context.Context {
+	if len(headers) == 0 {
+		return ctx
+	}
+
+	all := headersFromContext(ctx).Clone()
+	if all == nil {
+		all = http.Header{}
+	}
+
+	for k, vs := range headers {
+		all[k] = append([]string(nil), vs...)
+	}
+
+	return context.WithValue(ctx, headersKey{}, all)
+}
+
+func headersFromContext(ctx context.Context) http.Header {
+	headers, _ := ctx.Value(headersKey{}).(http.Header)
+	return headers
+}
+
+// EndpointFromContext returns the endpoint constant a request was made for
+// from the request's context.  It is intended for RoundTrippers wrapping the
+// transport used by the SDK.
+func EndpointFromContext(ctx context.Context) (string, bool) {
+	endpoint, ok := ctx.Value(endpointKey{}).(string)
+	return endpoint, ok
+}
+
+func withEndpoint(ctx context.Context, endpoint string) context.Context {
+	return context.WithValue(ctx, endpointKey{}, endpoint)
+}
+
+func before(hooks []Hooks, call *CallInfo) {
+	for _, h := range hooks {
+		if h.BeforeRequest != nil {
+			h.BeforeRequest(call)
+		}
+	}
+}
+
+func after(hooks []Hooks, call *CallInfo) {
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if hooks[i].AfterResponse != nil {
+			hooks[i].AfterResponse(call)
+		}
+	}
+}
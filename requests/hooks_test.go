@@ -0,0 +1,160 @@
+package requests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic/errors"
+	"github.com/ion-channel/ionic/pagination"
+	. "github.com/onsi/gomega"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestHooks(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Hooks", func() {
+		var server *httptest.Server
+		var baseURL *url.URL
+		var status int
+
+		g.BeforeEach(func() {
+			status = http.StatusOK
+
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if status != http.StatusOK {
+					w.WriteHeader(status)
+					fmt.Fprint(w, `{"message":"nope"}`)
+					return
+				}
+
+				if r.URL.Query().Get("limit") != "" {
+					offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+					fmt.Fprintf(w, `{"data":[%v],"meta":{"total_count":3}}`, offset)
+					return
+				}
+
+				fmt.Fprintf(w, `{"data":{"trace":%q},"meta":{"total_count":1}}`, r.Header.Get("X-Trace"))
+			}))
+
+			baseURL, _ = url.Parse(server.URL)
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+		})
+
+		g.It("should call the hooks around a request in order", func() {
+			var order []string
+			var calls []CallInfo
+
+			outer := Hooks{
+				BeforeRequest: func(c *CallInfo) {
+					order = append(order, "outer before")
+					c.Request.Header.Set("X-Trace", "abc")
+				},
+				AfterResponse: func(c *CallInfo) {
+					order = append(order, "outer after")
+					calls = append(calls, *c)
+				},
+			}
+			inner := Hooks{
+				BeforeRequest: func(c *CallInfo) { order = append(order, "inner before") },
+				AfterResponse: func(c *CallInfo) { order = append(order, "inner after") },
+			}
+
+			ctx := WithHooks(WithHooks(context.Background(), outer), inner)
+			b, _, err := GetWithContext(ctx, http.DefaultClient, baseURL, "v1/some/endpoint", "token", nil, nil, nil)
+			Expect(err).To(BeNil())
+			Expect(string(b)).To(Equal(`{"trace":"abc"}`))
+
+			Expect(order).To(Equal([]string{"outer before", "inner before", "inner after", "outer after"}))
+			Expect(calls).To(HaveLen(1))
+			Expect(calls[0].Endpoint).To(Equal("v1/some/endpoint"))
+			Expect(calls[0].Method).To(Equal("GET"))
+			Expect(calls[0].Status).To(Equal(http.StatusOK))
+			Expect(calls[0].Duration).To(BeNumerically(">", 0))
+			Expect(calls[0].Err).To(BeNil())
+		})
+
+		g.It("should report the error of a failed request", func() {
+			status = http.StatusNotFound
+
+			var call CallInfo
+			ctx := WithHooks(context.Background(), Hooks{
+				AfterResponse: func(c *CallInfo) { call = *c },
+			})
+
+			_, err := DeleteWithContext(ctx, http.DefaultClient, baseURL, "v1/some/endpoint", "token", nil, nil)
+			Expect(err).NotTo(BeNil())
+			Expect(call.Method).To(Equal("DELETE"))
+			Expect(call.Status).To(Equal(http.StatusNotFound))
+			Expect(call.Err).NotTo(BeNil())
+			Expect(errors.IsNotFound(call.Err)).To(BeTrue())
+			Expect(call.Err.Message).To(Equal("nope"))
+		})
+
+		g.It("should call the hooks for each page", func() {
+			var offsets []string
+			ctx := WithHooks(context.Background(), Hooks{
+				BeforeRequest: func(c *CallInfo) {
+					offsets = append(offsets, c.Request.URL.Query().Get("offset"))
+				},
+			})
+
+			it := NewIterator(ctx, http.DefaultClient, baseURL, "v1/some/endpoint", "token", nil, nil, pagination.New(0, 1))
+			for it.Next() {
+			}
+
+			Expect(it.Err()).To(BeNil())
+			Expect(offsets).To(Equal([]string{"0", "1", "2"}))
+		})
+
+		g.It("should make the endpoint available to round trippers", func() {
+			var endpoint string
+			client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				endpoint, _ = EndpointFromContext(r.Context())
+				return http.DefaultTransport.RoundTrip(r)
+			})}
+
+			_, _, err := Get(client, baseURL, "v1/some/endpoint", "token", nil, nil, nil)
+			Expect(err).To(BeNil())
+			Expect(endpoint).To(Equal("v1/some/endpoint"))
+		})
+
+		g.It("should leave the context alone when given no hooks", func() {
+			ctx := context.Background()
+			Expect(WithHooks(ctx)).To(Equal(ctx))
+		})
+
+		g.It("should send the headers carried by the context", func() {
+			h := http.Header{}
+			h.Set("X-Trace", "abc")
+			ctx := WithHeaders(context.Background(), h)
+
+			b, _, err := GetWithContext(ctx, http.DefaultClient, baseURL, "v1/some/endpoint", "token", nil, nil, nil)
+			Expect(err).To(BeNil())
+			Expect(string(b)).To(Equal(`{"trace":"abc"}`))
+
+			call := http.Header{}
+			call.Set("X-Trace", "def")
+			b, _, err = GetWithContext(ctx, http.DefaultClient, baseURL, "v1/some/endpoint", "token", nil, call, nil)
+			Expect(err).To(BeNil())
+			Expect(string(b)).To(Equal(`{"trace":"def"}`))
+
+			Expect(WithHeaders(ctx, nil)).To(Equal(ctx))
+		})
+	})
+}
@@ -0,0 +1,138 @@
+package requests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/ion-channel/ionic/errors"
+	"github.com/ion-channel/ionic/pagination"
+	"github.com/ion-channel/ionic/responses"
+)
+
+// Iterator walks through the items of a paginated endpoint, fetching each page
+// only once the items of the previous page have been consumed.  Iteration
+// stops at the end of the items, at the first error, or whenever the caller
+// stops calling Next.  An Iterator is not safe for concurrent use.
+type Iterator struct {
+	ctx      context.Context
+	client   *http.Client
+	method   string
+	baseURL  *url.URL
+	endpoint string
+	token    string
+	params   *url.Values
+	headers  http.Header
+	page     *pagination.Pagination
+
+	items []json.RawMessage
+	index int
+	value json.RawMessage
+	meta  *responses.Meta
+	done  bool
+	err   error
+}
+
+// NewIterator takes a context, client, baseURL, endpoint, token, params,
+// headers, and pagination params for a get call to the API.  It returns an
+// Iterator over the items of the endpoint.  The pagination params set the
+// offset of the first item and the number of items fetched with each page.  If
+// nil, iteration starts from the first item and fetches pages of 100 items.
+// It is used internally by the SDK
+func NewIterator(ctx context.Context, client *http.Client, baseURL *url.URL, endpoint, token string, params *url.Values, headers http.Header, page *pagination.Pagination) *Iterator {
+	return newIterator(ctx, client, "GET", baseURL, endpoint, token, params, headers, page)
+}
+
+func newIterator(ctx context.Context, client *http.Client, method string, baseURL *url.URL, endpoint, token string, params *url.Values, headers http.Header, page *pagination.Pagination) *Iterator {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	p := pagination.New(0, maxPagingLimit)
+	if page != nil {
+		p = pagination.New(page.Offset, page.Limit)
+		if page.Limit <= 0 {
+			p.Limit = maxPagingLimit
+		}
+	}
+
+	return &Iterator{
+		ctx:      ctx,
+		client:   client,
+		method:   method,
+		baseURL:  baseURL,
+		endpoint: endpoint,
+		token:    token,
+		params:   params,
+		headers:  headers,
+		page:     p,
+	}
+}
+
+// Next advances the iterator to the next item, fetching the next page if
+// needed.  It returns false once there are no more items or an error has been
+// encountered.
+func (it *Iterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.index >= len(it.items) {
+		if it.done || !it.fetch() {
+			it.value = nil
+			return false
+		}
+	}
+
+	it.value = it.items[it.index]
+	it.index++
+	return true
+}
+
+// Value returns the raw JSON of the current item
+func (it *Iterator) Value() json.RawMessage {
+	return it.value
+}
+
+// Scan unmarshals the current item into the provided value
+func (it *Iterator) Scan(v interface{}) error {
+	return json.Unmarshal(it.value, v)
+}
+
+// Err returns the error encountered while iterating, if any
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Meta returns the metadata of the most recently fetched page, or nil if no
+// page has been fetched
+func (it *Iterator) Meta() *responses.Meta {
+	return it.meta
+}
+
+// fetch requests the next page of items, returning false if the iteration
+// should stop.
+func (it *Iterator) fetch() bool {
+	if it.ctx.Err() != nil {
+		it.err = errors.Errors("no body", 0, "api: paging: %w", it.ctx.Err())
+		return false
+	}
+
+	items, meta, ierr := fetchPage(it.ctx, it.client, it.method, it.baseURL, it.endpoint, it.token, it.params, it.headers, it.page)
+	if ierr != nil {
+		it.err = ierr
+		return false
+	}
+
+	it.meta = meta
+	it.items = items
+	it.index = 0
+
+	it.page.Offset += it.page.Limit
+	if len(items) == 0 || it.page.Offset >= meta.TotalCount {
+		it.done = true
+	}
+
+	return len(items) > 0
+}
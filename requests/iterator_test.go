@@ -0,0 +1,145 @@
+package requests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic/pagination"
+	. "github.com/onsi/gomega"
+)
+
+func TestIterator(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Iterator", func() {
+		var server *httptest.Server
+		var baseURL *url.URL
+		var items []int
+		var total int
+		var hits int
+
+		g.BeforeEach(func() {
+			items = nil
+			for i := 0; i < 25; i++ {
+				items = append(items, i)
+			}
+			total = len(items)
+			hits = 0
+
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits++
+				offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+				limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+				page := []int{}
+				for i := offset; i < offset+limit && i < len(items); i++ {
+					page = append(page, items[i])
+				}
+
+				b, _ := json.Marshal(page)
+				fmt.Fprintf(w, `{"data":%s,"meta":{"total_count":%v,"offset":%v,"limit":%v}}`, b, total, offset, limit)
+			}))
+
+			baseURL, _ = url.Parse(server.URL)
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+		})
+
+		g.It("should iterate over every item a page at a time", func() {
+			it := NewIterator(context.Background(), http.DefaultClient, baseURL, "some/endpoint", "", nil, nil, &pagination.Pagination{Limit: 10})
+
+			var got []int
+			for it.Next() {
+				var v int
+				Expect(it.Scan(&v)).To(BeNil())
+				got = append(got, v)
+			}
+
+			Expect(it.Err()).To(BeNil())
+			Expect(got).To(Equal(items))
+			Expect(hits).To(Equal(3))
+			Expect(it.Meta().TotalCount).To(Equal(25))
+		})
+
+		g.It("should only fetch the pages needed", func() {
+			it := NewIterator(context.Background(), http.DefaultClient, baseURL, "some/endpoint", "", nil, nil, &pagination.Pagination{Limit: 10})
+
+			for i := 0; i < 11; i++ {
+				Expect(it.Next()).To(BeTrue())
+			}
+
+			Expect(string(it.Value())).To(Equal("10"))
+			Expect(hits).To(Equal(2))
+		})
+
+		g.It("should start from the given offset", func() {
+			it := NewIterator(context.Background(), http.DefaultClient, baseURL, "some/endpoint", "", nil, nil, &pagination.Pagination{Offset: 20, Limit: 10})
+
+			count := 0
+			for it.Next() {
+				count++
+			}
+
+			Expect(count).To(Equal(5))
+			Expect(hits).To(Equal(1))
+		})
+
+		g.It("should stop on an empty page", func() {
+			total = 1000
+
+			it := NewIterator(context.Background(), http.DefaultClient, baseURL, "some/endpoint", "", nil, nil, &pagination.Pagination{Limit: 10})
+
+			count := 0
+			for it.Next() {
+				count++
+			}
+
+			Expect(it.Err()).To(BeNil())
+			Expect(count).To(Equal(25))
+			Expect(hits).To(Equal(4))
+		})
+
+		g.It("should join every page when getting all items", func() {
+			total = 1000
+
+			b, m, err := Get(http.DefaultClient, baseURL, "some/endpoint", "", nil, nil, &pagination.Pagination{})
+			Expect(err).To(BeNil())
+			Expect(m.TotalCount).To(Equal(1000))
+
+			var got []int
+			Expect(json.Unmarshal(b, &got)).To(BeNil())
+			Expect(got).To(Equal(items))
+		})
+
+		g.It("should return an empty list when there are no items", func() {
+			items = nil
+			total = 0
+
+			b, _, err := Get(http.DefaultClient, baseURL, "some/endpoint", "", nil, nil, &pagination.Pagination{})
+			Expect(err).To(BeNil())
+			Expect(string(b)).To(Equal("[]"))
+		})
+
+		g.It("should return errors from the api", func() {
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			})
+
+			it := NewIterator(context.Background(), http.DefaultClient, baseURL, "some/endpoint", "", nil, nil, nil)
+			Expect(it.Next()).To(BeFalse())
+			Expect(it.Err()).NotTo(BeNil())
+			Expect(it.Err().Error()).To(ContainSubstring("(500) api: paging"))
+			Expect(it.Meta()).To(BeNil())
+		})
+	})
+}
@@ -0,0 +1,127 @@
+package requests
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// Limiter represents a client side rate limit.  Wait blocks until a request
+// is allowed to proceed, returning an error if the context is done first.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// TokenBucket is a Limiter that allows bursts of up to its capacity and
+// refills at a steady rate.  It is safe for concurrent use.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket takes a rate in requests per second and the burst size.  It
+// returns a full bucket.  A burst of less than 1 is treated as 1.
+func NewTokenBucket(perSecond float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &TokenBucket{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or the context is done.  A bucket
+// with a rate of zero or less never limits.
+func (tb *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		wait := tb.reserve(time.Now())
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available and returns zero, otherwise it
+// returns how long until the next token is available.
+func (tb *TokenBucket) reserve(now time.Time) time.Duration {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	if tb.rate <= 0 {
+		return 0
+	}
+
+	tb.tokens += now.Sub(tb.last).Seconds() * tb.rate
+	if tb.tokens > tb.burst {
+		tb.tokens = tb.burst
+	}
+	tb.last = now
+
+	if tb.tokens >= 1 {
+		tb.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - tb.tokens) / tb.rate * float64(time.Second))
+}
+
+// InFlightLimit caps the number of requests in flight at any one time.  A
+// request holds its slot until its response body is closed.  It is safe for
+// concurrent use.
+type InFlightLimit struct {
+	slots chan struct{}
+}
+
+// NewInFlightLimit takes the maximum number of requests allowed in flight and
+// returns the limit.  A max of less than 1 is treated as 1.
+func NewInFlightLimit(max int) *InFlightLimit {
+	if max < 1 {
+		max = 1
+	}
+
+	return &InFlightLimit{slots: make(chan struct{}, max)}
+}
+
+// Acquire blocks until a slot is available or the context is done.
+func (l *InFlightLimit) Acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees a slot taken by Acquire.
+func (l *InFlightLimit) Release() {
+	<-l.slots
+}
+
+// releaseOnClose releases its slot once the wrapped body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
@@ -0,0 +1,67 @@
+package requests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestLimits(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Token Bucket", func() {
+		g.It("should allow a burst before limiting", func() {
+			tb := NewTokenBucket(1, 2)
+			now := tb.last
+
+			Expect(tb.reserve(now)).To(Equal(time.Duration(0)))
+			Expect(tb.reserve(now)).To(Equal(time.Duration(0)))
+			Expect(tb.reserve(now)).To(Equal(time.Second))
+		})
+
+		g.It("should refill at the given rate", func() {
+			tb := NewTokenBucket(10, 1)
+			now := tb.last
+
+			Expect(tb.reserve(now)).To(Equal(time.Duration(0)))
+			Expect(tb.reserve(now)).To(Equal(100 * time.Millisecond))
+			Expect(tb.reserve(now.Add(100 * time.Millisecond))).To(Equal(time.Duration(0)))
+		})
+
+		g.It("should never limit with no rate", func() {
+			tb := NewTokenBucket(0, 1)
+
+			for i := 0; i < 5; i++ {
+				Expect(tb.Wait(context.Background())).To(BeNil())
+			}
+		})
+
+		g.It("should stop waiting when the context is done", func() {
+			tb := NewTokenBucket(0.001, 1)
+			Expect(tb.Wait(context.Background())).To(BeNil())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			Expect(tb.Wait(ctx)).To(Equal(context.DeadlineExceeded))
+		})
+	})
+
+	g.Describe("In Flight Limit", func() {
+		g.It("should block once all slots are taken", func() {
+			l := NewInFlightLimit(1)
+			Expect(l.Acquire(context.Background())).To(BeNil())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			Expect(l.Acquire(ctx)).To(Equal(context.DeadlineExceeded))
+
+			l.Release()
+			Expect(l.Acquire(context.Background())).To(BeNil())
+		})
+	})
+}
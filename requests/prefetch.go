@@ -0,0 +1,155 @@
+package requests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/ion-channel/ionic/errors"
+	"github.com/ion-channel/ionic/pagination"
+	"github.com/ion-channel/ionic/responses"
+)
+
+// GetAllWithContext takes a context, client, baseURL, endpoint, token, params,
+// headers, and a number of workers to get every item of a paginated endpoint.
+// The first page is fetched on its own to learn the total count of items, then
+// the remaining pages are fetched by up to the given number of workers at once
+// and joined in order.  The remaining pages are the size of the first, so an
+// API that returns smaller pages than asked for is still read in full.  It
+// will return a json RawMessage of all the items and any errors it encounters
+// with the API.  The first error encountered cancels any pages still being
+// fetched.  With fewer than two workers the pages are fetched one after
+// another, the same as Get with a zero limit.
+// It is used internally by the SDK
+func GetAllWithContext(ctx context.Context, client *http.Client, baseURL *url.URL, endpoint, token string, params *url.Values, headers http.Header, workers int) (json.RawMessage, *responses.Meta, error) {
+	if workers < 2 {
+		return GetWithContext(ctx, client, baseURL, endpoint, token, params, headers, &pagination.Pagination{})
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	first, meta, ierr := fetchPage(ctx, client, "GET", baseURL, endpoint, token, params, headers, pagination.New(0, maxPagingLimit))
+	if ierr != nil {
+		return nil, nil, ierr
+	}
+
+	total := meta.TotalCount
+
+	size := len(first)
+
+	count := 0
+	if size > 0 && total > size {
+		count = (total - 1) / size
+	}
+
+	pages := make([][]json.RawMessage, count+1)
+	pages[0] = first
+
+	if count > 0 {
+		err := fetchPages(ctx, client, baseURL, endpoint, token, params, headers, pages, size, workers)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var data bytes.Buffer
+	data.WriteString("[")
+
+	n := 0
+	for _, items := range pages {
+		for _, item := range items {
+			if n > 0 {
+				data.WriteString(",")
+			}
+
+			data.Write(item)
+			n++
+		}
+	}
+
+	data.WriteString("]")
+	return data.Bytes(), &responses.Meta{TotalCount: total}, nil
+}
+
+// fetchPages fills in every page after the first, fetching pages of the given
+// size with a pool of workers.
+func fetchPages(ctx context.Context, client *http.Client, baseURL *url.URL, endpoint, token string, params *url.Values, headers http.Header, pages [][]json.RawMessage, size, workers int) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if workers > len(pages)-1 {
+		workers = len(pages) - 1
+	}
+
+	jobs := make(chan int)
+	var once sync.Once
+	var firstErr error
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				page := pagination.New(i*size, size)
+
+				items, _, ierr := fetchPage(ctx, client, "GET", baseURL, endpoint, token, params, headers, page)
+				if ierr != nil {
+					once.Do(func() {
+						firstErr = ierr
+						cancel()
+					})
+					continue
+				}
+
+				pages[i] = items
+			}
+		}()
+	}
+
+	for i := 1; i < len(pages); i++ {
+		if ctx.Err() != nil {
+			break
+		}
+
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	if ctx.Err() != nil {
+		return errors.Errors("no body", 0, "api: paging: %w", ctx.Err())
+	}
+
+	return nil
+}
+
+// fetchPage gets a single page, returning its items and the metadata reported
+// by the API.
+func fetchPage(ctx context.Context, client *http.Client, method string, baseURL *url.URL, endpoint, token string, params *url.Values, headers http.Header, page *pagination.Pagination) ([]json.RawMessage, *responses.Meta, *errors.IonError) {
+	ir, ierr := _do(ctx, client, method, baseURL, endpoint, token, params, bytes.Buffer{}, headers, page)
+	if ierr != nil {
+		ierr.Prepend("api: paging")
+		return nil, nil, ierr
+	}
+
+	var items []json.RawMessage
+	if len(ir.Data) > 0 {
+		err := json.Unmarshal(ir.Data, &items)
+		if err != nil {
+			return nil, nil, errors.Errors(string(ir.Data), 0, "api: paging: malformed page: %w", err)
+		}
+	}
+
+	return items, &ir.Meta, nil
+}
@@ -0,0 +1,145 @@
+package requests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestPrefetch(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Prefetch", func() {
+		var server *httptest.Server
+		var baseURL *url.URL
+		var items []int
+		var hits int32
+		var inFlight int32
+		var maxInFlight int32
+		var failOffset string
+		var pageCap int
+
+		g.BeforeEach(func() {
+			items = nil
+			for i := 0; i < 550; i++ {
+				items = append(items, i)
+			}
+			hits = 0
+			inFlight = 0
+			maxInFlight = 0
+			failOffset = ""
+			pageCap = 0
+
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&hits, 1)
+				n := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+
+				for {
+					m := atomic.LoadInt32(&maxInFlight)
+					if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+						break
+					}
+				}
+
+				if r.URL.Query().Get("offset") == failOffset {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				time.Sleep(5 * time.Millisecond)
+
+				offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+				limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+				if pageCap > 0 && limit > pageCap {
+					limit = pageCap
+				}
+
+				page := []int{}
+				for i := offset; i < offset+limit && i < len(items); i++ {
+					page = append(page, items[i])
+				}
+
+				b, _ := json.Marshal(page)
+				fmt.Fprintf(w, `{"data":%s,"meta":{"total_count":%v}}`, b, len(items))
+			}))
+
+			baseURL, _ = url.Parse(server.URL)
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+		})
+
+		g.It("should fetch every page concurrently and keep the order", func() {
+			params := &url.Values{}
+			params.Set("team_id", "someteam")
+
+			b, m, err := GetAllWithContext(context.Background(), http.DefaultClient, baseURL, "some/endpoint", "sometoken", params, http.Header{}, 3)
+			Expect(err).To(BeNil())
+			Expect(m.TotalCount).To(Equal(550))
+
+			var got []int
+			Expect(json.Unmarshal(b, &got)).To(BeNil())
+			Expect(got).To(Equal(items))
+			Expect(hits).To(Equal(int32(6)))
+			Expect(maxInFlight).To(BeNumerically(">", 1))
+			Expect(maxInFlight).To(BeNumerically("<=", 3))
+			Expect(params.Encode()).To(Equal("team_id=someteam"))
+		})
+
+		g.It("should fetch a single page once", func() {
+			items = items[:10]
+
+			b, _, err := GetAllWithContext(context.Background(), http.DefaultClient, baseURL, "some/endpoint", "", nil, nil, 4)
+			Expect(err).To(BeNil())
+
+			var got []int
+			Expect(json.Unmarshal(b, &got)).To(BeNil())
+			Expect(got).To(Equal(items))
+			Expect(hits).To(Equal(int32(1)))
+		})
+
+		g.It("should fetch every page when the API returns smaller pages", func() {
+			pageCap = 50
+
+			b, m, err := GetAllWithContext(context.Background(), http.DefaultClient, baseURL, "some/endpoint", "", nil, nil, 3)
+			Expect(err).To(BeNil())
+			Expect(m.TotalCount).To(Equal(550))
+
+			var got []int
+			Expect(json.Unmarshal(b, &got)).To(BeNil())
+			Expect(got).To(Equal(items))
+			Expect(hits).To(Equal(int32(11)))
+		})
+
+		g.It("should fetch pages one after another with a single worker", func() {
+			b, _, err := GetAllWithContext(context.Background(), http.DefaultClient, baseURL, "some/endpoint", "", nil, nil, 1)
+			Expect(err).To(BeNil())
+
+			var got []int
+			Expect(json.Unmarshal(b, &got)).To(BeNil())
+			Expect(got).To(Equal(items))
+			Expect(maxInFlight).To(Equal(int32(1)))
+		})
+
+		g.It("should return the first error encountered", func() {
+			failOffset = "300"
+
+			_, _, err := GetAllWithContext(context.Background(), http.DefaultClient, baseURL, "some/endpoint", "", nil, nil, 2)
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("(500) api: paging"))
+		})
+	})
+}
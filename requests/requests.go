@@ -2,12 +2,14 @@ package requests
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/ion-channel/ionic/errors"
 	"github.com/ion-channel/ionic/pagination"
@@ -29,9 +31,13 @@ type ByIDsAndTeamID struct {
 	IDs    []string `json:"ids"`
 }
 
-func do(client *http.Client, method string, baseURL *url.URL, endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header, page *pagination.Pagination) (json.RawMessage, *responses.Meta, error) {
+func do(ctx context.Context, client *http.Client, method string, baseURL *url.URL, endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header, page *pagination.Pagination) (json.RawMessage, *responses.Meta, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	if page == nil || page.Limit > 0 {
-		ir, err := _do(client, method, baseURL, endpoint, token, params, payload, headers, page)
+		ir, err := _do(ctx, client, method, baseURL, endpoint, token, params, payload, headers, page)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -39,70 +45,108 @@ func do(client *http.Client, method string, baseURL *url.URL, endpoint, token st
 		return ir.Data, &ir.Meta, nil
 	}
 
-	page = pagination.New(0, maxPagingLimit)
-	var data json.RawMessage
-	data = append(data, []byte("[")...)
+	it := newIterator(ctx, client, method, baseURL, endpoint, token, params, headers, nil)
 
-	total := 1
-	for page.Offset < total {
-		ir, err := _do(client, method, baseURL, endpoint, token, params, payload, headers, page)
-		if err != nil {
-			err.Prepend("api: paging")
-			return nil, nil, err
+	var data bytes.Buffer
+	data.WriteString("[")
+
+	for count := 0; it.Next(); count++ {
+		if count > 0 {
+			data.WriteString(",")
 		}
 
-		data = append(data, ir.Data[1:len(ir.Data)-1]...)
-		data = append(data, []byte(",")...)
-		page.Up()
-		total = ir.Meta.TotalCount
+		data.Write(it.Value())
+	}
+
+	if it.Err() != nil {
+		return nil, nil, it.Err()
+	}
+
+	data.WriteString("]")
+
+	total := 0
+	if it.Meta() != nil {
+		total = it.Meta().TotalCount
 	}
 
-	data = append(data[:len(data)-1], []byte("]")...)
-	return data, &responses.Meta{TotalCount: total}, nil
+	return data.Bytes(), &responses.Meta{TotalCount: total}, nil
 }
 
-func _do(client *http.Client, method string, baseURL *url.URL, endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header, page *pagination.Pagination) (*responses.IonResponse, *errors.IonError) {
+func _do(ctx context.Context, client *http.Client, method string, baseURL *url.URL, endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header, page *pagination.Pagination) (*responses.IonResponse, *errors.IonError) {
 	u := createURL(baseURL, endpoint, params, page)
 
-	req, err := http.NewRequest(strings.ToUpper(method), u.String(), &payload)
+	req, err := http.NewRequestWithContext(withEndpoint(ctx, endpoint), strings.ToUpper(method), u.String(), &payload)
 	if err != nil {
-		return nil, errors.Errors("no body", 0, "http request: failed to create: %v", err.Error())
+		return nil, errors.Errors("no body", 0, "http request: failed to create: %w", err)
 	}
 
 	if headers != nil {
-		req.Header = headers
+		req.Header = headers.Clone()
 	}
 
-	if token != "" {
+	for k, vs := range headersFromContext(ctx) {
+		if _, ok := req.Header[k]; !ok {
+			req.Header[k] = append([]string(nil), vs...)
+		}
+	}
+
+	if token != "" && req.Header.Get("Authorization") == "" {
 		req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", token))
 	}
 
+	hooks := hooksFromContext(ctx)
+	if len(hooks) == 0 {
+		ir, _, ierr := exchange(client, req)
+		return ir, ierr
+	}
+
+	call := &CallInfo{
+		Endpoint: endpoint,
+		Method:   req.Method,
+		Request:  req,
+	}
+	before(hooks, call)
+
+	start := time.Now()
+	ir, status, ierr := exchange(client, req)
+
+	call.Status = status
+	call.Duration = time.Since(start)
+	call.Err = ierr
+	after(hooks, call)
+
+	return ir, ierr
+}
+
+// exchange sends the request and reads the response, returning the response
+// status along with the parsed response.
+func exchange(client *http.Client, req *http.Request) (*responses.IonResponse, int, *errors.IonError) {
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, errors.Errors("no body", 0, "http request: failed: %v", err.Error())
+		return nil, 0, errors.Errors("no body", 0, "http request: failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, errors.Errors("no body", resp.StatusCode, "response body: failed to read: %v", err.Error())
+		return nil, resp.StatusCode, errors.Errors("no body", resp.StatusCode, "response body: failed to read: %w", err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, errors.Errors(string(body), resp.StatusCode, "api: error response")
+		return nil, resp.StatusCode, errors.Errors(string(body), resp.StatusCode, "api: error response")
 	}
 
-	if strings.ToUpper(method) == "HEAD" || strings.ToUpper(method) == "DELETE" {
-		return &responses.IonResponse{}, nil
+	if req.Method == "HEAD" || req.Method == "DELETE" {
+		return &responses.IonResponse{}, resp.StatusCode, nil
 	}
 
 	var ir responses.IonResponse
 	err = json.Unmarshal(body, &ir)
 	if err != nil {
-		return nil, errors.Errors(string(body), resp.StatusCode, "api: malformed response: %v", err.Error())
+		return nil, resp.StatusCode, errors.Errors(string(body), resp.StatusCode, "api: malformed response: %w", err)
 	}
 
-	return &ir, nil
+	return &ir, resp.StatusCode, nil
 }
 
 func createURL(baseURL *url.URL, endpoint string, params *url.Values, page *pagination.Pagination) *url.URL {
@@ -111,7 +155,9 @@ func createURL(baseURL *url.URL, endpoint string, params *url.Values, page *pagi
 
 	vals := &url.Values{}
 	if params != nil {
-		vals = params
+		for k, vs := range *params {
+			(*vals)[k] = append([]string(nil), vs...)
+		}
 	}
 
 	if page != nil {
@@ -127,7 +173,14 @@ func createURL(baseURL *url.URL, endpoint string, params *url.Values, page *pagi
 // encounters with the API.
 // It is used internally by the SDK
 func Delete(client *http.Client, baseURL *url.URL, endpoint, token string, params *url.Values, headers http.Header) (json.RawMessage, error) {
-	r, _, err := do(client, "DELETE", baseURL, endpoint, token, params, bytes.Buffer{}, headers, nil)
+	return DeleteWithContext(context.Background(), client, baseURL, endpoint, token, params, headers)
+}
+
+// DeleteWithContext performs the same call as Delete, but binds the request to
+// the provided context so it can be cancelled or given a deadline.
+// It is used internally by the SDK
+func DeleteWithContext(ctx context.Context, client *http.Client, baseURL *url.URL, endpoint, token string, params *url.Values, headers http.Header) (json.RawMessage, error) {
+	r, _, err := do(ctx, client, "DELETE", baseURL, endpoint, token, params, bytes.Buffer{}, headers, nil)
 	return r, err
 }
 
@@ -135,7 +188,14 @@ func Delete(client *http.Client, baseURL *url.URL, endpoint, token string, param
 // head call to the API.  It will return any errors it encounters with the API.
 // It is used internally by the SDK
 func Head(client *http.Client, baseURL *url.URL, endpoint, token string, params *url.Values, headers http.Header, page *pagination.Pagination) error {
-	_, _, err := do(client, "HEAD", baseURL, endpoint, token, params, bytes.Buffer{}, headers, page)
+	return HeadWithContext(context.Background(), client, baseURL, endpoint, token, params, headers, page)
+}
+
+// HeadWithContext performs the same call as Head, but binds the request to the
+// provided context so it can be cancelled or given a deadline.
+// It is used internally by the SDK
+func HeadWithContext(ctx context.Context, client *http.Client, baseURL *url.URL, endpoint, token string, params *url.Values, headers http.Header, page *pagination.Pagination) error {
+	_, _, err := do(ctx, client, "HEAD", baseURL, endpoint, token, params, bytes.Buffer{}, headers, page)
 	return err
 }
 
@@ -144,7 +204,15 @@ func Head(client *http.Client, baseURL *url.URL, endpoint, token string, params
 // any errors it encounters with the API.
 // It is used internally by the SDK
 func Get(client *http.Client, baseURL *url.URL, endpoint, token string, params *url.Values, headers http.Header, page *pagination.Pagination) (json.RawMessage, *responses.Meta, error) {
-	r, m, err := do(client, "GET", baseURL, endpoint, token, params, bytes.Buffer{}, headers, page)
+	return GetWithContext(context.Background(), client, baseURL, endpoint, token, params, headers, page)
+}
+
+// GetWithContext performs the same call as Get, but binds the request to the
+// provided context so it can be cancelled or given a deadline.  When paging
+// through all items the context is checked before each page is requested.
+// It is used internally by the SDK
+func GetWithContext(ctx context.Context, client *http.Client, baseURL *url.URL, endpoint, token string, params *url.Values, headers http.Header, page *pagination.Pagination) (json.RawMessage, *responses.Meta, error) {
+	r, m, err := do(ctx, client, "GET", baseURL, endpoint, token, params, bytes.Buffer{}, headers, page)
 	return r, m, err
 }
 
@@ -153,7 +221,14 @@ func Get(client *http.Client, baseURL *url.URL, endpoint, token string, params *
 // it encounters with the API.
 // It is used internally by the SDK
 func Post(client *http.Client, baseURL *url.URL, endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header) (json.RawMessage, error) {
-	r, _, err := do(client, "POST", baseURL, endpoint, token, params, payload, headers, nil)
+	return PostWithContext(context.Background(), client, baseURL, endpoint, token, params, payload, headers)
+}
+
+// PostWithContext performs the same call as Post, but binds the request to the
+// provided context so it can be cancelled or given a deadline.
+// It is used internally by the SDK
+func PostWithContext(ctx context.Context, client *http.Client, baseURL *url.URL, endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header) (json.RawMessage, error) {
+	r, _, err := do(ctx, client, "POST", baseURL, endpoint, token, params, payload, headers, nil)
 	return r, err
 }
 
@@ -162,7 +237,14 @@ func Post(client *http.Client, baseURL *url.URL, endpoint, token string, params
 // encounters with the API.
 // It is used internally by the SDK
 func Put(client *http.Client, baseURL *url.URL, endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header) (json.RawMessage, error) {
-	r, _, err := do(client, "PUT", baseURL, endpoint, token, params, payload, headers, nil)
+	return PutWithContext(context.Background(), client, baseURL, endpoint, token, params, payload, headers)
+}
+
+// PutWithContext performs the same call as Put, but binds the request to the
+// provided context so it can be cancelled or given a deadline.
+// It is used internally by the SDK
+func PutWithContext(ctx context.Context, client *http.Client, baseURL *url.URL, endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header) (json.RawMessage, error) {
+	r, _, err := do(ctx, client, "PUT", baseURL, endpoint, token, params, payload, headers, nil)
 	return r, err
 }
 
@@ -171,6 +253,13 @@ func Put(client *http.Client, baseURL *url.URL, endpoint, token string, params *
 // encounters with the API.
 // It is used internally by the SDK
 func Patch(client *http.Client, baseURL *url.URL, endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header) (json.RawMessage, error) {
-	r, _, err := do(client, "PATCH", baseURL, endpoint, token, params, payload, headers, nil)
+	return PatchWithContext(context.Background(), client, baseURL, endpoint, token, params, payload, headers)
+}
+
+// PatchWithContext performs the same call as Patch, but binds the request to
+// the provided context so it can be cancelled or given a deadline.
+// It is used internally by the SDK
+func PatchWithContext(ctx context.Context, client *http.Client, baseURL *url.URL, endpoint, token string, params *url.Values, payload bytes.Buffer, headers http.Header) (json.RawMessage, error) {
+	r, _, err := do(ctx, client, "PATCH", baseURL, endpoint, token, params, payload, headers, nil)
 	return r, err
 }
@@ -1,11 +1,14 @@
 package requests
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"testing"
 
 	"github.com/franela/goblin"
+	"github.com/gomicro/bogus"
 	"github.com/ion-channel/ionic/pagination"
 	. "github.com/onsi/gomega"
 )
@@ -52,5 +55,63 @@ func TestClient(t *testing.T) {
 			u := createURL(b, e, nil, p)
 			Expect(u.String()).To(Equal(fmt.Sprintf("%v/%v?limit=%v&offset=%v", b, e, l, o)))
 		})
+
+		g.Describe("With Context", func() {
+			var server *bogus.Bogus
+			var baseURL *url.URL
+
+			g.BeforeEach(func() {
+				server = bogus.New()
+				h, p := server.HostPort()
+				baseURL, _ = url.Parse(fmt.Sprintf("http://%v:%v", h, p))
+			})
+
+			g.AfterEach(func() {
+				server.Close()
+			})
+
+			g.It("should make a request with a live context", func() {
+				server.AddPath("/some/endpoint").
+					SetMethods("GET").
+					SetPayload([]byte(`{"data":{"foo":"bar"},"meta":{"total_count":1}}`)).
+					SetStatus(http.StatusOK)
+
+				b, m, err := GetWithContext(context.Background(), http.DefaultClient, baseURL, "some/endpoint", "", nil, nil, nil)
+				Expect(err).To(BeNil())
+				Expect(string(b)).To(Equal(`{"foo":"bar"}`))
+				Expect(m.TotalCount).To(Equal(1))
+				Expect(server.Hits()).To(Equal(1))
+			})
+
+			g.It("should not make a request with a cancelled context", func() {
+				server.AddPath("/some/endpoint").
+					SetMethods("GET").
+					SetPayload([]byte(`{"data":{"foo":"bar"}}`)).
+					SetStatus(http.StatusOK)
+
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				_, _, err := GetWithContext(ctx, http.DefaultClient, baseURL, "some/endpoint", "", nil, nil, nil)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(ContainSubstring("context canceled"))
+				Expect(server.Hits()).To(Equal(0))
+			})
+
+			g.It("should stop paging with a cancelled context", func() {
+				server.AddPath("/some/endpoint").
+					SetMethods("GET").
+					SetPayload([]byte(`{"data":[{"foo":"bar"}],"meta":{"total_count":500}}`)).
+					SetStatus(http.StatusOK)
+
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				_, _, err := GetWithContext(ctx, http.DefaultClient, baseURL, "some/endpoint", "", nil, nil, &pagination.Pagination{})
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(ContainSubstring("paging"))
+				Expect(server.Hits()).To(Equal(0))
+			})
+		})
 	})
 }
@@ -0,0 +1,144 @@
+package requests
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = 250 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+	defaultJitter         = 0.5
+)
+
+// RetryPolicy represents how requests that fail with a transient error should
+// be retried.  A nil policy, or one with a MaxAttempts of 1 or less, disables
+// retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request,
+	// including the first one.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry.  Each following
+	// retry doubles the previous wait.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the computed wait between attempts.  It does not cap a
+	// wait requested by the API through a Retry-After header.
+	MaxBackoff time.Duration
+	// Jitter is the fraction, between 0 and 1, of each computed wait that is
+	// randomized to keep many clients from retrying in lockstep.
+	Jitter float64
+	// RetryableStatuses are the response status codes considered transient.
+	RetryableStatuses []int
+	// RetryNonIdempotent enables retries for POST and PATCH requests.  Only
+	// enable it when the endpoints being called are safe to repeat.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a policy that retries idempotent requests up to
+// three times on rate limiting and gateway errors, backing off exponentially
+// from 250ms.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    defaultMaxAttempts,
+		InitialBackoff: defaultInitialBackoff,
+		MaxBackoff:     defaultMaxBackoff,
+		Jitter:         defaultJitter,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// retryable returns whether the policy allows retrying a request with the
+// given method.
+func (p *RetryPolicy) retryable(method string) bool {
+	if p == nil || p.MaxAttempts <= 1 {
+		return false
+	}
+
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	case http.MethodPost, http.MethodPatch:
+		return p.RetryNonIdempotent
+	}
+
+	return false
+}
+
+// retryableStatus returns whether the status code is one the policy considers
+// transient.
+func (p *RetryPolicy) retryableStatus(status int) bool {
+	for _, s := range p.RetryableStatuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the wait before the given retry, where the first retry is
+// attempt 1.  A Retry-After header on the response takes precedence over the
+// computed wait.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return d
+		}
+	}
+
+	wait := float64(p.InitialBackoff) * math.Pow(2, float64(attempt-1))
+	if p.MaxBackoff > 0 && wait > float64(p.MaxBackoff) {
+		wait = float64(p.MaxBackoff)
+	}
+
+	jitter := p.Jitter
+	if jitter < 0 {
+		jitter = 0
+	}
+
+	if jitter > 1 {
+		jitter = 1
+	}
+
+	wait -= wait * jitter * rand.Float64()
+	return time.Duration(wait)
+}
+
+// retryAfter parses the value of a Retry-After header, which can either be a
+// number of seconds or an HTTP date.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+
+		return time.Duration(secs) * time.Second, true
+	}
+
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	d := t.Sub(now)
+	if d < 0 {
+		d = 0
+	}
+
+	return d, true
+}
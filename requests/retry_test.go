@@ -0,0 +1,93 @@
+package requests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Retry Policy", func() {
+		g.It("should only retry idempotent methods by default", func() {
+			p := DefaultRetryPolicy()
+
+			Expect(p.retryable("GET")).To(BeTrue())
+			Expect(p.retryable("head")).To(BeTrue())
+			Expect(p.retryable("PUT")).To(BeTrue())
+			Expect(p.retryable("DELETE")).To(BeTrue())
+			Expect(p.retryable("POST")).To(BeFalse())
+			Expect(p.retryable("PATCH")).To(BeFalse())
+		})
+
+		g.It("should retry non idempotent methods when opted in", func() {
+			p := DefaultRetryPolicy()
+			p.RetryNonIdempotent = true
+
+			Expect(p.retryable("POST")).To(BeTrue())
+			Expect(p.retryable("PATCH")).To(BeTrue())
+		})
+
+		g.It("should not retry with a nil or single attempt policy", func() {
+			var p *RetryPolicy
+			Expect(p.retryable("GET")).To(BeFalse())
+
+			p = &RetryPolicy{MaxAttempts: 1}
+			Expect(p.retryable("GET")).To(BeFalse())
+		})
+
+		g.It("should back off exponentially up to the maximum", func() {
+			p := &RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+			Expect(p.backoff(1, nil)).To(Equal(time.Second))
+			Expect(p.backoff(2, nil)).To(Equal(2 * time.Second))
+			Expect(p.backoff(3, nil)).To(Equal(4 * time.Second))
+			Expect(p.backoff(4, nil)).To(Equal(5 * time.Second))
+		})
+
+		g.It("should keep jittered backoffs within range", func() {
+			p := &RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Jitter: 0.5}
+
+			for i := 0; i < 20; i++ {
+				d := p.backoff(1, nil)
+				Expect(d).To(BeNumerically(">", 500*time.Millisecond))
+				Expect(d).To(BeNumerically("<=", time.Second))
+			}
+		})
+
+		g.It("should prefer the retry after header", func() {
+			p := &RetryPolicy{InitialBackoff: time.Second}
+			resp := &http.Response{Header: http.Header{}}
+			resp.Header.Set("Retry-After", "7")
+
+			Expect(p.backoff(1, resp)).To(Equal(7 * time.Second))
+		})
+
+		g.It("should parse retry after values", func() {
+			now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+			d, ok := retryAfter("3", now)
+			Expect(ok).To(BeTrue())
+			Expect(d).To(Equal(3 * time.Second))
+
+			d, ok = retryAfter("Tue, 01 Jun 2021 12:00:30 GMT", now)
+			Expect(ok).To(BeTrue())
+			Expect(d).To(Equal(30 * time.Second))
+
+			d, ok = retryAfter("Tue, 01 Jun 2021 11:00:00 GMT", now)
+			Expect(ok).To(BeTrue())
+			Expect(d).To(Equal(time.Duration(0)))
+
+			_, ok = retryAfter("", now)
+			Expect(ok).To(BeFalse())
+
+			_, ok = retryAfter("soon", now)
+			Expect(ok).To(BeFalse())
+		})
+	})
+}
@@ -0,0 +1,164 @@
+package requests
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Transport is an http.RoundTripper that applies the client wide policies,
+// such as retries and rate limits, to every request made through it.  It is
+// installed on the HTTP client used by the SDK so the policies also apply to
+// each page fetched while paging.  Its fields should not be changed while
+// requests are in flight.
+type Transport struct {
+	// Base is the RoundTripper used to make the actual requests.  If nil,
+	// http.DefaultTransport is used.
+	Base http.RoundTripper
+	// Retry is the policy used to retry transient failures.  If nil, each
+	// request is attempted exactly once.
+	Retry *RetryPolicy
+	// Limiter throttles the rate at which requests are sent, including each
+	// retry attempt.  If nil, requests are not throttled.
+	Limiter Limiter
+	// InFlight caps the number of requests in flight.  If nil, there is no
+	// cap.
+	InFlight *InFlightLimit
+	// Logger receives a line for each retried request.  If nil, nothing is
+	// logged.
+	Logger Logger
+}
+
+// Logger represents anything that can log formatted lines, such as the
+// standard library's *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.Retry.retryable(req.Method) || !rewindable(req) {
+		return t.send(req)
+	}
+
+	ctx := req.Context()
+
+	for attempt := 1; ; attempt++ {
+		r := req
+		if attempt > 1 {
+			var err error
+			r, err = rewind(req)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.send(r)
+		if attempt >= t.Retry.MaxAttempts || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.Retry.backoff(attempt, resp)
+		t.logRetry(req, attempt, wait, resp, err)
+
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// send makes a single attempt at the request once the limits allow it.
+func (t *Transport) send(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.Limiter != nil {
+		err := t.Limiter.Wait(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if t.InFlight == nil {
+		return t.base().RoundTrip(req)
+	}
+
+	err := t.InFlight.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	if err != nil {
+		t.InFlight.Release()
+		return nil, err
+	}
+
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: t.InFlight.Release}
+	return resp, nil
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+
+	return http.DefaultTransport
+}
+
+func (t *Transport) logRetry(req *http.Request, attempt int, wait time.Duration, resp *http.Response, err error) {
+	if t.Logger == nil {
+		return
+	}
+
+	var reason string
+	if err != nil {
+		reason = err.Error()
+	} else {
+		reason = resp.Status
+	}
+
+	t.Logger.Printf("ionic: retrying %v %v in %v (attempt %v of %v): %v", req.Method, req.URL.Path, wait, attempt+1, t.Retry.MaxAttempts, reason)
+}
+
+func (t *Transport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	return t.Retry.retryableStatus(resp.StatusCode)
+}
+
+// rewindable returns whether the body of the request can be sent again.
+func rewindable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// rewind returns a copy of the request with a fresh body for another attempt.
+func rewind(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+
+		r.Body = body
+	}
+
+	return r, nil
+}
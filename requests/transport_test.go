@@ -0,0 +1,144 @@
+package requests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic/pagination"
+	. "github.com/onsi/gomega"
+)
+
+func TestTransport(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Transport", func() {
+		var hits int32
+		var failures int32
+		var bodies []string
+		var server *httptest.Server
+		var baseURL *url.URL
+		var client *http.Client
+		var transport *Transport
+
+		g.BeforeEach(func() {
+			hits = 0
+			failures = 0
+			bodies = nil
+
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				bodies = append(bodies, string(b))
+
+				if atomic.AddInt32(&hits, 1) <= atomic.LoadInt32(&failures) {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+
+				fmt.Fprint(w, `{"data":{"foo":"bar"}}`)
+			}))
+
+			baseURL, _ = url.Parse(server.URL)
+
+			policy := DefaultRetryPolicy()
+			policy.InitialBackoff = time.Millisecond
+			transport = &Transport{Retry: policy}
+			client = &http.Client{Transport: transport}
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+		})
+
+		g.It("should retry a transient failure", func() {
+			failures = 2
+
+			b, _, err := Get(client, baseURL, "some/endpoint", "", nil, nil, nil)
+			Expect(err).To(BeNil())
+			Expect(string(b)).To(Equal(`{"foo":"bar"}`))
+			Expect(hits).To(Equal(int32(3)))
+		})
+
+		g.It("should give up after the maximum attempts", func() {
+			failures = 5
+
+			_, _, err := Get(client, baseURL, "some/endpoint", "", nil, nil, nil)
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("(503)"))
+			Expect(hits).To(Equal(int32(3)))
+		})
+
+		g.It("should not retry a post by default", func() {
+			failures = 1
+
+			_, err := Post(client, baseURL, "some/endpoint", "", nil, *bytes.NewBufferString("payload"), nil)
+			Expect(err).NotTo(BeNil())
+			Expect(hits).To(Equal(int32(1)))
+		})
+
+		g.It("should retry a post with its body when opted in", func() {
+			failures = 1
+			transport.Retry.RetryNonIdempotent = true
+
+			_, err := Post(client, baseURL, "some/endpoint", "", nil, *bytes.NewBufferString("payload"), nil)
+			Expect(err).To(BeNil())
+			Expect(hits).To(Equal(int32(2)))
+			Expect(bodies).To(Equal([]string{"payload", "payload"}))
+		})
+
+		g.It("should not retry without a policy", func() {
+			failures = 1
+			transport.Retry = nil
+
+			_, _, err := Get(client, baseURL, "some/endpoint", "", nil, nil, nil)
+			Expect(err).NotTo(BeNil())
+			Expect(hits).To(Equal(int32(1)))
+		})
+
+		g.It("should throttle each page while paging", func() {
+			transport.Limiter = NewTokenBucket(1000, 1)
+			transport.InFlight = NewInFlightLimit(1)
+
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&hits, 1)
+				fmt.Fprint(w, `{"data":[{"foo":"bar"}],"meta":{"total_count":250}}`)
+			})
+
+			start := time.Now()
+			b, m, err := Get(client, baseURL, "some/endpoint", "", nil, nil, &pagination.Pagination{})
+			Expect(err).To(BeNil())
+			Expect(string(b)).To(Equal(`[{"foo":"bar"},{"foo":"bar"},{"foo":"bar"}]`))
+			Expect(m.TotalCount).To(Equal(250))
+			Expect(hits).To(Equal(int32(3)))
+			Expect(time.Since(start)).To(BeNumerically(">=", 2*time.Millisecond))
+		})
+
+		g.It("should stop waiting when the context is done", func() {
+			failures = 5
+			transport.Retry.InitialBackoff = time.Hour
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&hits, 1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			})
+
+			_, _, err := GetWithContext(ctx, client, baseURL, "some/endpoint", "", nil, nil, nil)
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("deadline exceeded"))
+			Expect(hits).To(Equal(int32(1)))
+		})
+	})
+}
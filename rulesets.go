@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 
+	"github.com/ion-channel/ionic/errors"
 	"github.com/ion-channel/ionic/pagination"
 	"github.com/ion-channel/ionic/requests"
 	"github.com/ion-channel/ionic/rulesets"
@@ -15,20 +16,20 @@ import (
 func (ic *IonClient) CreateRuleSet(opts rulesets.CreateRuleSetOptions, token string) (*rulesets.RuleSet, error) {
 	b, err := json.Marshal(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal project: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal project: %w", err)
 	}
 
 	buff := bytes.NewBuffer(b)
 
 	b, err = ic.Post(rulesets.CreateRuleSetEndpoint, token, nil, *buff, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create ruleset: %v", err.Error())
+		return nil, fmt.Errorf("failed to create ruleset: %w", err)
 	}
 
 	var p rulesets.RuleSet
 	err = json.Unmarshal(b, &p)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create ruleset: %v", err.Error())
+		return nil, fmt.Errorf("failed to create ruleset: %w", err)
 	}
 
 	return &p, nil
@@ -45,13 +46,13 @@ func (ic *IonClient) GetAppliedRuleSet(projectID, teamID, analysisID, token stri
 
 	b, _, err := ic.Get(rulesets.GetAppliedRuleSetEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get applied ruleset summary: %v", err.Error())
+		return nil, fmt.Errorf("failed to get applied ruleset summary: %w", err)
 	}
 
 	var s rulesets.AppliedRulesetSummary
 	err = json.Unmarshal(b, &s)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal applied ruleset summary: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal applied ruleset summary: %w", err)
 	}
 
 	return &s, nil
@@ -61,19 +62,19 @@ func (ic *IonClient) GetAppliedRuleSet(projectID, teamID, analysisID, token stri
 func (ic *IonClient) GetAppliedRuleSets(appliedRequestBatch []*rulesets.AppliedRulesetRequest, token string) (*[]rulesets.AppliedRulesetSummary, error) {
 	b, err := json.Marshal(appliedRequestBatch)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal project: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal project: %w", err)
 	}
 
 	buff := bytes.NewBuffer(b)
 	r, err := ic.Post(rulesets.GetBatchAppliedRulesetEndpoint, token, nil, *buff, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get applied ruleset summary: %v", err.Error())
+		return nil, fmt.Errorf("failed to get applied ruleset summary: %w", err)
 	}
 
 	var s []rulesets.AppliedRulesetSummary
 	err = json.Unmarshal(r, &s)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal applied ruleset summary: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal applied ruleset summary: %w", err)
 	}
 
 	return &s, nil
@@ -84,7 +85,7 @@ func (ic *IonClient) GetAppliedRuleSets(appliedRequestBatch []*rulesets.AppliedR
 func (ic *IonClient) GetAppliedRuleSetsBrief(appliedRequestBatch []*rulesets.AppliedRulesetRequest, token string) (*[]rulesets.AppliedRulesetSummary, error) {
 	b, err := json.Marshal(appliedRequestBatch)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal project: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal project: %w", err)
 	}
 
 	params := &url.Values{}
@@ -93,13 +94,13 @@ func (ic *IonClient) GetAppliedRuleSetsBrief(appliedRequestBatch []*rulesets.App
 	buff := bytes.NewBuffer(b)
 	r, err := ic.Post(rulesets.GetBatchAppliedRulesetEndpoint, token, params, *buff, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get applied ruleset summary: %v", err.Error())
+		return nil, fmt.Errorf("failed to get applied ruleset summary: %w", err)
 	}
 
 	var s []rulesets.AppliedRulesetSummary
 	err = json.Unmarshal(r, &s)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal applied ruleset summary: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal applied ruleset summary: %w", err)
 	}
 
 	return &s, nil
@@ -116,7 +117,7 @@ func (ic *IonClient) GetRawAppliedRuleSet(projectID, teamID, analysisID, token s
 
 	b, _, err := ic.Get(rulesets.GetAppliedRuleSetEndpoint, token, params, nil, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get applied rulesets: %v", err.Error())
+		return nil, fmt.Errorf("failed to get applied rulesets: %w", err)
 	}
 
 	return b, nil
@@ -130,13 +131,13 @@ func (ic *IonClient) GetRuleSet(ruleSetID, teamID, token string) (*rulesets.Rule
 
 	b, _, err := ic.Get(rulesets.GetRuleSetEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get ruleset: %v", err.Error())
+		return nil, fmt.Errorf("failed to get ruleset: %w", err)
 	}
 
 	var rs rulesets.RuleSet
 	err = json.Unmarshal(b, &rs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal ruleset: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal ruleset: %w", err)
 	}
 
 	return &rs, nil
@@ -149,13 +150,13 @@ func (ic *IonClient) GetRuleSets(teamID, token string, page *pagination.Paginati
 
 	b, _, err := ic.Get(rulesets.GetRuleSetsEndpoint, token, params, nil, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get rulesets: %v", err.Error())
+		return nil, fmt.Errorf("failed to get rulesets: %w", err)
 	}
 
 	var rs []rulesets.RuleSet
 	err = json.Unmarshal(b, &rs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal rulesets: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal rulesets: %w", err)
 	}
 
 	return rs, nil
@@ -164,7 +165,20 @@ func (ic *IonClient) GetRuleSets(teamID, token string, page *pagination.Paginati
 // RuleSetExists takes a ruleSetID, teamId and token string and checks against api to see if ruleset exists.
 // It returns whether or not ruleset exists and any errors it encounters with the API.
 func (ic *IonClient) RuleSetExists(ruleSetID, teamID, token string) (bool, error) {
-	return rulesets.RuleSetExists(ic.client, ic.baseURL, ruleSetID, teamID, token)
+	params := &url.Values{}
+	params.Set("id", ruleSetID)
+	params.Set("team_id", teamID)
+
+	err := ic.Head(rulesets.GetRuleSetEndpoint, token, params, nil, nil)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to request ruleset: %w", err)
+	}
+
+	return true, nil
 }
 
 //GetProjectPassFailHistory takes a project id and returns a daily history of pass/fail statuses
@@ -174,13 +188,13 @@ func (ic *IonClient) GetProjectPassFailHistory(projectID, token string) ([]rules
 
 	b, _, err := ic.Get(rulesets.GetProjectHistoryEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project history: %v", err.Error())
+		return nil, fmt.Errorf("failed to get project history: %w", err)
 	}
 
 	var ph []rulesets.ProjectPassFailHistory
 	err = json.Unmarshal(b, &ph)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal ruleset: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal ruleset: %w", err)
 	}
 
 	return ph, nil
@@ -194,19 +208,19 @@ func (ic *IonClient) GetRulesetNames(ids []string, token string) ([]rulesets.Nam
 
 	b, err := json.Marshal(byIDs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	buff := bytes.NewBuffer(b)
 	r, err := ic.Post(rulesets.RulesetsGetRulesetNames, token, nil, *buff, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get applied ruleset summary: %v", err.Error())
+		return nil, fmt.Errorf("failed to get applied ruleset summary: %w", err)
 	}
 
 	var s []rulesets.NameForID
 	err = json.Unmarshal(r, &s)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal applied ruleset summary: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal applied ruleset summary: %w", err)
 	}
 
 	return s, nil
@@ -222,18 +236,18 @@ func (ic *IonClient) GetAnalysesStatuses(teamID string, ids []string, token stri
 
 	b, err := json.Marshal(body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	r, err := ic.Post(rulesets.GetRulesetAnalysesStatuses, token, nil, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analyses statuses: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analyses statuses: %w", err)
 	}
 
 	var statuses []rulesets.Status
 	err = json.Unmarshal(r, &statuses)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return statuses, nil
@@ -1,14 +1,15 @@
 package rulesets
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 
+	"github.com/ion-channel/ionic/errors"
 	"github.com/ion-channel/ionic/requests"
 	"github.com/ion-channel/ionic/rules"
 )
@@ -91,18 +92,25 @@ func (r RuleSet) String() string {
 // It returns whether or not ruleset exists and any errors it encounters with the API.
 // This is used internally in the SDK
 func RuleSetExists(client *http.Client, baseURL *url.URL, ruleSetID, teamID, token string) (bool, error) {
+	return RuleSetExistsWithContext(context.Background(), client, baseURL, ruleSetID, teamID, token)
+}
+
+// RuleSetExistsWithContext performs the same check as RuleSetExists, bound to
+// the provided context.
+// This is used internally in the SDK
+func RuleSetExistsWithContext(ctx context.Context, client *http.Client, baseURL *url.URL, ruleSetID, teamID, token string) (bool, error) {
 	params := &url.Values{}
 	params.Set("id", ruleSetID)
 	params.Set("team_id", teamID)
 
-	err := requests.Head(client, baseURL, GetRuleSetEndpoint, token, params, nil, nil)
+	err := requests.HeadWithContext(ctx, client, baseURL, GetRuleSetEndpoint, token, params, nil, nil)
 
 	if err != nil {
-		if strings.Contains(err.Error(), "(404)") {
+		if errors.IsNotFound(err) {
 			return false, nil
 		}
 
-		return false, fmt.Errorf("failed to request ruleset: %v", err.Error())
+		return false, fmt.Errorf("failed to request ruleset: %w", err)
 	}
 
 	return true, nil
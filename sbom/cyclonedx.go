@@ -0,0 +1,229 @@
+package sbom
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ion-channel/ionic/analyses"
+	"github.com/ion-channel/ionic/cyclonedx"
+	"github.com/ion-channel/ionic/vulnerabilities"
+)
+
+// CycloneDXFromAnalysis builds a CycloneDX 1.4 BOM from an analysis.  The
+// project is the BOM's metadata component and its dependencies are library
+// components, referenced by their package URLs where they have them.  Licenses
+// are given on the project.  Vulnerabilities are listed with the components
+// they affect, along with any VEX analyses given in the options.
+func CycloneDXFromAnalysis(a *analyses.Analysis, opts Options) (*cyclonedx.BOM, error) {
+	inv, err := newInventory(a, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := bomRefs(inv)
+
+	bom := &cyclonedx.BOM{
+		BOMFormat:    cyclonedx.BOMFormat,
+		SpecVersion:  cyclonedx.SpecVersion,
+		SerialNumber: "urn:uuid:" + uuid.New().String(),
+		Version:      1,
+		Metadata: &cyclonedx.Metadata{
+			Timestamp: opts.created(),
+		},
+	}
+
+	for _, creator := range opts.creators() {
+		kind, name := splitCreator(creator)
+
+		switch kind {
+		case "Tool":
+			bom.Metadata.Tools = append(bom.Metadata.Tools, cyclonedx.Tool{Name: name})
+		case "Person":
+			bom.Metadata.Authors = append(bom.Metadata.Authors, cyclonedx.OrganizationalContact{Name: name})
+		case "Organization":
+			if bom.Metadata.Supplier == nil {
+				bom.Metadata.Supplier = &cyclonedx.OrganizationalEntity{Name: name}
+			}
+		}
+	}
+
+	root := cyclonedxComponent(inv.components[0], refs[0])
+	root.Type = "application"
+	root.Description = a.Description
+	if opts.Supplier != "" {
+		root.Supplier = &cyclonedx.OrganizationalEntity{Name: opts.Supplier}
+		bom.Metadata.Supplier = root.Supplier
+	}
+
+	if a.Source != "" {
+		switch strings.ToLower(a.Type) {
+		case "git":
+			location := a.Source
+			if a.Branch != "" {
+				location += "#" + a.Branch
+			}
+
+			root.ExternalReferences = append(root.ExternalReferences, cyclonedx.ExternalReference{Type: "vcs", URL: location})
+		case "artifact":
+			root.ExternalReferences = append(root.ExternalReferences, cyclonedx.ExternalReference{Type: "distribution", URL: a.Source})
+		}
+	}
+
+	for _, name := range inv.licenses {
+		l := &cyclonedx.License{ID: name}
+		if !licenseRegex.MatchString(name) {
+			l = &cyclonedx.License{Name: name}
+		}
+
+		root.Licenses = append(root.Licenses, cyclonedx.LicenseChoice{License: l})
+	}
+
+	bom.Metadata.Component = &root
+
+	for ii, c := range inv.components[1:] {
+		bom.Components = append(bom.Components, cyclonedxComponent(c, refs[ii+1]))
+	}
+
+	for ii, c := range inv.components {
+		dep := cyclonedx.Dependency{Ref: refs[ii]}
+		for _, on := range c.dependsOn {
+			dep.DependsOn = append(dep.DependsOn, refs[on])
+		}
+
+		bom.Dependencies = append(bom.Dependencies, dep)
+	}
+
+	bom.Vulnerabilities = cyclonedxVulnerabilities(inv, refs, opts)
+
+	return bom, nil
+}
+
+// bomRefs returns the BOM reference of each component, which is its package
+// URL if it has a unique one, or its identifier otherwise
+func bomRefs(inv *inventory) []string {
+	counts := make(map[string]int)
+	for _, c := range inv.components {
+		counts[c.purl]++
+	}
+
+	refs := make([]string, len(inv.components))
+	for ii, c := range inv.components {
+		refs[ii] = c.id
+		if c.purl != "" && counts[c.purl] == 1 {
+			refs[ii] = c.purl
+		}
+	}
+
+	return refs
+}
+
+func cyclonedxComponent(c *component, ref string) cyclonedx.Component {
+	return cyclonedx.Component{
+		Type:    "library",
+		BOMRef:  ref,
+		Group:   c.org,
+		Name:    c.name,
+		Version: c.version,
+		PURL:    c.purl,
+	}
+}
+
+// cyclonedxVulnerabilities lists the vulnerabilities of every component, with
+// the components each one affects
+func cyclonedxVulnerabilities(inv *inventory, refs []string, opts Options) []cyclonedx.Vulnerability {
+	var vulns []cyclonedx.Vulnerability
+	index := make(map[string]int)
+
+	for ii, c := range inv.components {
+		for _, v := range sortedVulnerabilities(c.vulns) {
+			jj, ok := index[v.ExternalID]
+			if !ok {
+				jj = len(vulns)
+				index[v.ExternalID] = jj
+				vulns = append(vulns, cyclonedxVulnerability(v.Vulnerability))
+
+				if analysis, ok := opts.VulnerabilityAnalyses[v.ExternalID]; ok {
+					analysis := analysis
+					vulns[jj].Analysis = &analysis
+				}
+			}
+
+			vulns[jj].Affects = append(vulns[jj].Affects, cyclonedx.Affect{Ref: refs[ii]})
+		}
+	}
+
+	return vulns
+}
+
+func cyclonedxVulnerability(v vulnerabilities.Vulnerability) cyclonedx.Vulnerability {
+	cv := cyclonedx.Vulnerability{
+		ID:             v.ExternalID,
+		Description:    v.Summary,
+		Recommendation: v.Recommendation,
+		Ratings:        ratings(v),
+	}
+
+	if cv.Description == "" {
+		cv.Description = v.Title
+	}
+
+	if len(v.Source) > 0 && v.Source[0].Name != "" {
+		cv.Source = &cyclonedx.VulnerabilitySource{Name: v.Source[0].Name}
+	}
+
+	for _, ref := range v.References {
+		if ref.URL != "" {
+			cv.Advisories = append(cv.Advisories, cyclonedx.Advisory{Title: ref.Text, URL: ref.URL})
+		}
+	}
+
+	if !v.PublishedAt.IsZero() {
+		cv.Published = v.PublishedAt.UTC().Format(time.RFC3339)
+	}
+
+	if !v.ModifiedAt.IsZero() {
+		cv.Updated = v.ModifiedAt.UTC().Format(time.RFC3339)
+	}
+
+	return cv
+}
+
+// ratings returns the CVSS ratings of a vulnerability, falling back to its
+// score when it has no score details
+func ratings(v vulnerabilities.Vulnerability) []cyclonedx.Rating {
+	var rs []cyclonedx.Rating
+
+	if s := v.ScoreDetails.CVSSv3; s != nil {
+		method := "CVSSv3"
+		if strings.HasPrefix(s.VectorString, "CVSS:3.1/") {
+			method = "CVSSv31"
+		}
+
+		rs = append(rs, cyclonedx.Rating{
+			Score:    s.BaseScore,
+			Severity: strings.ToLower(s.BaseSeverity),
+			Method:   method,
+			Vector:   s.VectorString,
+		})
+	}
+
+	if s := v.ScoreDetails.CVSSv2; s != nil {
+		rs = append(rs, cyclonedx.Rating{
+			Score:    s.BaseScore,
+			Severity: strings.ToLower(vulnerabilities.CVSSv2Severity(s.BaseScore)),
+			Method:   "CVSSv2",
+			Vector:   s.VectorString,
+		})
+	}
+
+	if len(rs) == 0 && v.Score != "" {
+		score, err := strconv.ParseFloat(v.Score, 64)
+		if err == nil {
+			rs = append(rs, cyclonedx.Rating{Score: score, Method: "other"})
+		}
+	}
+
+	return rs
+}
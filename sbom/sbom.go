@@ -0,0 +1,338 @@
+// Package sbom builds software bills of materials from Ion Channel analyses
+// locally, rather than asking the API to render them.  The project analyzed
+// and the dependencies, licenses, and vulnerabilities found by the analysis'
+// scans are written into SPDX 2.2 documents or CycloneDX 1.4 BOMs, so SBOMs
+// can be produced offline from cached analyses.
+package sbom
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ion-channel/ionic/analyses"
+	"github.com/ion-channel/ionic/cyclonedx"
+	"github.com/ion-channel/ionic/scans"
+)
+
+const (
+	// DefaultCreator is the creator of documents that do not name their own
+	DefaultCreator = "Tool: ionic"
+	// DefaultNamespace is the base URI of the namespaces given to SPDX
+	// documents that do not name their own
+	DefaultNamespace = "https://ionchannel.io/spdx"
+)
+
+var (
+	// purlTypes maps Ion Channel dependency types to package URL types
+	purlTypes = map[string]string{
+		"maven":    "maven",
+		"npm":      "npm",
+		"yarn":     "npm",
+		"gem":      "gem",
+		"ruby":     "gem",
+		"rubygems": "gem",
+		"pypi":     "pypi",
+		"python":   "pypi",
+		"pip":      "pypi",
+		"go":       "golang",
+		"golang":   "golang",
+		"nuget":    "nuget",
+		"dotnet":   "nuget",
+		"composer": "composer",
+		"php":      "composer",
+		"cargo":    "cargo",
+		"rust":     "cargo",
+		"cocoapod": "cocoapods",
+		"hex":      "hex",
+		"docker":   "docker",
+	}
+
+	idRegex      = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+	licenseRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.+-]*$`)
+)
+
+// Options are the settings for building an SBOM from an analysis
+type Options struct {
+	// Creators are the people, organizations, and tools credited with
+	// creating the SBOM, in the SPDX form of "Person: name",
+	// "Organization: name", or "Tool: name".  DefaultCreator is used if none
+	// are given.
+	Creators []string
+	// Supplier is the name of the organization supplying the analyzed
+	// project
+	Supplier string
+	// Namespace is the SPDX document namespace.  A namespace under
+	// DefaultNamespace is made from the project name and analysis ID if it is
+	// not given.
+	Namespace string
+	// Created is when the SBOM was created, which defaults to now
+	Created time.Time
+	// IncludeLicenses adds the licenses found by the analysis to the project
+	IncludeLicenses bool
+	// IncludeVulnerabilities adds the vulnerabilities found by the analysis
+	// to the packages or components they affect
+	IncludeVulnerabilities bool
+	// VulnerabilityAnalyses are VEX statements about whether vulnerabilities
+	// are exploitable in the project, keyed by the vulnerabilities' external
+	// IDs.  They are only written to CycloneDX BOMs.
+	VulnerabilityAnalyses map[string]cyclonedx.VulnerabilityAnalysis
+}
+
+// Results are the scan results of an analysis that an SBOM is built from
+type Results struct {
+	Dependencies    *scans.DependencyResults
+	Licenses        *scans.LicenseResults
+	Vulnerabilities *scans.VulnerabilityResults
+}
+
+// ResultsFromAnalysis collects the dependency, license, and vulnerability
+// results from the scan summaries of an analysis.  Results of scans that were
+// not run are nil.
+func ResultsFromAnalysis(a *analyses.Analysis) Results {
+	var r Results
+	if a == nil {
+		return r
+	}
+
+	for ii := range a.ScanSummaries {
+		tr := a.ScanSummaries[ii].TranslatedResults
+		if tr == nil && a.ScanSummaries[ii].UntranslatedResults != nil {
+			tr = a.ScanSummaries[ii].UntranslatedResults.Translate()
+		}
+
+		if tr == nil {
+			continue
+		}
+
+		switch data := tr.Data.(type) {
+		case scans.DependencyResults:
+			r.Dependencies = &data
+		case *scans.DependencyResults:
+			r.Dependencies = data
+		case scans.LicenseResults:
+			r.Licenses = &data
+		case *scans.LicenseResults:
+			r.Licenses = data
+		case scans.VulnerabilityResults:
+			r.Vulnerabilities = &data
+		case *scans.VulnerabilityResults:
+			r.Vulnerabilities = data
+		}
+	}
+
+	return r
+}
+
+// component is a format agnostic representation of a piece of software in an
+// SBOM: the analyzed project or one of its dependencies
+type component struct {
+	id        string
+	ecosystem string
+	org       string
+	name      string
+	version   string
+	purl      string
+	dependsOn []int
+	vulns     []scans.VulnerabilityResultsVulnerability
+}
+
+// inventory is the analyzed project and everything found in it.  The first
+// component is always the project.
+type inventory struct {
+	analysis   *analyses.Analysis
+	components []*component
+	licenses   []string
+	index      map[string]int
+}
+
+// newInventory builds the inventory of an analysis, walking its dependency
+// tree and attaching vulnerabilities to the components they were found in
+func newInventory(a *analyses.Analysis, opts Options) (*inventory, error) {
+	if a == nil {
+		return nil, fmt.Errorf("no analysis given")
+	}
+
+	inv := &inventory{
+		analysis: a,
+		index:    make(map[string]int),
+	}
+
+	inv.components = append(inv.components, &component{
+		id:      "Project-" + sanitize(a.Name),
+		name:    a.Name,
+		version: a.TriggerHash,
+	})
+
+	results := ResultsFromAnalysis(a)
+
+	if results.Dependencies != nil {
+		for ii := range results.Dependencies.Dependencies {
+			child := inv.addDependency(results.Dependencies.Dependencies[ii])
+			inv.components[0].dependsOn = appendIndex(inv.components[0].dependsOn, child)
+		}
+	}
+
+	if opts.IncludeLicenses && results.Licenses != nil && results.Licenses.License != nil {
+		for _, t := range results.Licenses.Type {
+			if t.Name != "" && !contains(inv.licenses, t.Name) {
+				inv.licenses = append(inv.licenses, t.Name)
+			}
+		}
+	}
+
+	if opts.IncludeVulnerabilities && results.Vulnerabilities != nil {
+		for _, product := range results.Vulnerabilities.Vulnerabilities {
+			if len(product.Vulnerabilities) == 0 {
+				continue
+			}
+
+			c := inv.components[inv.findProduct(product)]
+			c.vulns = append(c.vulns, product.Vulnerabilities...)
+		}
+	}
+
+	return inv, nil
+}
+
+// addDependency adds a dependency and its dependencies to the inventory,
+// returning the dependency's index.  Dependencies seen before are not added
+// again.
+func (inv *inventory) addDependency(d scans.Dependency) int {
+	key := strings.ToLower(strings.Join([]string{d.Type, d.Org, d.Name, d.Version}, "|"))
+
+	ii, ok := inv.index[key]
+	if !ok {
+		ii = len(inv.components)
+		inv.index[key] = ii
+		inv.components = append(inv.components, &component{
+			id:        fmt.Sprintf("Package-%v-%v", ii, sanitize(d.Name)),
+			ecosystem: d.Type,
+			org:       d.Org,
+			name:      d.Name,
+			version:   d.Version,
+			purl:      purl(d.Type, d.Org, d.Name, d.Version),
+		})
+	}
+
+	for jj := range d.Dependencies {
+		child := inv.addDependency(d.Dependencies[jj])
+		inv.components[ii].dependsOn = appendIndex(inv.components[ii].dependsOn, child)
+	}
+
+	return ii
+}
+
+// findProduct returns the index of the component a vulnerable product was
+// found in, matching on its name, org, and version.  Products that match no
+// dependency are added as components of their own, unless they are the
+// project itself.
+func (inv *inventory) findProduct(p scans.VulnerabilityResultsProduct) int {
+	org, name, version := p.Org, p.Name, p.Version
+	if p.Query.Name != "" {
+		org, name, version = p.Query.Org, p.Query.Name, p.Query.Version
+	}
+
+	for ii, c := range inv.components[1:] {
+		if strings.EqualFold(c.name, name) && c.version == version && (org == "" || strings.EqualFold(c.org, org)) {
+			return ii + 1
+		}
+	}
+
+	if strings.EqualFold(inv.components[0].name, name) {
+		return 0
+	}
+
+	return inv.addDependency(scans.Dependency{Type: p.Query.Type, Org: org, Name: name, Version: version})
+}
+
+// created returns the creation time of the SBOM in RFC 3339 form
+func (o Options) created() string {
+	created := o.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
+
+	return created.UTC().Format(time.RFC3339)
+}
+
+// creators returns the creators of the SBOM
+func (o Options) creators() []string {
+	if len(o.Creators) == 0 {
+		return []string{DefaultCreator}
+	}
+
+	return o.Creators
+}
+
+// splitCreator splits a creator into its kind, such as Tool, and its name
+func splitCreator(creator string) (string, string) {
+	parts := strings.SplitN(creator, ":", 2)
+	if len(parts) != 2 {
+		return "", strings.TrimSpace(creator)
+	}
+
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+}
+
+// purl returns the package URL of a dependency, or an empty string if the
+// dependency's type has no package URL type
+func purl(ecosystem, org, name, version string) string {
+	t, ok := purlTypes[strings.ToLower(ecosystem)]
+	if !ok || name == "" {
+		return ""
+	}
+
+	b := strings.Builder{}
+	b.WriteString("pkg:" + t + "/")
+
+	if org != "" {
+		for _, segment := range strings.Split(org, "/") {
+			b.WriteString(escape(segment) + "/")
+		}
+	}
+
+	b.WriteString(escape(name))
+
+	if version != "" {
+		b.WriteString("@" + escape(version))
+	}
+
+	return b.String()
+}
+
+func escape(s string) string {
+	return strings.Replace(url.PathEscape(s), "@", "%40", -1)
+}
+
+// sanitize replaces the characters not allowed in SPDX identifiers
+func sanitize(s string) string {
+	s = strings.Trim(idRegex.ReplaceAllString(s, "-"), "-")
+	if s == "" {
+		return "unnamed"
+	}
+
+	return s
+}
+
+func contains(ss []string, s string) bool {
+	for _, e := range ss {
+		if e == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func appendIndex(is []int, i int) []int {
+	for _, e := range is {
+		if e == i {
+			return is
+		}
+	}
+
+	return append(is, i)
+}
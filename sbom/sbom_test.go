@@ -0,0 +1,233 @@
+package sbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic/analyses"
+	"github.com/ion-channel/ionic/cyclonedx"
+	"github.com/ion-channel/ionic/scans"
+	ionspdx "github.com/ion-channel/ionic/spdx"
+	. "github.com/onsi/gomega"
+	"github.com/spdx/tools-golang/spdx"
+)
+
+const (
+	sampleDependencyResults = `{"type": "dependency", "data": {"dependencies": [
+		{"name": "commons-lang3", "org": "org.apache.commons", "version": "3.12.0", "type": "maven", "dependencies": [
+			{"name": "junit", "org": "junit", "version": "4.13", "type": "maven", "dependencies": []}
+		]},
+		{"name": "junit", "org": "junit", "version": "4.13", "type": "maven", "dependencies": []}
+	], "meta": {"first_degree_count": 2}}}`
+
+	sampleLicenseResults = `{"type": "license", "data": {"license": {"name": "LICENSE", "type": [
+		{"name": "MIT", "confidence": 1},
+		{"name": "Some Custom License", "confidence": 0.8}
+	]}}}`
+
+	sampleVulnerabilityResults = `{"type": "vulnerability", "data": {"vulnerabilities": [{
+		"name": "junit", "org": "junit", "version": "4.13",
+		"query": {"name": "junit", "org": "junit", "version": "4.13", "type": "maven"},
+		"vulnerabilities": [{
+			"external_id": "CVE-2020-15250",
+			"title": "TemporaryFolder is shared",
+			"summary": "Information disclosure in TemporaryFolder",
+			"score": "5.5",
+			"source": [{"name": "NVD"}],
+			"score_details": {"cvssv3": {"vectorString": "CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:N/A:N", "baseScore": 5.5, "baseSeverity": "MEDIUM"}},
+			"references": [{"url": "https://nvd.nist.gov/vuln/detail/CVE-2020-15250", "text": "NVD"}],
+			"published_at": "2020-10-12T18:15:00Z"
+		}]
+	}], "meta": {"vulnerability_count": 1}}}`
+)
+
+func sampleAnalysis() *analyses.Analysis {
+	a := &analyses.Analysis{
+		Summary: analyses.Summary{
+			ID:          "analysis-id",
+			Name:        "some-project",
+			Type:        "git",
+			Source:      "https://github.com/some-org/some-project.git",
+			Branch:      "main",
+			TriggerHash: "0123456789abcdef0123456789abcdef01234567",
+		},
+	}
+
+	for _, results := range []string{sampleDependencyResults, sampleLicenseResults, sampleVulnerabilityResults} {
+		s, err := scans.NewScan("", "", "", a.ID, "", "", "", json.RawMessage(results), time.Time{}, time.Time{}, 0)
+		if err != nil {
+			panic(err)
+		}
+
+		a.ScanSummaries = append(a.ScanSummaries, *s)
+	}
+
+	return a
+}
+
+func TestSBOM(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	created := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	g.Describe("Results", func() {
+		g.It("should collect the results of an analysis", func() {
+			r := ResultsFromAnalysis(sampleAnalysis())
+			Expect(r.Dependencies.Dependencies).To(HaveLen(2))
+			Expect(r.Licenses.Type).To(HaveLen(2))
+			Expect(r.Vulnerabilities.Vulnerabilities).To(HaveLen(1))
+
+			Expect(ResultsFromAnalysis(nil).Dependencies).To(BeNil())
+		})
+
+		g.It("should build package URLs", func() {
+			Expect(purl("maven", "org.apache.commons", "commons-lang3", "3.12.0")).To(Equal("pkg:maven/org.apache.commons/commons-lang3@3.12.0"))
+			Expect(purl("npm", "@angular", "core", "12.0.0")).To(Equal("pkg:npm/%40angular/core@12.0.0"))
+			Expect(purl("golang", "github.com/some-org", "some-pkg", "")).To(Equal("pkg:golang/github.com/some-org/some-pkg"))
+			Expect(purl("unknown", "", "something", "1.0")).To(Equal(""))
+		})
+	})
+
+	g.Describe("SPDX", func() {
+		g.It("should build a document from an analysis", func() {
+			doc, err := SPDXFromAnalysis(sampleAnalysis(), Options{
+				Creators:               []string{"Organization: Some Org", "Tool: ionic-test"},
+				Supplier:               "Some Org",
+				Created:                created,
+				IncludeLicenses:        true,
+				IncludeVulnerabilities: true,
+			})
+			Expect(err).To(BeNil())
+
+			Expect(doc.CreationInfo.SPDXVersion).To(Equal("SPDX-2.2"))
+			Expect(doc.CreationInfo.Created).To(Equal("2021-06-01T12:00:00Z"))
+			Expect(doc.CreationInfo.CreatorOrganizations).To(Equal([]string{"Some Org"}))
+			Expect(doc.CreationInfo.CreatorTools).To(Equal([]string{"ionic-test"}))
+			Expect(doc.CreationInfo.DocumentNamespace).To(Equal("https://ionchannel.io/spdx/some-project-analysis-id"))
+			Expect(doc.Packages).To(HaveLen(3))
+
+			root := doc.Packages["Project-some-project"]
+			Expect(root.PackageDownloadLocation).To(Equal("git+https://github.com/some-org/some-project.git@main"))
+			Expect(root.PackageSupplierOrganization).To(Equal("Some Org"))
+			Expect(root.PackageLicenseDeclared).To(Equal("(MIT AND LicenseRef-Some-Custom-License)"))
+			Expect(doc.OtherLicenses).To(HaveLen(1))
+			Expect(doc.OtherLicenses[0].LicenseName).To(Equal("Some Custom License"))
+
+			junit := doc.Packages["Package-2-junit"]
+			Expect(junit.PackageExternalReferences).To(HaveLen(2))
+			Expect(junit.PackageExternalReferences[0].Locator).To(Equal("pkg:maven/junit/junit@4.13"))
+			Expect(junit.PackageExternalReferences[1].Locator).To(Equal("CVE-2020-15250"))
+
+			// DESCRIBES, root on both dependencies, and commons-lang3 on junit
+			Expect(doc.Relationships).To(HaveLen(4))
+			Expect(doc.Relationships[0].Relationship).To(Equal("DESCRIBES"))
+		})
+
+		g.It("should reject creators of unknown kinds", func() {
+			_, err := SPDXFromAnalysis(sampleAnalysis(), Options{Creators: []string{"Robot: R2"}})
+			Expect(err).NotTo(BeNil())
+
+			_, err = SPDXFromAnalysis(nil, Options{})
+			Expect(err).NotTo(BeNil())
+		})
+
+		g.It("should read back as the analyzed project", func() {
+			doc, err := SPDXFromAnalysis(sampleAnalysis(), Options{Created: created})
+			Expect(err).To(BeNil())
+			Expect(doc.Packages["Project-some-project"].PackageLicenseDeclared).To(Equal("NOASSERTION"))
+
+			var b bytes.Buffer
+			Expect(ionspdx.WriteJSON(&b, doc)).To(BeNil())
+
+			parsed, err := ionspdx.Parse(&b, ionspdx.FormatJSON)
+			Expect(err).To(BeNil())
+			Expect(parsed.(*spdx.Document2_2).Packages).To(HaveLen(3))
+
+			projs, err := ionspdx.ProjectsFromSPDX(parsed, false)
+			Expect(err).To(BeNil())
+			Expect(projs).To(HaveLen(1))
+			Expect(*projs[0].Name).To(Equal("some-project"))
+			Expect(*projs[0].Branch).To(Equal("main"))
+		})
+	})
+
+	g.Describe("CycloneDX", func() {
+		g.It("should build a BOM from an analysis", func() {
+			bom, err := CycloneDXFromAnalysis(sampleAnalysis(), Options{
+				Creators:               []string{"Person: Some Person", "Tool: ionic"},
+				Created:                created,
+				IncludeLicenses:        true,
+				IncludeVulnerabilities: true,
+				VulnerabilityAnalyses: map[string]cyclonedx.VulnerabilityAnalysis{
+					"CVE-2020-15250": {State: "not_affected", Justification: "code_not_reachable"},
+				},
+			})
+			Expect(err).To(BeNil())
+
+			Expect(bom.SpecVersion).To(Equal("1.4"))
+			Expect(bom.Metadata.Timestamp).To(Equal("2021-06-01T12:00:00Z"))
+			Expect(bom.Metadata.Tools).To(Equal([]cyclonedx.Tool{{Name: "ionic"}}))
+			Expect(bom.Metadata.Authors).To(Equal([]cyclonedx.OrganizationalContact{{Name: "Some Person"}}))
+
+			root := bom.Metadata.Component
+			Expect(root.Type).To(Equal("application"))
+			Expect(root.ExternalReferences[0].URL).To(Equal("https://github.com/some-org/some-project.git#main"))
+			Expect(root.Licenses).To(Equal(cyclonedx.Licenses{
+				{License: &cyclonedx.License{ID: "MIT"}},
+				{License: &cyclonedx.License{Name: "Some Custom License"}},
+			}))
+
+			Expect(bom.Components).To(HaveLen(2))
+			Expect(bom.Components[1].BOMRef).To(Equal("pkg:maven/junit/junit@4.13"))
+			Expect(bom.Dependencies).To(HaveLen(3))
+			Expect(bom.Dependencies[0].DependsOn).To(HaveLen(2))
+
+			Expect(bom.Vulnerabilities).To(HaveLen(1))
+			v := bom.Vulnerabilities[0]
+			Expect(v.ID).To(Equal("CVE-2020-15250"))
+			Expect(v.Ratings).To(Equal([]cyclonedx.Rating{{
+				Score:    5.5,
+				Severity: "medium",
+				Method:   "CVSSv31",
+				Vector:   "CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:N/A:N",
+			}}))
+			Expect(v.Analysis.State).To(Equal("not_affected"))
+			Expect(v.Affects).To(Equal([]cyclonedx.Affect{{Ref: "pkg:maven/junit/junit@4.13"}}))
+			Expect(v.Published).To(Equal("2020-10-12T18:15:00Z"))
+		})
+
+		g.It("should leave out licenses and vulnerabilities unless asked", func() {
+			bom, err := CycloneDXFromAnalysis(sampleAnalysis(), Options{})
+			Expect(err).To(BeNil())
+			Expect(bom.Metadata.Component.Licenses).To(BeEmpty())
+			Expect(bom.Vulnerabilities).To(BeEmpty())
+		})
+
+		g.It("should read back in both encodings", func() {
+			bom, err := CycloneDXFromAnalysis(sampleAnalysis(), Options{IncludeLicenses: true, IncludeVulnerabilities: true})
+			Expect(err).To(BeNil())
+
+			var j, x bytes.Buffer
+			Expect(cyclonedx.WriteJSON(&j, bom)).To(BeNil())
+			Expect(cyclonedx.WriteXML(&x, bom)).To(BeNil())
+
+			for _, b := range []*bytes.Buffer{&j, &x} {
+				parsed, err := cyclonedx.Parse(b)
+				Expect(err).To(BeNil())
+				Expect(parsed.Components).To(HaveLen(2))
+				Expect(parsed.Metadata.Component.Licenses).To(HaveLen(2))
+				Expect(parsed.Vulnerabilities[0].Affects).To(HaveLen(1))
+				Expect(parsed.Dependencies[1].DependsOn).To(HaveLen(1))
+
+				projs, err := cyclonedx.ProjectsFromCycloneDX(parsed, false)
+				Expect(err).To(BeNil())
+				Expect(*projs[0].Source).To(Equal("https://github.com/some-org/some-project.git"))
+				Expect(*projs[0].Branch).To(Equal("main"))
+			}
+		})
+	})
+}
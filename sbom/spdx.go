@@ -0,0 +1,217 @@
+package sbom
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ion-channel/ionic/analyses"
+	"github.com/ion-channel/ionic/scans"
+	"github.com/spdx/tools-golang/spdx"
+)
+
+const (
+	noAssertion = "NOASSERTION"
+
+	// vulnerabilityRefType is the external reference type used for the
+	// vulnerabilities of a package, as SPDX 2.2 has no security reference
+	// type for them
+	vulnerabilityRefType = "vulnerability"
+)
+
+// SPDXFromAnalysis builds an SPDX 2.2 document from an analysis.  The project
+// is the package the document describes and depends on its dependencies, which
+// are packages identified by their package URLs.  Licenses are declared on the
+// project, and licenses not on the SPDX license list are given as extracted
+// licenses.  Vulnerabilities are added to the packages they affect as OTHER
+// external references, since SPDX 2.2 has no vocabulary for them.
+func SPDXFromAnalysis(a *analyses.Analysis, opts Options) (*spdx.Document2_2, error) {
+	inv, err := newInventory(a, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	persons, organizations, tools := []string{}, []string{}, []string{}
+	for _, creator := range opts.creators() {
+		kind, name := splitCreator(creator)
+
+		switch kind {
+		case "Person":
+			persons = append(persons, name)
+		case "Organization":
+			organizations = append(organizations, name)
+		case "Tool":
+			tools = append(tools, name)
+		default:
+			return nil, fmt.Errorf("creator %q must be a Person, Organization, or Tool", creator)
+		}
+	}
+
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = fmt.Sprintf("%v/%v-%v", DefaultNamespace, sanitize(a.Name), sanitize(a.ID))
+	}
+
+	doc := &spdx.Document2_2{
+		CreationInfo: &spdx.CreationInfo2_2{
+			SPDXVersion:          "SPDX-2.2",
+			DataLicense:          "CC0-1.0",
+			SPDXIdentifier:       "DOCUMENT",
+			DocumentName:         a.Name,
+			DocumentNamespace:    namespace,
+			CreatorPersons:       persons,
+			CreatorOrganizations: organizations,
+			CreatorTools:         tools,
+			Created:              opts.created(),
+		},
+		Packages:        make(map[spdx.ElementID]*spdx.Package2_2),
+		UnpackagedFiles: make(map[spdx.ElementID]*spdx.File2_2),
+	}
+
+	for _, c := range inv.components {
+		doc.Packages[spdx.ElementID(c.id)] = spdxPackage(c)
+	}
+
+	root := doc.Packages[spdx.ElementID(inv.components[0].id)]
+	root.PackageDownloadLocation = downloadLocation(a)
+	root.PackageDescription = a.Description
+	if opts.Supplier != "" {
+		root.PackageSupplierOrganization = opts.Supplier
+	}
+
+	if len(inv.licenses) > 0 {
+		ids := []string{}
+		for _, name := range inv.licenses {
+			id := licenseID(name)
+			ids = append(ids, id)
+
+			if strings.HasPrefix(id, "LicenseRef-") {
+				doc.OtherLicenses = append(doc.OtherLicenses, &spdx.OtherLicense2_2{
+					LicenseIdentifier: id,
+					ExtractedText:     name,
+					LicenseName:       name,
+				})
+			}
+		}
+
+		root.PackageLicenseDeclared = strings.Join(ids, " AND ")
+		if len(ids) > 1 {
+			root.PackageLicenseDeclared = "(" + root.PackageLicenseDeclared + ")"
+		}
+	}
+
+	doc.Relationships = append(doc.Relationships, &spdx.Relationship2_2{
+		RefA:         spdx.MakeDocElementID("", "DOCUMENT"),
+		RefB:         spdx.MakeDocElementID("", inv.components[0].id),
+		Relationship: "DESCRIBES",
+	})
+
+	for _, c := range inv.components {
+		for _, dep := range c.dependsOn {
+			doc.Relationships = append(doc.Relationships, &spdx.Relationship2_2{
+				RefA:         spdx.MakeDocElementID("", c.id),
+				RefB:         spdx.MakeDocElementID("", inv.components[dep].id),
+				Relationship: "DEPENDS_ON",
+			})
+		}
+	}
+
+	return doc, nil
+}
+
+// spdxPackage converts a component into a package without file information
+func spdxPackage(c *component) *spdx.Package2_2 {
+	pkg := &spdx.Package2_2{
+		PackageName:                 c.name,
+		PackageSPDXIdentifier:       spdx.ElementID(c.id),
+		PackageVersion:              c.version,
+		PackageSupplierOrganization: c.org,
+		PackageDownloadLocation:     noAssertion,
+		FilesAnalyzed:               false,
+		IsFilesAnalyzedTagPresent:   true,
+		PackageLicenseConcluded:     noAssertion,
+		PackageLicenseDeclared:      noAssertion,
+		PackageCopyrightText:        noAssertion,
+		PackageChecksums:            make(map[spdx.ChecksumAlgorithm]spdx.Checksum),
+		Files:                       make(map[spdx.ElementID]*spdx.File2_2),
+	}
+
+	if c.purl != "" {
+		pkg.PackageExternalReferences = append(pkg.PackageExternalReferences, &spdx.PackageExternalReference2_2{
+			Category: "PACKAGE-MANAGER",
+			RefType:  "purl",
+			Locator:  c.purl,
+		})
+	}
+
+	for _, v := range sortedVulnerabilities(c.vulns) {
+		comment := v.Title
+		if v.Score != "" {
+			comment = fmt.Sprintf("%v (score %v)", v.Title, v.Score)
+		}
+
+		pkg.PackageExternalReferences = append(pkg.PackageExternalReferences, &spdx.PackageExternalReference2_2{
+			Category:           "OTHER",
+			RefType:            vulnerabilityRefType,
+			Locator:            v.ExternalID,
+			ExternalRefComment: comment,
+		})
+	}
+
+	return pkg
+}
+
+// downloadLocation returns where the analyzed project can be downloaded from,
+// as a VCS location for git projects
+func downloadLocation(a *analyses.Analysis) string {
+	if a.Source == "" {
+		return noAssertion
+	}
+
+	switch strings.ToLower(a.Type) {
+	case "git":
+		location := a.Source
+		if !strings.HasPrefix(location, "git+") {
+			location = "git+" + location
+		}
+
+		if a.Branch != "" {
+			location += "@" + a.Branch
+		}
+
+		return location
+	case "artifact":
+		return a.Source
+	default:
+		return noAssertion
+	}
+}
+
+// licenseID returns the SPDX identifier of a license name, or a LicenseRef-
+// identifier for names that cannot be one
+func licenseID(name string) string {
+	if licenseRegex.MatchString(name) {
+		return name
+	}
+
+	return "LicenseRef-" + sanitize(name)
+}
+
+// sortedVulnerabilities returns the distinct vulnerabilities in order of their
+// external IDs
+func sortedVulnerabilities(vulns []scans.VulnerabilityResultsVulnerability) []scans.VulnerabilityResultsVulnerability {
+	seen := make(map[string]bool)
+	distinct := []scans.VulnerabilityResultsVulnerability{}
+	for _, v := range vulns {
+		if v.ExternalID == "" || seen[v.ExternalID] {
+			continue
+		}
+
+		seen[v.ExternalID] = true
+		distinct = append(distinct, v)
+	}
+
+	sort.Slice(distinct, func(i, j int) bool { return distinct[i].ExternalID < distinct[j].ExternalID })
+
+	return distinct
+}
@@ -32,19 +32,19 @@ func (ic *IonClient) AnalyzeProject(projectID, teamID, branch, token string) (*s
 
 	b, err := json.Marshal(request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body to JSON: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body to JSON: %w", err)
 	}
 
 	buff := bytes.NewBuffer(b)
 	b, err = ic.Post(scanner.ScannerAnalyzeProjectEndpoint, token, nil, *buff, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to start analysis: %w", err)
 	}
 
 	var a scanner.AnalysisStatus
 	err = json.Unmarshal(b, &a)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis status: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis status: %w", err)
 	}
 
 	return &a, nil
@@ -61,13 +61,13 @@ func (ic *IonClient) AnalyzeProjects(teamID, token string, params *url.Values) (
 
 	b, err := json.Marshal(request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body to JSON: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body to JSON: %w", err)
 	}
 
 	buff := bytes.NewBuffer(b)
 	b, err = ic.Post(scanner.ScannerAnalyzeProjectEndpoint, token, params, *buff, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to start analysis: %w", err)
 	}
 
 	// We got a "team recently analyzed" message back
@@ -78,7 +78,7 @@ func (ic *IonClient) AnalyzeProjects(teamID, token string, params *url.Values) (
 	var ids []string
 	err = json.Unmarshal(b, &ids)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis status: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis status: %w", err)
 	}
 
 	return ids, nil
@@ -93,13 +93,13 @@ func (ic *IonClient) GetAnalysisStatus(analysisID, teamID, projectID, token stri
 
 	b, _, err := ic.Get(scanner.ScannerGetAnalysisStatusEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis status: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis status: %w", err)
 	}
 
 	var a scanner.AnalysisStatus
 	err = json.Unmarshal(b, &a)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis status: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis status: %w", err)
 	}
 
 	return &a, nil
@@ -113,13 +113,13 @@ func (ic *IonClient) GetLatestAnalysisStatus(teamID, projectID, token string) (*
 
 	b, _, err := ic.Get(scanner.ScannerGetLatestAnalysisStatusEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis: %w", err)
 	}
 
 	var a scanner.AnalysisStatus
 	err = json.Unmarshal(b, &a)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis: %w", err)
 	}
 
 	return &a, nil
@@ -132,13 +132,13 @@ func (ic *IonClient) GetLatestAnalysisStatuses(teamID, token string) ([]scanner.
 
 	b, _, err := ic.Get(scanner.ScannerGetLatestAnalysisStatusesEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis: %w", err)
 	}
 
 	var a []scanner.AnalysisStatus
 	err = json.Unmarshal(b, &a)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis: %w", err)
 	}
 
 	return a, nil
@@ -157,19 +157,19 @@ func (ic *IonClient) AddScanResult(scanResultID, teamID, projectID, status, scan
 
 	b, err := json.Marshal(request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body to JSON: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body to JSON: %w", err)
 	}
 
 	buff := bytes.NewBuffer(b)
 	b, err = ic.Post(scanner.ScannerAddScanEndpoint, token, nil, *buff, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start analysis: %v", err.Error())
+		return nil, fmt.Errorf("failed to start analysis: %w", err)
 	}
 
 	var a scanner.AnalysisStatus
 	err = json.Unmarshal(b, &a)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get analysis status: %v", err.Error())
+		return nil, fmt.Errorf("failed to get analysis status: %w", err)
 	}
 
 	return &a, nil
@@ -190,18 +190,18 @@ func (ic *IonClient) GetProjectsStates(ids []string, filter string, token string
 
 	b, err := json.Marshal(ri)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	r, err := ic.Post(scanner.ScannerGetProjectsStates, token, nil, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project states: %v", err.Error())
+		return nil, fmt.Errorf("failed to get project states: %w", err)
 	}
 
 	var ps []scanner.ProjectsStates
 	err = json.Unmarshal(r, &ps)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return ps, nil
@@ -211,7 +211,7 @@ func (ic *IonClient) GetProjectsStates(ids []string, filter string, token string
 func (ic *IonClient) FindScans(parameters scans.SearchParameters, teamID, token string) ([]scans.Scan, error) {
 	b, err := json.Marshal(parameters)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	params := &url.Values{}
@@ -219,13 +219,13 @@ func (ic *IonClient) FindScans(parameters scans.SearchParameters, teamID, token
 
 	r, err := ic.Post(scans.ScanFindScansEndpoint, token, params, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find scans: %v", err.Error())
+		return nil, fmt.Errorf("failed to find scans: %w", err)
 	}
 
 	var scansResult []scans.Scan
 	err = json.Unmarshal(r, &scansResult)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return scansResult, nil
@@ -508,3 +508,18 @@ type VulnerabilityResultsVulnerability struct {
 	vulnerabilities.Vulnerability
 	Dependencies []VulnerabilityResultsProduct `json:"dependencies" xml:"dependencies"`
 }
+
+// SeverityCounts returns the number of vulnerabilities of each severity found
+// across the products of the results.  A vulnerability is counted once for
+// each product it is found in, with its normalized severity.
+func (r VulnerabilityResults) SeverityCounts() map[vulnerabilities.Severity]int {
+	counts := make(map[vulnerabilities.Severity]int)
+
+	for _, p := range r.Vulnerabilities {
+		for ii := range p.Vulnerabilities {
+			counts[p.Vulnerabilities[ii].Severity()]++
+		}
+	}
+
+	return counts
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/franela/goblin"
 	"github.com/ion-channel/ionic/dependencies"
 	"github.com/ion-channel/ionic/secrets"
+	"github.com/ion-channel/ionic/vulnerabilities"
 	. "github.com/onsi/gomega"
 )
 
@@ -333,6 +334,11 @@ func TestScanResults(t *testing.T) {
 			Expect(ok).To(Equal(true))
 			Expect(v.Meta.VulnerabilityCount).To(Equal(1))
 			Expect(v.Vulnerabilities[0].Query.Name).To(Equal("broken"))
+
+			vuln := v.Vulnerabilities[0].Vulnerabilities[0]
+			Expect(vuln.EffectiveScore()).To(Equal(7.5))
+			Expect(vuln.Severity()).To(Equal(vulnerabilities.SeverityHigh))
+			Expect(v.SeverityCounts()).To(Equal(map[vulnerabilities.Severity]int{vulnerabilities.SeverityHigh: 1}))
 		})
 
 		g.It("should return an error for an invalid results type", func() {
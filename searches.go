@@ -49,13 +49,13 @@ func (ic *IonClient) GetSearch(query, tbs, token string) ([]SearchMatch, *respon
 
 	b, m, err := ic.Get(searchEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get productidentifiers search: %v", err.Error())
+		return nil, nil, fmt.Errorf("failed to get productidentifiers search: %w", err)
 	}
 
 	var results []SearchMatch
 	err = json.Unmarshal(b, &results)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to unmarshal product search results: %v", err.Error())
+		return nil, nil, fmt.Errorf("failed to unmarshal product search results: %w", err)
 	}
 	return results, m, nil
 
@@ -12,13 +12,13 @@ import (
 func (ic *IonClient) GetSecrets(text string, token string) ([]secrets.Secret, error) {
 	b, err := ic.Post(secrets.SecretsGetSecrets, token, nil, *bytes.NewBuffer([]byte(text)), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get secrets: %v", err.Error())
+		return nil, fmt.Errorf("failed to get secrets: %w", err)
 	}
 
 	var s []secrets.Secret
 	err = json.Unmarshal(b, &s)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response from get secrets: %v", err.Error())
+		return nil, fmt.Errorf("failed to read response from get secrets: %w", err)
 	}
 
 	return s, nil
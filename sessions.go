@@ -6,12 +6,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/ion-channel/ionic/users"
 )
 
 const (
-	sessionsLoginEndpoint = "v1/sessions/login"
+	sessionsLoginEndpoint   = "v1/sessions/login"
+	sessionsRefreshEndpoint = "v1/sessions/refresh"
+	sessionsLogoutEndpoint  = "v1/sessions/logout"
 )
 
 // Session represents the BearerToken and User for the current session
@@ -20,6 +24,66 @@ type Session struct {
 	User        users.User `json:"user"`
 }
 
+// SessionClaims represents the registered claims carried by a session's
+// bearer token
+type SessionClaims struct {
+	Subject   string `json:"sub,omitempty"`
+	Issuer    string `json:"iss,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	NotBefore int64  `json:"nbf,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+// Claims decodes the claims from the session's bearer token.  The token's
+// signature is not verified, the claims are only used to inspect the session.
+// It returns an error if the token is not a JWT.
+func (s *Session) Claims() (*SessionClaims, error) {
+	parts := strings.Split(s.BearerToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("session: bearer token is not a jwt")
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("session: failed to decode claims: %w", err)
+	}
+
+	var claims SessionClaims
+	err = json.Unmarshal(b, &claims)
+	if err != nil {
+		return nil, fmt.Errorf("session: failed to unmarshal claims: %w", err)
+	}
+
+	return &claims, nil
+}
+
+// ExpiresAt returns when the session expires, and false if the bearer token
+// does not carry an expiry
+func (s *Session) ExpiresAt() (time.Time, bool) {
+	claims, err := s.Claims()
+	if err != nil || claims.ExpiresAt == 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(claims.ExpiresAt, 0), true
+}
+
+// ExpiresWithin returns whether the session expires within the given
+// duration.  A session without a known expiry is never considered expiring.
+func (s *Session) ExpiresWithin(d time.Duration) bool {
+	exp, ok := s.ExpiresAt()
+	if !ok {
+		return false
+	}
+
+	return time.Until(exp) < d
+}
+
+// Expired returns whether the session has expired
+func (s *Session) Expired() bool {
+	return s.ExpiresWithin(0)
+}
+
 type loginRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
@@ -37,20 +101,49 @@ func (ic *IonClient) Login(username, password string) (*Session, error) {
 	login := loginRequest{username, password}
 	b, err := json.Marshal(login)
 	if err != nil {
-		return nil, fmt.Errorf("session: failed to marshal login body: %v", err.Error())
+		return nil, fmt.Errorf("session: failed to marshal login body: %w", err)
 	}
 
 	buff := bytes.NewBuffer(b)
 	b, err = ic.Post(sessionsLoginEndpoint, "", nil, *buff, headers)
 	if err != nil {
-		return nil, fmt.Errorf("session: failed login request: %v", err.Error())
+		return nil, fmt.Errorf("session: failed login request: %w", err)
 	}
 
 	var resp Session
 	err = json.Unmarshal(b, &resp)
 	if err != nil {
-		return nil, fmt.Errorf("session: failed to unmarshal response: %v", err.Error())
+		return nil, fmt.Errorf("session: failed to unmarshal response: %w", err)
 	}
 
 	return &resp, nil
 }
+
+// RefreshSession takes the bearer token of a session that has not yet expired
+// and returns a new session with a fresh bearer token.  Returns an error for
+// HTTP and JSON errors.
+func (ic *IonClient) RefreshSession(token string) (*Session, error) {
+	b, _, err := ic.Get(sessionsRefreshEndpoint, token, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("session: failed refresh request: %w", err)
+	}
+
+	var resp Session
+	err = json.Unmarshal(b, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("session: failed to unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// Logout takes the bearer token of a session and ends the session, so the
+// token can no longer be used.  Returns an error for HTTP errors.
+func (ic *IonClient) Logout(token string) error {
+	_, err := ic.Delete(sessionsLogoutEndpoint, token, nil, nil)
+	if err != nil {
+		return fmt.Errorf("session: failed logout request: %w", err)
+	}
+
+	return nil
+}
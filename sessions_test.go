@@ -1,9 +1,11 @@
 package ionic
 
 import (
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/franela/goblin"
 	"github.com/gomicro/bogus"
@@ -30,9 +32,81 @@ func TestSessions(t *testing.T) {
 			Expect(session.BearerToken).To(Equal("supersecretkey"))
 			Expect(session.User.Email).To(Equal("ion@ion.ion"))
 		})
+
+		g.It("should refresh a session", func() {
+			server.AddPath("/v1/sessions/refresh").
+				SetMethods("GET").
+				SetPayload([]byte(SampleSessionResponse)).
+				SetStatus(http.StatusOK)
+
+			session, err := client.RefreshSession("oldkey")
+			Expect(err).To(BeNil())
+			Expect(session.BearerToken).To(Equal("supersecretkey"))
+		})
+
+		g.It("should return an error when a session cannot be refreshed", func() {
+			server.AddPath("/v1/sessions/refresh").
+				SetMethods("GET").
+				SetPayload([]byte(`{"message":"unauthorized","code":401}`)).
+				SetStatus(http.StatusUnauthorized)
+
+			session, err := client.RefreshSession("oldkey")
+			Expect(err).NotTo(BeNil())
+			Expect(session).To(BeNil())
+		})
+
+		g.It("should log out of a session", func() {
+			server.AddPath("/v1/sessions/logout").
+				SetMethods("DELETE").
+				SetStatus(http.StatusNoContent)
+
+			err := client.Logout("supersecretkey")
+			Expect(err).To(BeNil())
+		})
+
+		g.Describe("Expiry", func() {
+			g.It("should decode the expiry from the bearer token", func() {
+				exp := time.Now().Add(time.Hour).Truncate(time.Second)
+				s := &Session{BearerToken: sampleJWT(exp.Unix())}
+
+				claims, err := s.Claims()
+				Expect(err).To(BeNil())
+				Expect(claims.Subject).To(Equal("userid"))
+
+				at, ok := s.ExpiresAt()
+				Expect(ok).To(BeTrue())
+				Expect(at.Equal(exp)).To(BeTrue())
+				Expect(s.Expired()).To(BeFalse())
+				Expect(s.ExpiresWithin(2 * time.Hour)).To(BeTrue())
+			})
+
+			g.It("should report an expired session", func() {
+				s := &Session{BearerToken: sampleJWT(time.Now().Add(-time.Minute).Unix())}
+
+				Expect(s.Expired()).To(BeTrue())
+			})
+
+			g.It("should not know the expiry of an opaque token", func() {
+				s := &Session{BearerToken: "supersecretkey"}
+
+				_, err := s.Claims()
+				Expect(err).NotTo(BeNil())
+
+				_, ok := s.ExpiresAt()
+				Expect(ok).To(BeFalse())
+				Expect(s.Expired()).To(BeFalse())
+			})
+		})
 	})
 }
 
+func sampleJWT(exp int64) string {
+	enc := base64.RawURLEncoding
+	header := enc.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	claims := enc.EncodeToString([]byte(fmt.Sprintf(`{"sub":"userid","exp":%v}`, exp)))
+	return fmt.Sprintf("%v.%v.signature", header, claims)
+}
+
 const (
 	SampleSessionResponse = `{"data":{"jwt":"supersecretkey","user":{"id":"userid","created_at":"2016-08-17T21:07:29.697Z","updated_at":"2017-04-27T22:11:00.404Z","username":"ion","email":"ion@ion.ion","chat_handle":null,"last_active_at":"2017-04-27T22:11:00.396Z","sys_admin":true,"teams":{"adminteamid":"admin"}}},"meta":{"copyright":"Copyright 2016 Ion Channel Corporation","authors":["kitplummer","Olio Apps"],"version":"v1"},"links":{"self":"https://janice.ionchannel.testing/v1/sessions/login","created":"https://janice.ionchannel.testing/v1/sessions/login"},"timestamps":{"created":"2017-04-27T22:11:10.546+00:00","updated":"2017-04-27T22:11:10.546+00:00"}}`
 )
@@ -0,0 +1,667 @@
+package spdx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/ion-channel/ionic/projects"
+	"github.com/spdx/tools-golang/spdx"
+	"gopkg.in/yaml.v2"
+)
+
+// Format is a string enum for the serializations of SPDX documents that can be
+// read
+type Format string
+
+const (
+	// FormatAuto detects the serialization from the document's content
+	FormatAuto Format = ""
+	// FormatJSON is the enum value for SPDX JSON documents
+	FormatJSON Format = "json"
+	// FormatYAML is the enum value for SPDX YAML documents
+	FormatYAML Format = "yaml"
+	// FormatTagValue is the enum value for SPDX tag-value documents
+	FormatTagValue Format = "tag-value"
+	// FormatRDF is the enum value for SPDX RDF/XML documents
+	FormatRDF Format = "rdf"
+)
+
+var (
+	// SupportedVersions are the SPDX versions that can be read
+	SupportedVersions = []string{"SPDX-2.1", "SPDX-2.2", "SPDX-2.3"}
+
+	// ErrUnsupportedVersion is returned when a document's SPDX version is not
+	// one of the supported versions
+	ErrUnsupportedVersion = fmt.Errorf("unsupported SPDX version")
+
+	tagValueRegex = regexp.MustCompile(`(?m)^\s*SPDXVersion\s*:`)
+	yamlRegex     = regexp.MustCompile(`(?m)^\s*(---|spdxVersion\s*:)`)
+)
+
+// ProjectsFromSPDXReader reads an SPDX document (v2.1, v2.2, or v2.3) in the
+// given format and parses its packages into Projects, as ProjectsFromSPDX
+// does.  FormatAuto detects the format from the document.
+func ProjectsFromSPDXReader(r io.Reader, format Format, includeDependencies bool) ([]projects.Project, error) {
+	doc, err := Parse(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return ProjectsFromSPDX(doc, includeDependencies)
+}
+
+// Parse reads an SPDX document in the given format, detecting the format from
+// the document for FormatAuto.  It returns a *spdx.Document2_1 for v2.1
+// documents and a *spdx.Document2_2 for v2.2 and v2.3 documents, since v2.3
+// only adds to v2.2.  The document's version is kept in its creation info.
+func Parse(r io.Reader, format Format) (interface{}, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SPDX document: %w", err)
+	}
+
+	b = bytes.TrimPrefix(b, []byte("\xef\xbb\xbf"))
+
+	if format == FormatAuto {
+		format, err = detectFormat(b)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var doc *document
+	switch format {
+	case FormatJSON:
+		doc, err = parseJSON(b)
+	case FormatYAML:
+		doc, err = parseYAML(b)
+	case FormatTagValue:
+		doc, err = parseTagValue(b)
+	case FormatRDF:
+		doc, err = parseRDF(b)
+	default:
+		return nil, fmt.Errorf("unknown SPDX format %q", format)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SPDX %v document: %w", format, err)
+	}
+
+	switch doc.SPDXVersion {
+	case "SPDX-2.1":
+		return doc.toDocument2_1(), nil
+	case "SPDX-2.2", "SPDX-2.3":
+		return doc.toDocument2_2(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedVersion, doc.SPDXVersion)
+	}
+}
+
+// detectFormat returns the serialization of the document based on its content
+func detectFormat(b []byte) (Format, error) {
+	trimmed := bytes.TrimSpace(b)
+	if len(trimmed) == 0 {
+		return "", fmt.Errorf("failed to detect SPDX format: empty document")
+	}
+
+	switch {
+	case trimmed[0] == '{':
+		return FormatJSON, nil
+	case trimmed[0] == '<':
+		return FormatRDF, nil
+	case tagValueRegex.Match(trimmed):
+		return FormatTagValue, nil
+	case yamlRegex.Match(trimmed):
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("failed to detect SPDX format")
+	}
+}
+
+// document is a serialization agnostic representation of an SPDX document,
+// shaped after the SPDX JSON schema.  Every format is read into a document
+// before being converted to the version specific types, and documents are
+// converted back into one to be written.
+type document struct {
+	SPDXVersion          string                `json:"spdxVersion" yaml:"spdxVersion"`
+	DataLicense          string                `json:"dataLicense" yaml:"dataLicense"`
+	SPDXID               string                `json:"SPDXID" yaml:"SPDXID"`
+	Name                 string                `json:"name" yaml:"name"`
+	DocumentNamespace    string                `json:"documentNamespace" yaml:"documentNamespace"`
+	ExternalDocumentRefs []externalDocumentRef `json:"externalDocumentRefs,omitempty" yaml:"externalDocumentRefs,omitempty"`
+	CreationInfo         creationInfo          `json:"creationInfo" yaml:"creationInfo"`
+	Comment              string                `json:"comment,omitempty" yaml:"comment,omitempty"`
+	DocumentDescribes    []string              `json:"documentDescribes,omitempty" yaml:"documentDescribes,omitempty"`
+	Packages             []packageSection      `json:"packages,omitempty" yaml:"packages,omitempty"`
+	Files                []fileSection         `json:"files,omitempty" yaml:"files,omitempty"`
+	Relationships        []relationship        `json:"relationships,omitempty" yaml:"relationships,omitempty"`
+	ExtractedLicenses    []extractedLicense    `json:"hasExtractedLicensingInfos,omitempty" yaml:"hasExtractedLicensingInfos,omitempty"`
+}
+
+type externalDocumentRef struct {
+	ExternalDocumentID string   `json:"externalDocumentId" yaml:"externalDocumentId"`
+	SPDXDocument       string   `json:"spdxDocument" yaml:"spdxDocument"`
+	Checksum           checksum `json:"checksum" yaml:"checksum"`
+}
+
+type creationInfo struct {
+	Created            string   `json:"created" yaml:"created"`
+	Creators           []string `json:"creators" yaml:"creators"`
+	Comment            string   `json:"comment,omitempty" yaml:"comment,omitempty"`
+	LicenseListVersion string   `json:"licenseListVersion,omitempty" yaml:"licenseListVersion,omitempty"`
+}
+
+type checksum struct {
+	Algorithm string `json:"algorithm" yaml:"algorithm"`
+	Value     string `json:"checksumValue" yaml:"checksumValue"`
+}
+
+type verificationCode struct {
+	Value         string   `json:"packageVerificationCodeValue" yaml:"packageVerificationCodeValue"`
+	ExcludedFiles []string `json:"packageVerificationCodeExcludedFiles,omitempty" yaml:"packageVerificationCodeExcludedFiles,omitempty"`
+}
+
+type externalRef struct {
+	Category string `json:"referenceCategory" yaml:"referenceCategory"`
+	Type     string `json:"referenceType" yaml:"referenceType"`
+	Locator  string `json:"referenceLocator" yaml:"referenceLocator"`
+	Comment  string `json:"comment,omitempty" yaml:"comment,omitempty"`
+}
+
+type packageSection struct {
+	SPDXID               string            `json:"SPDXID" yaml:"SPDXID"`
+	Name                 string            `json:"name" yaml:"name"`
+	VersionInfo          string            `json:"versionInfo,omitempty" yaml:"versionInfo,omitempty"`
+	PackageFileName      string            `json:"packageFileName,omitempty" yaml:"packageFileName,omitempty"`
+	Supplier             string            `json:"supplier,omitempty" yaml:"supplier,omitempty"`
+	Originator           string            `json:"originator,omitempty" yaml:"originator,omitempty"`
+	DownloadLocation     string            `json:"downloadLocation" yaml:"downloadLocation"`
+	FilesAnalyzed        *bool             `json:"filesAnalyzed,omitempty" yaml:"filesAnalyzed,omitempty"`
+	VerificationCode     *verificationCode `json:"packageVerificationCode,omitempty" yaml:"packageVerificationCode,omitempty"`
+	Checksums            []checksum        `json:"checksums,omitempty" yaml:"checksums,omitempty"`
+	Homepage             string            `json:"homepage,omitempty" yaml:"homepage,omitempty"`
+	SourceInfo           string            `json:"sourceInfo,omitempty" yaml:"sourceInfo,omitempty"`
+	LicenseConcluded     string            `json:"licenseConcluded,omitempty" yaml:"licenseConcluded,omitempty"`
+	LicenseInfoFromFiles []string          `json:"licenseInfoFromFiles,omitempty" yaml:"licenseInfoFromFiles,omitempty"`
+	LicenseDeclared      string            `json:"licenseDeclared,omitempty" yaml:"licenseDeclared,omitempty"`
+	LicenseComments      string            `json:"licenseComments,omitempty" yaml:"licenseComments,omitempty"`
+	CopyrightText        string            `json:"copyrightText,omitempty" yaml:"copyrightText,omitempty"`
+	Summary              string            `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description          string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Comment              string            `json:"comment,omitempty" yaml:"comment,omitempty"`
+	ExternalRefs         []externalRef     `json:"externalRefs,omitempty" yaml:"externalRefs,omitempty"`
+	AttributionTexts     []string          `json:"attributionTexts,omitempty" yaml:"attributionTexts,omitempty"`
+	HasFiles             []string          `json:"hasFiles,omitempty" yaml:"hasFiles,omitempty"`
+}
+
+type fileSection struct {
+	SPDXID             string     `json:"SPDXID" yaml:"SPDXID"`
+	FileName           string     `json:"fileName" yaml:"fileName"`
+	FileTypes          []string   `json:"fileTypes,omitempty" yaml:"fileTypes,omitempty"`
+	Checksums          []checksum `json:"checksums,omitempty" yaml:"checksums,omitempty"`
+	LicenseConcluded   string     `json:"licenseConcluded,omitempty" yaml:"licenseConcluded,omitempty"`
+	LicenseInfoInFiles []string   `json:"licenseInfoInFiles,omitempty" yaml:"licenseInfoInFiles,omitempty"`
+	LicenseComments    string     `json:"licenseComments,omitempty" yaml:"licenseComments,omitempty"`
+	CopyrightText      string     `json:"copyrightText,omitempty" yaml:"copyrightText,omitempty"`
+	Comment            string     `json:"comment,omitempty" yaml:"comment,omitempty"`
+	NoticeText         string     `json:"noticeText,omitempty" yaml:"noticeText,omitempty"`
+	FileContributors   []string   `json:"fileContributors,omitempty" yaml:"fileContributors,omitempty"`
+	AttributionTexts   []string   `json:"attributionTexts,omitempty" yaml:"attributionTexts,omitempty"`
+}
+
+type extractedLicense struct {
+	LicenseID     string   `json:"licenseId" yaml:"licenseId"`
+	ExtractedText string   `json:"extractedText" yaml:"extractedText"`
+	Name          string   `json:"name,omitempty" yaml:"name,omitempty"`
+	SeeAlsos      []string `json:"seeAlsos,omitempty" yaml:"seeAlsos,omitempty"`
+	Comment       string   `json:"comment,omitempty" yaml:"comment,omitempty"`
+}
+
+type relationship struct {
+	SPDXElementID      string `json:"spdxElementId" yaml:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType" yaml:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement" yaml:"relatedSpdxElement"`
+	Comment            string `json:"comment,omitempty" yaml:"comment,omitempty"`
+}
+
+func parseJSON(b []byte) (*document, error) {
+	var doc document
+	err := json.Unmarshal(b, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+// parseYAML reads a YAML document into the same structures as JSON, since the
+// YAML serialization shares the JSON schema.  Decoding into the typed
+// structures keeps scalars such as 1.10 or y as written for string fields.
+func parseYAML(b []byte) (*document, error) {
+	var doc document
+	err := yaml.Unmarshal(b, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+// elementID strips the SPDXRef- prefix from an SPDX identifier
+func elementID(id string) spdx.ElementID {
+	return spdx.ElementID(strings.TrimPrefix(strings.TrimSpace(id), "SPDXRef-"))
+}
+
+// docElementID parses an identifier that may refer to an element of another
+// document, or be one of the special NONE and NOASSERTION values
+func docElementID(id string) spdx.DocElementID {
+	id = strings.TrimSpace(id)
+	if id == "NONE" || id == "NOASSERTION" {
+		return spdx.MakeDocElementSpecial(id)
+	}
+
+	if strings.HasPrefix(id, "DocumentRef-") {
+		parts := strings.SplitN(id, ":", 2)
+		if len(parts) == 2 {
+			return spdx.MakeDocElementID(strings.TrimPrefix(parts[0], "DocumentRef-"), string(elementID(parts[1])))
+		}
+	}
+
+	return spdx.MakeDocElementID("", string(elementID(id)))
+}
+
+// actor splits an actor, such as a supplier, into its person or organization
+// and whether it is NOASSERTION
+func actor(value string) (person, organization string, noAssertion bool) {
+	value = strings.TrimSpace(value)
+
+	switch {
+	case value == "NOASSERTION":
+		return "", "", true
+	case strings.HasPrefix(value, "Person:"):
+		return strings.TrimSpace(strings.TrimPrefix(value, "Person:")), "", false
+	case strings.HasPrefix(value, "Organization:"):
+		return "", strings.TrimSpace(strings.TrimPrefix(value, "Organization:")), false
+	default:
+		return "", "", false
+	}
+}
+
+// creators splits the document's creators into people, organizations, and
+// tools
+func (c creationInfo) creators() (persons, organizations, tools []string) {
+	for _, creator := range c.Creators {
+		creator = strings.TrimSpace(creator)
+
+		switch {
+		case strings.HasPrefix(creator, "Person:"):
+			persons = append(persons, strings.TrimSpace(strings.TrimPrefix(creator, "Person:")))
+		case strings.HasPrefix(creator, "Organization:"):
+			organizations = append(organizations, strings.TrimSpace(strings.TrimPrefix(creator, "Organization:")))
+		case strings.HasPrefix(creator, "Tool:"):
+			tools = append(tools, strings.TrimSpace(strings.TrimPrefix(creator, "Tool:")))
+		}
+	}
+
+	return persons, organizations, tools
+}
+
+// filesAnalyzed returns whether the package's files were analyzed, which
+// defaults to true when not given
+func (p packageSection) filesAnalyzed() bool {
+	return p.FilesAnalyzed == nil || *p.FilesAnalyzed
+}
+
+func (p packageSection) verificationCode() (string, string) {
+	if p.VerificationCode == nil {
+		return "", ""
+	}
+
+	return p.VerificationCode.Value, strings.Join(p.VerificationCode.ExcludedFiles, ", ")
+}
+
+// relationships returns the document's relationships, including those implied
+// by the packages it lists as described
+func (d *document) relationships() []relationship {
+	rs := append([]relationship{}, d.Relationships...)
+
+	docID := d.SPDXID
+	if docID == "" {
+		docID = "SPDXRef-DOCUMENT"
+	}
+
+	for _, described := range d.DocumentDescribes {
+		found := false
+		for _, r := range rs {
+			if r.SPDXElementID == docID && r.RelationshipType == "DESCRIBES" && r.RelatedSPDXElement == described {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			rs = append(rs, relationship{SPDXElementID: docID, RelationshipType: "DESCRIBES", RelatedSPDXElement: described})
+		}
+	}
+
+	return rs
+}
+
+// fileOwners maps the ID of each file to the ID of the package containing it,
+// based on the packages' files and CONTAINS and CONTAINED_BY relationships
+func (d *document) fileOwners() map[string]string {
+	owners := make(map[string]string)
+
+	for _, p := range d.Packages {
+		for _, f := range p.HasFiles {
+			owners[f] = p.SPDXID
+		}
+	}
+
+	packages := make(map[string]bool)
+	for _, p := range d.Packages {
+		packages[p.SPDXID] = true
+	}
+
+	for _, r := range d.Relationships {
+		switch {
+		case r.RelationshipType == "CONTAINS" && packages[r.SPDXElementID]:
+			if _, ok := owners[r.RelatedSPDXElement]; !ok {
+				owners[r.RelatedSPDXElement] = r.SPDXElementID
+			}
+		case r.RelationshipType == "CONTAINED_BY" && packages[r.RelatedSPDXElement]:
+			if _, ok := owners[r.SPDXElementID]; !ok {
+				owners[r.SPDXElementID] = r.RelatedSPDXElement
+			}
+		}
+	}
+
+	return owners
+}
+
+func (d *document) toDocument2_2() *spdx.Document2_2 {
+	persons, organizations, tools := d.CreationInfo.creators()
+
+	doc := &spdx.Document2_2{
+		CreationInfo: &spdx.CreationInfo2_2{
+			SPDXVersion:                d.SPDXVersion,
+			DataLicense:                d.DataLicense,
+			SPDXIdentifier:             elementID(d.SPDXID),
+			DocumentName:               d.Name,
+			DocumentNamespace:          d.DocumentNamespace,
+			ExternalDocumentReferences: make(map[string]spdx.ExternalDocumentRef2_2),
+			LicenseListVersion:         d.CreationInfo.LicenseListVersion,
+			CreatorPersons:             persons,
+			CreatorOrganizations:       organizations,
+			CreatorTools:               tools,
+			Created:                    d.CreationInfo.Created,
+			CreatorComment:             d.CreationInfo.Comment,
+			DocumentComment:            d.Comment,
+		},
+		Packages:        make(map[spdx.ElementID]*spdx.Package2_2),
+		UnpackagedFiles: make(map[spdx.ElementID]*spdx.File2_2),
+	}
+
+	for _, ref := range d.ExternalDocumentRefs {
+		id := strings.TrimPrefix(ref.ExternalDocumentID, "DocumentRef-")
+		doc.CreationInfo.ExternalDocumentReferences[id] = spdx.ExternalDocumentRef2_2{
+			DocumentRefID: id,
+			URI:           ref.SPDXDocument,
+			Alg:           ref.Checksum.Algorithm,
+			Checksum:      ref.Checksum.Value,
+		}
+	}
+
+	for _, p := range d.Packages {
+		supplierPerson, supplierOrg, supplierNoAssertion := actor(p.Supplier)
+		originatorPerson, originatorOrg, originatorNoAssertion := actor(p.Originator)
+		code, excluded := p.verificationCode()
+
+		pkg := &spdx.Package2_2{
+			PackageName:                         p.Name,
+			PackageSPDXIdentifier:               elementID(p.SPDXID),
+			PackageVersion:                      p.VersionInfo,
+			PackageFileName:                     p.PackageFileName,
+			PackageSupplierPerson:               supplierPerson,
+			PackageSupplierOrganization:         supplierOrg,
+			PackageSupplierNOASSERTION:          supplierNoAssertion,
+			PackageOriginatorPerson:             originatorPerson,
+			PackageOriginatorOrganization:       originatorOrg,
+			PackageOriginatorNOASSERTION:        originatorNoAssertion,
+			PackageDownloadLocation:             p.DownloadLocation,
+			FilesAnalyzed:                       p.filesAnalyzed(),
+			IsFilesAnalyzedTagPresent:           p.FilesAnalyzed != nil,
+			PackageVerificationCode:             code,
+			PackageVerificationCodeExcludedFile: excluded,
+			PackageChecksums:                    make(map[spdx.ChecksumAlgorithm]spdx.Checksum),
+			PackageHomePage:                     p.Homepage,
+			PackageSourceInfo:                   p.SourceInfo,
+			PackageLicenseConcluded:             p.LicenseConcluded,
+			PackageLicenseInfoFromFiles:         p.LicenseInfoFromFiles,
+			PackageLicenseDeclared:              p.LicenseDeclared,
+			PackageLicenseComments:              p.LicenseComments,
+			PackageCopyrightText:                p.CopyrightText,
+			PackageSummary:                      p.Summary,
+			PackageDescription:                  p.Description,
+			PackageComment:                      p.Comment,
+			PackageAttributionTexts:             p.AttributionTexts,
+			Files:                               make(map[spdx.ElementID]*spdx.File2_2),
+		}
+
+		for _, c := range p.Checksums {
+			alg := spdx.ChecksumAlgorithm(c.Algorithm)
+			pkg.PackageChecksums[alg] = spdx.Checksum{Algorithm: alg, Value: c.Value}
+		}
+
+		for _, ref := range p.ExternalRefs {
+			pkg.PackageExternalReferences = append(pkg.PackageExternalReferences, &spdx.PackageExternalReference2_2{
+				Category:           ref.Category,
+				RefType:            ref.Type,
+				Locator:            ref.Locator,
+				ExternalRefComment: ref.Comment,
+			})
+		}
+
+		doc.Packages[pkg.PackageSPDXIdentifier] = pkg
+	}
+
+	owners := d.fileOwners()
+	for _, f := range d.Files {
+		file := &spdx.File2_2{
+			FileName:             f.FileName,
+			FileSPDXIdentifier:   elementID(f.SPDXID),
+			FileType:             f.FileTypes,
+			FileChecksums:        make(map[spdx.ChecksumAlgorithm]spdx.Checksum),
+			LicenseConcluded:     f.LicenseConcluded,
+			LicenseInfoInFile:    f.LicenseInfoInFiles,
+			LicenseComments:      f.LicenseComments,
+			FileCopyrightText:    f.CopyrightText,
+			FileComment:          f.Comment,
+			FileNotice:           f.NoticeText,
+			FileContributor:      f.FileContributors,
+			FileAttributionTexts: f.AttributionTexts,
+		}
+
+		for _, c := range f.Checksums {
+			alg := spdx.ChecksumAlgorithm(c.Algorithm)
+			file.FileChecksums[alg] = spdx.Checksum{Algorithm: alg, Value: c.Value}
+		}
+
+		if pkg, ok := doc.Packages[elementID(owners[f.SPDXID])]; ok {
+			pkg.Files[file.FileSPDXIdentifier] = file
+		} else {
+			doc.UnpackagedFiles[file.FileSPDXIdentifier] = file
+		}
+	}
+
+	for _, l := range d.ExtractedLicenses {
+		doc.OtherLicenses = append(doc.OtherLicenses, &spdx.OtherLicense2_2{
+			LicenseIdentifier:      l.LicenseID,
+			ExtractedText:          l.ExtractedText,
+			LicenseName:            l.Name,
+			LicenseCrossReferences: l.SeeAlsos,
+			LicenseComment:         l.Comment,
+		})
+	}
+
+	for _, r := range d.relationships() {
+		doc.Relationships = append(doc.Relationships, &spdx.Relationship2_2{
+			RefA:                docElementID(r.SPDXElementID),
+			RefB:                docElementID(r.RelatedSPDXElement),
+			Relationship:        r.RelationshipType,
+			RelationshipComment: r.Comment,
+		})
+	}
+
+	return doc
+}
+
+func (d *document) toDocument2_1() *spdx.Document2_1 {
+	persons, organizations, tools := d.CreationInfo.creators()
+
+	doc := &spdx.Document2_1{
+		CreationInfo: &spdx.CreationInfo2_1{
+			SPDXVersion:                d.SPDXVersion,
+			DataLicense:                d.DataLicense,
+			SPDXIdentifier:             elementID(d.SPDXID),
+			DocumentName:               d.Name,
+			DocumentNamespace:          d.DocumentNamespace,
+			ExternalDocumentReferences: make(map[string]spdx.ExternalDocumentRef2_1),
+			LicenseListVersion:         d.CreationInfo.LicenseListVersion,
+			CreatorPersons:             persons,
+			CreatorOrganizations:       organizations,
+			CreatorTools:               tools,
+			Created:                    d.CreationInfo.Created,
+			CreatorComment:             d.CreationInfo.Comment,
+			DocumentComment:            d.Comment,
+		},
+		Packages:        make(map[spdx.ElementID]*spdx.Package2_1),
+		UnpackagedFiles: make(map[spdx.ElementID]*spdx.File2_1),
+	}
+
+	for _, ref := range d.ExternalDocumentRefs {
+		id := strings.TrimPrefix(ref.ExternalDocumentID, "DocumentRef-")
+		doc.CreationInfo.ExternalDocumentReferences[id] = spdx.ExternalDocumentRef2_1{
+			DocumentRefID: id,
+			URI:           ref.SPDXDocument,
+			Alg:           ref.Checksum.Algorithm,
+			Checksum:      ref.Checksum.Value,
+		}
+	}
+
+	for _, p := range d.Packages {
+		supplierPerson, supplierOrg, supplierNoAssertion := actor(p.Supplier)
+		originatorPerson, originatorOrg, originatorNoAssertion := actor(p.Originator)
+		code, excluded := p.verificationCode()
+
+		pkg := &spdx.Package2_1{
+			PackageName:                         p.Name,
+			PackageSPDXIdentifier:               elementID(p.SPDXID),
+			PackageVersion:                      p.VersionInfo,
+			PackageFileName:                     p.PackageFileName,
+			PackageSupplierPerson:               supplierPerson,
+			PackageSupplierOrganization:         supplierOrg,
+			PackageSupplierNOASSERTION:          supplierNoAssertion,
+			PackageOriginatorPerson:             originatorPerson,
+			PackageOriginatorOrganization:       originatorOrg,
+			PackageOriginatorNOASSERTION:        originatorNoAssertion,
+			PackageDownloadLocation:             p.DownloadLocation,
+			FilesAnalyzed:                       p.filesAnalyzed(),
+			IsFilesAnalyzedTagPresent:           p.FilesAnalyzed != nil,
+			PackageVerificationCode:             code,
+			PackageVerificationCodeExcludedFile: excluded,
+			PackageHomePage:                     p.Homepage,
+			PackageSourceInfo:                   p.SourceInfo,
+			PackageLicenseConcluded:             p.LicenseConcluded,
+			PackageLicenseInfoFromFiles:         p.LicenseInfoFromFiles,
+			PackageLicenseDeclared:              p.LicenseDeclared,
+			PackageLicenseComments:              p.LicenseComments,
+			PackageCopyrightText:                p.CopyrightText,
+			PackageSummary:                      p.Summary,
+			PackageDescription:                  p.Description,
+			PackageComment:                      p.Comment,
+			Files:                               make(map[spdx.ElementID]*spdx.File2_1),
+		}
+
+		for _, c := range p.Checksums {
+			switch spdx.ChecksumAlgorithm(c.Algorithm) {
+			case spdx.SHA1:
+				pkg.PackageChecksumSHA1 = c.Value
+			case spdx.SHA256:
+				pkg.PackageChecksumSHA256 = c.Value
+			case spdx.MD5:
+				pkg.PackageChecksumMD5 = c.Value
+			}
+		}
+
+		for _, ref := range p.ExternalRefs {
+			pkg.PackageExternalReferences = append(pkg.PackageExternalReferences, &spdx.PackageExternalReference2_1{
+				Category:           ref.Category,
+				RefType:            ref.Type,
+				Locator:            ref.Locator,
+				ExternalRefComment: ref.Comment,
+			})
+		}
+
+		doc.Packages[pkg.PackageSPDXIdentifier] = pkg
+	}
+
+	owners := d.fileOwners()
+	for _, f := range d.Files {
+		file := &spdx.File2_1{
+			FileName:           f.FileName,
+			FileSPDXIdentifier: elementID(f.SPDXID),
+			FileType:           f.FileTypes,
+			LicenseConcluded:   f.LicenseConcluded,
+			LicenseInfoInFile:  f.LicenseInfoInFiles,
+			LicenseComments:    f.LicenseComments,
+			FileCopyrightText:  f.CopyrightText,
+			FileComment:        f.Comment,
+			FileNotice:         f.NoticeText,
+			FileContributor:    f.FileContributors,
+		}
+
+		for _, c := range f.Checksums {
+			switch spdx.ChecksumAlgorithm(c.Algorithm) {
+			case spdx.SHA1:
+				file.FileChecksumSHA1 = c.Value
+			case spdx.SHA256:
+				file.FileChecksumSHA256 = c.Value
+			case spdx.MD5:
+				file.FileChecksumMD5 = c.Value
+			}
+		}
+
+		if pkg, ok := doc.Packages[elementID(owners[f.SPDXID])]; ok {
+			pkg.Files[file.FileSPDXIdentifier] = file
+		} else {
+			doc.UnpackagedFiles[file.FileSPDXIdentifier] = file
+		}
+	}
+
+	for _, l := range d.ExtractedLicenses {
+		doc.OtherLicenses = append(doc.OtherLicenses, &spdx.OtherLicense2_1{
+			LicenseIdentifier:      l.LicenseID,
+			ExtractedText:          l.ExtractedText,
+			LicenseName:            l.Name,
+			LicenseCrossReferences: l.SeeAlsos,
+			LicenseComment:         l.Comment,
+		})
+	}
+
+	for _, r := range d.relationships() {
+		doc.Relationships = append(doc.Relationships, &spdx.Relationship2_1{
+			RefA:                docElementID(r.SPDXElementID),
+			RefB:                docElementID(r.RelatedSPDXElement),
+			Relationship:        r.RelationshipType,
+			RelationshipComment: r.Comment,
+		})
+	}
+
+	return doc
+}
@@ -0,0 +1,359 @@
+package spdx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic/aliases"
+	"github.com/ion-channel/ionic/errors"
+	. "github.com/onsi/gomega"
+	"github.com/spdx/tools-golang/spdx"
+)
+
+const sampleJSON = `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "some-cool-pkg",
+  "documentNamespace": "https://ionchannel.io/spdx/some-cool-pkg",
+  "creationInfo": {
+    "created": "2022-01-01T00:00:00Z",
+    "creators": ["Person: Monsieur Package Creator (mpc@mail.com)", "Tool: ionic"]
+  },
+  "documentDescribes": ["SPDXRef-some-cool-pkg"],
+  "packages": [
+    {
+      "SPDXID": "SPDXRef-some-cool-pkg",
+      "name": "some-cool-pkg",
+      "versionInfo": "1.2.3",
+      "supplier": "Organization: The Org",
+      "downloadLocation": "git+https://github.com/some-org/some-cool-pkg.git@main",
+      "description": "Some description",
+      "primaryPackagePurpose": "APPLICATION",
+      "hasFiles": ["SPDXRef-main"],
+      "externalRefs": [
+        {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/github.com/some-org/some-cool-pkg@1.2.3"},
+        {"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:some-org:some-cool-pkg:1.2.3:*:*:*:*:*:*:*"}
+      ]
+    },
+    {
+      "SPDXID": "SPDXRef-some-dep",
+      "name": "some-dep",
+      "versionInfo": "3.2.1",
+      "downloadLocation": "https://example.com/some-dep-3.2.1.tgz",
+      "filesAnalyzed": false,
+      "checksums": [{"algorithm": "SHA256", "checksumValue": "abc123"}]
+    }
+  ],
+  "files": [
+    {"SPDXID": "SPDXRef-main", "fileName": "./main.go", "licenseConcluded": "MIT", "licenseInfoInFiles": ["MIT"]},
+    {"SPDXID": "SPDXRef-loose", "fileName": "./README.md"}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-some-cool-pkg", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-some-dep"}
+  ]
+}`
+
+const sampleYAML = `spdxVersion: SPDX-2.2
+dataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+name: some-cool-pkg
+documentNamespace: https://ionchannel.io/spdx/some-cool-pkg
+creationInfo:
+  created: "2022-01-01T00:00:00Z"
+  creators:
+    - "Tool: ionic"
+packages:
+  - SPDXID: SPDXRef-some-cool-pkg
+    name: some-cool-pkg
+    versionInfo: 1.2.3
+    downloadLocation: NOASSERTION
+`
+
+const sampleTagValue = `SPDXVersion: SPDX-2.1
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: some-cool-pkg
+DocumentNamespace: https://ionchannel.io/spdx/some-cool-pkg
+Creator: Organization: The Org
+Created: 2022-01-01T00:00:00Z
+DocumentComment: <text>A document
+over two lines</text>
+
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-some-cool-pkg
+
+PackageName: some-cool-pkg
+SPDXID: SPDXRef-some-cool-pkg
+PackageVersion: 1.2.3
+PackageSupplier: Organization: The Org
+PackageDownloadLocation: https://github.com/some-org/some-cool-pkg.git
+PackageChecksum: SHA1: 85ed0817af83a24ad8da68c2b5094de69833983c
+PackageDescription: <text>Some description</text>
+ExternalRef: PACKAGE-MANAGER purl pkg:npm/some-cool-pkg@1.2.3
+ExternalRefComment: the npm package
+
+FileName: ./index.js
+SPDXID: SPDXRef-index
+FileChecksum: SHA1: 85ed0817af83a24ad8da68c2b5094de69833983c
+LicenseConcluded: MIT
+LicenseInfoInFile: MIT
+
+PackageName: some-dep
+SPDXID: SPDXRef-some-dep
+PackageVersion: 3.2.1
+PackageDownloadLocation: NONE
+FilesAnalyzed: false
+
+Relationship: SPDXRef-some-cool-pkg DEPENDS_ON SPDXRef-some-dep
+`
+
+const sampleRDF = `<?xml version="1.0" encoding="UTF-8"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+         xmlns:rdfs="http://www.w3.org/2000/01/rdf-schema#"
+         xmlns:spdx="http://spdx.org/rdf/terms#">
+  <spdx:SpdxDocument rdf:about="https://ionchannel.io/spdx/some-cool-pkg#SPDXRef-DOCUMENT">
+    <spdx:specVersion>SPDX-2.2</spdx:specVersion>
+    <spdx:dataLicense rdf:resource="http://spdx.org/licenses/CC0-1.0"/>
+    <spdx:name>some-cool-pkg</spdx:name>
+    <spdx:creationInfo>
+      <spdx:CreationInfo>
+        <spdx:created>2022-01-01T00:00:00Z</spdx:created>
+        <spdx:creator>Tool: ionic</spdx:creator>
+      </spdx:CreationInfo>
+    </spdx:creationInfo>
+    <spdx:relationship>
+      <spdx:Relationship>
+        <spdx:relationshipType rdf:resource="http://spdx.org/rdf/terms#relationshipType_describes"/>
+        <spdx:relatedSpdxElement>
+          <spdx:Package rdf:about="https://ionchannel.io/spdx/some-cool-pkg#SPDXRef-some-cool-pkg">
+            <spdx:name>some-cool-pkg</spdx:name>
+            <spdx:versionInfo>1.2.3</spdx:versionInfo>
+            <spdx:downloadLocation>https://github.com/some-org/some-cool-pkg.git</spdx:downloadLocation>
+            <spdx:licenseConcluded>
+              <spdx:DisjunctiveLicenseSet>
+                <spdx:member rdf:resource="http://spdx.org/licenses/MIT"/>
+                <spdx:member rdf:resource="http://spdx.org/licenses/Apache-2.0"/>
+              </spdx:DisjunctiveLicenseSet>
+            </spdx:licenseConcluded>
+            <spdx:externalRef>
+              <spdx:ExternalRef>
+                <spdx:referenceCategory rdf:resource="http://spdx.org/rdf/terms#referenceCategory_packageManager"/>
+                <spdx:referenceType rdf:resource="http://spdx.org/rdf/references/purl"/>
+                <spdx:referenceLocator>pkg:npm/some-cool-pkg@1.2.3</spdx:referenceLocator>
+              </spdx:ExternalRef>
+            </spdx:externalRef>
+            <spdx:relationship>
+              <spdx:Relationship>
+                <spdx:relationshipType rdf:resource="http://spdx.org/rdf/terms#relationshipType_dependsOn"/>
+                <spdx:relatedSpdxElement rdf:resource="https://ionchannel.io/spdx/some-cool-pkg#SPDXRef-some-dep"/>
+              </spdx:Relationship>
+            </spdx:relationship>
+          </spdx:Package>
+        </spdx:relatedSpdxElement>
+      </spdx:Relationship>
+    </spdx:relationship>
+  </spdx:SpdxDocument>
+  <spdx:Package rdf:about="https://ionchannel.io/spdx/some-cool-pkg#SPDXRef-some-dep">
+    <spdx:name>some-dep</spdx:name>
+    <spdx:downloadLocation rdf:resource="http://spdx.org/rdf/terms#noassertion"/>
+    <spdx:checksum>
+      <spdx:Checksum>
+        <spdx:algorithm rdf:resource="http://spdx.org/rdf/terms#checksumAlgorithm_sha1"/>
+        <spdx:checksumValue>85ed0817af83a24ad8da68c2b5094de69833983c</spdx:checksumValue>
+      </spdx:Checksum>
+    </spdx:checksum>
+  </spdx:Package>
+</rdf:RDF>`
+
+func TestParse(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Parsing SPDX documents", func() {
+		g.It("should read SPDX 2.3 JSON documents", func() {
+			parsed, err := Parse(strings.NewReader(sampleJSON), FormatAuto)
+			Expect(err).To(BeNil())
+
+			doc, ok := parsed.(*spdx.Document2_2)
+			Expect(ok).To(BeTrue())
+			Expect(doc.CreationInfo.SPDXVersion).To(Equal("SPDX-2.3"))
+			Expect(doc.CreationInfo.CreatorPersons).To(Equal([]string{"Monsieur Package Creator (mpc@mail.com)"}))
+			Expect(doc.CreationInfo.CreatorTools).To(Equal([]string{"ionic"}))
+			Expect(doc.Packages).To(HaveLen(2))
+
+			pkg := doc.Packages["some-cool-pkg"]
+			Expect(pkg.PackageSupplierOrganization).To(Equal("The Org"))
+			Expect(pkg.FilesAnalyzed).To(BeTrue())
+			Expect(pkg.PackageExternalReferences).To(HaveLen(2))
+			Expect(pkg.Files).To(HaveKey(spdx.ElementID("main")))
+			Expect(doc.UnpackagedFiles).To(HaveKey(spdx.ElementID("loose")))
+
+			dep := doc.Packages["some-dep"]
+			Expect(dep.FilesAnalyzed).To(BeFalse())
+			Expect(dep.PackageChecksums[spdx.SHA256].Value).To(Equal("abc123"))
+
+			Expect(doc.Relationships).To(HaveLen(2))
+			Expect(doc.Relationships[1].RefA).To(Equal(spdx.MakeDocElementID("", "DOCUMENT")))
+			Expect(doc.Relationships[1].Relationship).To(Equal("DESCRIBES"))
+		})
+
+		g.It("should read YAML documents", func() {
+			parsed, err := Parse(strings.NewReader(sampleYAML), FormatAuto)
+			Expect(err).To(BeNil())
+
+			doc := parsed.(*spdx.Document2_2)
+			Expect(doc.CreationInfo.DocumentName).To(Equal("some-cool-pkg"))
+			Expect(doc.Packages["some-cool-pkg"].PackageVersion).To(Equal("1.2.3"))
+		})
+
+		g.It("should keep unquoted YAML scalars as written", func() {
+			yml := sampleYAML + `  - SPDXID: 1e3
+    name: y
+    versionInfo: 1.10
+    downloadLocation: NOASSERTION
+    filesAnalyzed: false
+`
+
+			parsed, err := Parse(strings.NewReader(yml), FormatYAML)
+			Expect(err).To(BeNil())
+
+			doc := parsed.(*spdx.Document2_2)
+			pkg := doc.Packages["1e3"]
+			Expect(pkg).NotTo(BeNil())
+			Expect(pkg.PackageName).To(Equal("y"))
+			Expect(pkg.PackageVersion).To(Equal("1.10"))
+			Expect(pkg.FilesAnalyzed).To(BeFalse())
+		})
+
+		g.It("should read tag-value documents", func() {
+			parsed, err := Parse(strings.NewReader(sampleTagValue), FormatAuto)
+			Expect(err).To(BeNil())
+
+			doc, ok := parsed.(*spdx.Document2_1)
+			Expect(ok).To(BeTrue())
+			Expect(doc.CreationInfo.DocumentComment).To(Equal("A document\nover two lines"))
+			Expect(doc.CreationInfo.CreatorOrganizations).To(Equal([]string{"The Org"}))
+
+			pkg := doc.Packages["some-cool-pkg"]
+			Expect(pkg.PackageDescription).To(Equal("Some description"))
+			Expect(pkg.PackageChecksumSHA1).To(Equal("85ed0817af83a24ad8da68c2b5094de69833983c"))
+			Expect(pkg.PackageExternalReferences[0].ExternalRefComment).To(Equal("the npm package"))
+			Expect(pkg.Files["index"].LicenseConcluded).To(Equal("MIT"))
+
+			Expect(doc.Packages["some-dep"].FilesAnalyzed).To(BeFalse())
+			Expect(doc.Packages["some-dep"].Files).To(BeEmpty())
+			Expect(doc.Relationships).To(HaveLen(2))
+		})
+
+		g.It("should read RDF documents", func() {
+			parsed, err := Parse(strings.NewReader(sampleRDF), FormatAuto)
+			Expect(err).To(BeNil())
+
+			doc := parsed.(*spdx.Document2_2)
+			Expect(doc.CreationInfo.DocumentNamespace).To(Equal("https://ionchannel.io/spdx/some-cool-pkg"))
+			Expect(doc.CreationInfo.DataLicense).To(Equal("CC0-1.0"))
+			Expect(doc.Packages).To(HaveLen(2))
+
+			pkg := doc.Packages["some-cool-pkg"]
+			Expect(strings.Split(pkg.PackageLicenseConcluded, " OR ")).To(ConsistOf("MIT", "Apache-2.0"))
+			Expect(pkg.PackageExternalReferences[0].Category).To(Equal("PACKAGE-MANAGER"))
+			Expect(pkg.PackageExternalReferences[0].RefType).To(Equal("purl"))
+
+			dep := doc.Packages["some-dep"]
+			Expect(dep.PackageDownloadLocation).To(Equal("NOASSERTION"))
+			Expect(dep.PackageChecksums[spdx.SHA1].Value).To(Equal("85ed0817af83a24ad8da68c2b5094de69833983c"))
+
+			Expect(doc.Relationships).To(HaveLen(2))
+
+			types := make(map[string]spdx.ElementID)
+			for _, r := range doc.Relationships {
+				types[r.Relationship] = r.RefA.ElementRefID
+			}
+			Expect(types).To(HaveKeyWithValue("DESCRIBES", spdx.ElementID("DOCUMENT")))
+			Expect(types).To(HaveKeyWithValue("DEPENDS_ON", spdx.ElementID("some-cool-pkg")))
+		})
+
+		g.It("should read SPDX 2.3 tag-value and RDF documents", func() {
+			tagValue := strings.Replace(sampleTagValue, "SPDX-2.1", "SPDX-2.3", 1)
+			tagValue = strings.Replace(tagValue, "PackageVersion: 3.2.1\n", "PackageVersion: 3.2.1\nPrimaryPackagePurpose: LIBRARY\nReleaseDate: 2022-01-01T00:00:00Z\n", 1)
+
+			rdf := strings.Replace(sampleRDF, "SPDX-2.2", "SPDX-2.3", 1)
+			rdf = strings.Replace(rdf, "<spdx:name>some-dep</spdx:name>", `<spdx:name>some-dep</spdx:name>
+    <spdx:primaryPackagePurpose rdf:resource="http://spdx.org/rdf/terms#purpose_library"/>`, 1)
+
+			for format, sample := range map[Format]string{FormatTagValue: tagValue, FormatRDF: rdf} {
+				parsed, err := Parse(strings.NewReader(sample), format)
+				Expect(err).To(BeNil())
+
+				doc, ok := parsed.(*spdx.Document2_2)
+				Expect(ok).To(BeTrue())
+				Expect(doc.CreationInfo.SPDXVersion).To(Equal("SPDX-2.3"))
+				Expect(doc.Packages).To(HaveKey(spdx.ElementID("some-dep")))
+				Expect(doc.Relationships).To(HaveLen(2))
+			}
+		})
+
+		g.It("should reject unsupported versions and formats", func() {
+			_, err := Parse(strings.NewReader(strings.Replace(sampleJSON, "SPDX-2.3", "SPDX-3.0", 1)), FormatJSON)
+			Expect(errors.Is(err, ErrUnsupportedVersion)).To(BeTrue())
+
+			_, err = Parse(strings.NewReader("not an SPDX document"), FormatAuto)
+			Expect(err).NotTo(BeNil())
+
+			_, err = Parse(strings.NewReader(sampleJSON), Format("xlsx"))
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	g.Describe("Writing SPDX documents", func() {
+		g.It("should write JSON that reads back the same", func() {
+			for _, sample := range []string{sampleJSON, sampleTagValue, sampleRDF} {
+				parsed, err := Parse(strings.NewReader(sample), FormatAuto)
+				Expect(err).To(BeNil())
+
+				var b bytes.Buffer
+				Expect(WriteJSON(&b, parsed)).To(BeNil())
+
+				read, err := Parse(&b, FormatJSON)
+				Expect(err).To(BeNil())
+				Expect(read).To(Equal(parsed))
+			}
+		})
+
+		g.It("should reject documents of other types", func() {
+			var b bytes.Buffer
+			Expect(WriteJSON(&b, "not a document")).NotTo(BeNil())
+			Expect(WriteJSON(&b, &spdx.Document2_2{})).NotTo(BeNil())
+		})
+	})
+
+	g.Describe("Projects from a reader", func() {
+		g.It("should carry purls and CPEs into aliases", func() {
+			p, err := ProjectsFromSPDXReader(strings.NewReader(sampleJSON), FormatJSON, false)
+			Expect(err).To(BeNil())
+			Expect(p).To(HaveLen(1))
+			Expect(*p[0].Name).To(Equal("some-cool-pkg"))
+			Expect(*p[0].Type).To(Equal("git"))
+			Expect(*p[0].Branch).To(Equal("main"))
+			Expect(p[0].Aliases).To(Equal([]aliases.Alias{
+				{Org: "github.com/some-org", Name: "some-cool-pkg", Version: "1.2.3"},
+				{Org: "some-org", Name: "some-cool-pkg", Version: "1.2.3"},
+			}))
+		})
+
+		g.It("should include dependencies from every format", func() {
+			for _, doc := range []string{sampleJSON, sampleTagValue, sampleRDF} {
+				p, err := ProjectsFromSPDXReader(strings.NewReader(doc), FormatAuto, true)
+				Expect(err).To(BeNil())
+				Expect(p).To(HaveLen(2))
+			}
+
+			p, err := ProjectsFromSPDXReader(strings.NewReader(sampleTagValue), FormatTagValue, true)
+			Expect(err).To(BeNil())
+			Expect(*p[1].Type).To(Equal("source_unavailable"))
+			Expect(p[1].Aliases).To(Equal([]aliases.Alias{{Name: "some-dep", Version: "3.2.1"}}))
+		})
+	})
+}
@@ -0,0 +1,122 @@
+package spdx
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+
+	gordfloader "github.com/spdx/gordf/rdfloader"
+	gordf "github.com/spdx/gordf/rdfloader/parser"
+	rdfparser2v2 "github.com/spdx/tools-golang/rdfloader/parser2v2"
+)
+
+const (
+	spdxNamespace      = "http://spdx.org/rdf/terms#"
+	referenceNamespace = "http://spdx.org/rdf/references/"
+)
+
+// rdf2_3Properties are the properties SPDX 2.3 adds to RDF documents.  They are
+// dropped so the rest of a 2.3 document can be read as 2.2, which it only adds
+// to.
+var rdf2_3Properties = map[string]bool{
+	spdxNamespace + "primaryPackagePurpose": true,
+	spdxNamespace + "releaseDate":           true,
+	spdxNamespace + "builtDate":             true,
+	spdxNamespace + "validUntilDate":        true,
+}
+
+// parseRDF reads an SPDX RDF/XML document with the tools-golang loader, the
+// same as rdfloader.Load2_2 does.  The loader reads every version as 2.2, which
+// only adds to 2.1.
+func parseRDF(b []byte) (*document, error) {
+	p, err := gordfloader.LoadFromReaderObject(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	version := rdfVersion(p.Triples)
+	switch version {
+	case "SPDX-2.1", "SPDX-2.2":
+	case "SPDX-2.3":
+		p.Triples = without2_3Properties(p.Triples)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedVersion, version)
+	}
+
+	resolveReferences(p.Triples)
+
+	doc, err := rdfparser2v2.LoadFromGoRDFParser(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// the loader keeps the RDF forms of reference and relationship types, which
+	// the other formats write as purl and DEPENDS_ON
+	for _, pkg := range doc.Packages {
+		for _, ref := range pkg.PackageExternalReferences {
+			ref.RefType = strings.TrimPrefix(ref.RefType, referenceNamespace)
+		}
+	}
+
+	for _, r := range doc.Relationships {
+		r.Relationship = relationshipType(r.Relationship)
+	}
+
+	return fromDocument2_2(doc), nil
+}
+
+// rdfVersion returns the SPDX version of an RDF document
+func rdfVersion(triples []*gordf.Triple) string {
+	for _, t := range triples {
+		if t.Predicate.ID == spdxNamespace+"specVersion" {
+			return strings.TrimSpace(t.Object.ID)
+		}
+	}
+
+	return ""
+}
+
+func without2_3Properties(triples []*gordf.Triple) []*gordf.Triple {
+	kept := make([]*gordf.Triple, 0, len(triples))
+	for _, t := range triples {
+		if !rdf2_3Properties[t.Predicate.ID] {
+			kept = append(kept, t)
+		}
+	}
+
+	return kept
+}
+
+// resolveReferences makes each reference to an element by rdf:resource the
+// same node as the element.  gordf keeps references as nodes of their own, so
+// the loader would otherwise skip elements, such as packages, that are only
+// related to by reference.
+func resolveReferences(triples []*gordf.Triple) {
+	elements := make(map[string]bool)
+	for _, t := range triples {
+		if t.Subject.NodeType == gordf.IRI {
+			elements[t.Subject.ID] = true
+		}
+	}
+
+	for _, t := range triples {
+		if t.Object.NodeType == gordf.RESOURCELITERAL && elements[t.Object.ID] {
+			t.Object.NodeType = gordf.IRI
+		}
+	}
+}
+
+// relationshipType returns the tag-value form of an RDF relationship type
+func relationshipType(rdfType string) string {
+	var b strings.Builder
+	for i, r := range rdfType {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteRune('_')
+		}
+
+		b.WriteRune(unicode.ToUpper(r))
+	}
+
+	return b.String()
+}
@@ -13,48 +13,104 @@ import (
 )
 
 type packageInfo struct {
+	ID               string
 	Name             string
 	Version          string
 	DownloadLocation string
 	Description      string
 	Organization     string
+	HasSupplier      bool
+	ExternalRefs     []externalRef
 }
 
 // packageInfoFromPackage takes either an spdx.Package2_1 or spdx.Package2_2 and returns a packageInfo object.
 // This is used to convert SPDX packages to version-agnostic representations of the data we need.
 func packageInfoFromPackage(spdxPackage interface{}) packageInfo {
-	var name, version, downloadLocation, description, organization string
+	var id, name, version, downloadLocation, description, organization string
+	var hasSupplier bool
+	var refs []externalRef
 
 	switch spdxPackage.(type) {
 	case spdx.Package2_1:
 		packageTyped := spdxPackage.(spdx.Package2_1)
+		id = spdx.RenderElementID(packageTyped.PackageSPDXIdentifier)
 		name = packageTyped.PackageName
 		version = packageTyped.PackageVersion
 		downloadLocation = packageTyped.PackageDownloadLocation
 		description = packageTyped.PackageDescription
 		organization = packageTyped.PackageSupplierOrganization
+		hasSupplier = organization != "" || packageTyped.PackageSupplierPerson != ""
+		for _, ref := range packageTyped.PackageExternalReferences {
+			refs = append(refs, externalRef{Category: ref.Category, Type: ref.RefType, Locator: ref.Locator})
+		}
 	case spdx.Package2_2:
 		packageTyped := spdxPackage.(spdx.Package2_2)
+		id = spdx.RenderElementID(packageTyped.PackageSPDXIdentifier)
 		name = packageTyped.PackageName
 		version = packageTyped.PackageVersion
 		downloadLocation = packageTyped.PackageDownloadLocation
 		description = packageTyped.PackageDescription
 		organization = packageTyped.PackageSupplierOrganization
+		hasSupplier = organization != "" || packageTyped.PackageSupplierPerson != ""
+		for _, ref := range packageTyped.PackageExternalReferences {
+			refs = append(refs, externalRef{Category: ref.Category, Type: ref.RefType, Locator: ref.Locator})
+		}
 	}
 
 	return packageInfo{
+		ID:               id,
 		Name:             name,
 		Version:          version,
 		DownloadLocation: downloadLocation,
 		Description:      description,
 		Organization:     organization,
+		HasSupplier:      hasSupplier,
+		ExternalRefs:     refs,
 	}
 }
 
-// ProjectsFromSPDX parses packages from an SPDX Document (v2.1 or v2.2) into Projects.
-// The given document must be of the type *spdx.Document2_1 or *spdx.Document2_2.
-// A package in the document must have a valid, resolveable PackageDownloadLocation in order to create a project
+// ProjectsFromSPDX parses packages from an SPDX Document (v2.1, v2.2, or v2.3) into Projects.
+// The given document must be of the type *spdx.Document2_1 or *spdx.Document2_2, which also holds v2.3 documents.
+// A package in the document must have a valid, resolveable PackageDownloadLocation in order to create a project.
+// Aliases are taken from the package's purl and CPE external references, or its name, supplier, and version.
+// ValidateSPDX reports the packages that will not import cleanly.
 func ProjectsFromSPDX(doc interface{}, includeDependencies bool) ([]projects.Project, error) {
+	packageInfos, err := packageInfosFromDocument(doc, includeDependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	projs := []projects.Project{}
+	for ii := range packageInfos {
+		pkg := packageInfos[ii]
+		ptype, source, branch, _ := parseDownloadLocation(pkg.DownloadLocation)
+
+		tmpID := uuid.New().String()
+
+		proj := projects.Project{
+			ID:          &tmpID,
+			Branch:      &branch,
+			Description: &pkg.Description,
+			Type:        &ptype,
+			Source:      &source,
+			Name:        &pkg.Name,
+			Active:      true,
+			Monitor:     true,
+		}
+
+		proj.Aliases = aliasesFromPackage(pkg)
+
+		projs = append(projs, proj)
+
+	}
+
+	return projs, nil
+}
+
+// packageInfosFromDocument returns the packages of an SPDX document that are
+// imported as projects, in order of their identifiers.  These are the packages
+// the document describes, or all of its packages if dependencies are included.
+func packageInfosFromDocument(doc interface{}, includeDependencies bool) ([]packageInfo, error) {
 	// use a SPDX-version-agnostic container for tracking package info
 	packageInfos := []packageInfo{}
 
@@ -113,79 +169,62 @@ func ProjectsFromSPDX(doc interface{}, includeDependencies bool) ([]projects.Pro
 		return nil, fmt.Errorf("wrong document type given, need *spdx.Document2_1 or *spdx.Document2_2")
 	}
 
-	projs := []projects.Project{}
-	for ii := range packageInfos {
-		pkg := packageInfos[ii]
-		// info we need to parse out of the SBOM
-		var ptype, source, branch string
+	return packageInfos, nil
+}
 
-		tmpID := uuid.New().String()
+// parseDownloadLocation returns the project type, source, and branch for a
+// package's download location.  If a git location names a commit rather than
+// a branch, the commit is returned and the branch is HEAD.
+func parseDownloadLocation(location string) (ptype, source, branch, commit string) {
+	if unresolvableLocation(location) {
+		return "source_unavailable", "", "", ""
+	}
 
-		if pkg.DownloadLocation == "" || pkg.DownloadLocation == "NOASSERTION" || pkg.DownloadLocation == "NONE" {
-			ptype = "source_unavailable"
-		} else if strings.Contains(pkg.DownloadLocation, "git") {
-			ptype = "git"
-
-			// SPDX spec says that git URLs can look like "git+https://github.com/..."
-			// we need to strip off the "git+"
-			if strings.Index(pkg.DownloadLocation, "git+") == 0 {
-				source = pkg.DownloadLocation[4:]
-			} else {
-				source = pkg.DownloadLocation
-			}
+	if !strings.Contains(location, "git") {
+		return "artifact", location, "", ""
+	}
 
-			// try to figure out which branch to monitor. The branch name will be after the last '@'.
-			var foundBranchName bool
-			branchDelimiterIndex := strings.LastIndex(source, "@")
-			if branchDelimiterIndex != -1 {
-				// if there is a ':' after the last '@', we know we do not have a branch name,
-				// because branch names cannot contain colons. A git URL with an '@' that does not denote a branch will
-				// always also contain a colon.
-				possibleBranch := source[branchDelimiterIndex+1:]
-				// this thing could be a branch name, or it could be a commit hash.
-				// To determine which it is, check if it looks like a commit hash (exactly 40 lower-case hex characters)
-				commitHashRegex := regexp.MustCompile("[a-f0-9]{40}")
-				if !strings.Contains(possibleBranch, ":") && !commitHashRegex.MatchString(possibleBranch) {
-					branch = possibleBranch
-					foundBranchName = true
-					// now we need to remove the branch name from the source
-					source = source[0:branchDelimiterIndex]
-				}
-			}
-			if !foundBranchName {
-				// use the remote's default branch
-				branch = "HEAD"
-			}
-		} else {
-			source = pkg.DownloadLocation
-			ptype = "artifact"
-		}
+	source, branch, commit = projects.GitSource(location)
 
-		proj := projects.Project{
-			ID:          &tmpID,
-			Branch:      &branch,
-			Description: &pkg.Description,
-			Type:        &ptype,
-			Source:      &source,
-			Name:        &pkg.Name,
-			Active:      true,
-			Monitor:     true,
-		}
+	return "git", source, branch, commit
+}
+
+// unresolvableLocation returns whether a download location gives no source
+func unresolvableLocation(location string) bool {
+	return location == "" || location == "NOASSERTION" || location == "NONE"
+}
+
+// aliasesFromPackage returns the distinct aliases identified by the package's
+// purl and CPE external references, falling back to its own name, org, and
+// version when it has neither
+func aliasesFromPackage(pkg packageInfo) []aliases.Alias {
+	var as []aliases.Alias
+
+	for _, ref := range pkg.ExternalRefs {
+		var a aliases.Alias
+		var ok bool
 
-		// check if version, org, or name are not empty strings
-		if len(pkg.Version) > 0 || len(pkg.Organization) > 0 || len(pkg.Name) > 0 {
-			proj.Aliases = []aliases.Alias{{
-				Name:    pkg.Name,
-				Org:     pkg.Organization,
-				Version: pkg.Version,
-			}}
+		switch ref.Type {
+		case "purl":
+			a, ok = aliases.FromPURL(ref.Locator)
+		case "cpe23Type", "cpe22Type":
+			a, ok = aliases.FromCPE(ref.Locator)
 		}
 
-		projs = append(projs, proj)
+		if ok {
+			as = aliases.AppendDistinct(as, a)
+		}
+	}
 
+	if len(as) == 0 {
+		as = aliases.AppendDistinct(as, aliases.Alias{
+			Name:    pkg.Name,
+			Org:     pkg.Organization,
+			Version: pkg.Version,
+		})
 	}
 
-	return projs, nil
+	return as
 }
 
 // Helper function to parse email from SPDX Creator info
@@ -201,113 +240,3 @@ func parseCreatorEmail(creatorPersons []string) string {
 	}
 	return ""
 }
-
-// Pretty print functions for SPDX file license/package information.
-
-func spdxV2_2(doc *spdx.Document2_2) {
-	// print the struct containing the SPDX file's Creation Info section data
-	fmt.Printf("==============\n")
-	fmt.Printf("Creation info:\n")
-	fmt.Printf("==============\n")
-	fmt.Printf("%#v\n\n", doc.CreationInfo)
-	pkgIDs, err := spdxlib.GetDescribedPackageIDs2_2(doc)
-	if err != nil {
-		fmt.Printf("Unable to get describe packages from SPDX document: %v\n", err)
-		return
-	}
-
-	// SPDX Document does contain packages, so we'll go through each one
-	for _, pkgID := range pkgIDs {
-		pkg, ok := doc.Packages[pkgID]
-		if !ok {
-			fmt.Printf("Package %s has described relationship but ID not found\n", string(pkgID))
-			continue
-		}
-
-		// check whether the package had its files analyzed
-		if !pkg.FilesAnalyzed {
-			fmt.Printf("Package %s (%s) had FilesAnalyzed: false\n", string(pkgID), pkg.PackageName)
-			continue
-		}
-
-		// also check whether the package has any files present
-		if pkg.Files == nil || len(pkg.Files) < 1 {
-			fmt.Printf("Package %s (%s) has no Files\n", string(pkgID), pkg.PackageName)
-			continue
-		}
-
-		// if we got here, there's at least one file
-		// print the filename and license info for the first 50
-		fmt.Printf("============================\n")
-		fmt.Printf("Package %s (%s)\n", string(pkgID), pkg.PackageName)
-		fmt.Printf("File info (up to first 50):\n")
-		i := 1
-		for _, f := range pkg.Files {
-			// note that these will be in random order, since we're pulling
-			// from a map. if we care about order, we should first pull the
-			// IDs into a slice, sort it, and then print the ordered files.
-			fmt.Printf("- File %d: %s\n", i, f.FileName)
-			fmt.Printf("    License from file: %v\n", f.LicenseInfoInFile)
-			fmt.Printf("    License concluded: %v\n", f.LicenseConcluded)
-			i++
-			if i > 50 {
-				break
-			}
-		}
-	}
-}
-
-func spdxV2_1(doc *spdx.Document2_1) {
-	// we can now take a look at its contents via the various data
-	// structures representing the SPDX document's sections.
-
-	// print the struct containing the SPDX file's Creation Info section data
-	fmt.Printf("==============\n")
-	fmt.Printf("Creation info:\n")
-	fmt.Printf("==============\n")
-	fmt.Printf("%#v\n\n", doc.CreationInfo)
-	pkgIDs, err := spdxlib.GetDescribedPackageIDs2_1(doc)
-	if err != nil {
-		fmt.Printf("Unable to get describe packages from SPDX document: %v\n", err)
-		return
-	}
-	// SPDX Document does contain packages, so we'll go through each one
-	for _, pkgID := range pkgIDs {
-		pkg, ok := doc.Packages[pkgID]
-		if !ok {
-			fmt.Printf("Package %s has described relationship but ID not found\n", string(pkgID))
-			continue
-		}
-
-		// check whether the package had its files analyzed
-		if !pkg.FilesAnalyzed {
-			fmt.Printf("Package %s (%s) had FilesAnalyzed: false\n", string(pkgID), pkg.PackageName)
-			continue
-		}
-
-		// also check whether the package has any files present
-		if pkg.Files == nil || len(pkg.Files) < 1 {
-			fmt.Printf("Package %s (%s) has no Files\n", string(pkgID), pkg.PackageName)
-			continue
-		}
-
-		// if we got here, there's at least one file
-		// print the filename and license info for the first 50
-		fmt.Printf("============================\n")
-		fmt.Printf("Package %s (%s)\n", string(pkgID), pkg.PackageName)
-		fmt.Printf("File info (up to first 50):\n")
-		i := 1
-		for _, f := range pkg.Files {
-			// note that these will be in random order, since we're pulling
-			// from a map. if we care about order, we should first pull the
-			// IDs into a slice, sort it, and then print the ordered files.
-			fmt.Printf("- File %d: %s\n", i, f.FileName)
-			fmt.Printf("    License from file: %v\n", f.LicenseInfoInFile)
-			fmt.Printf("    License concluded: %v\n", f.LicenseConcluded)
-			i++
-			if i > 50 {
-				break
-			}
-		}
-	}
-}
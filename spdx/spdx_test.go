@@ -265,6 +265,150 @@ func TestSPDX(t *testing.T) {
 		})
 	})
 
+	g.Describe("Validating SPDX documents", func() {
+		g.It("should report the problems of each package", func() {
+			packages := make(map[spdx.ElementID]*spdx.Package2_2)
+			for _, pkg := range []spdx.Package2_2{{
+				PackageName:                 "good-pkg",
+				PackageSPDXIdentifier:       "a-good-pkg",
+				PackageVersion:              "1.0.0",
+				PackageSupplierOrganization: "The Org",
+				PackageDownloadLocation:     "git+https://github.com/some-org/good-pkg.git@main",
+			}, {
+				PackageName:             "no-source",
+				PackageSPDXIdentifier:   "b-no-source",
+				PackageDownloadLocation: "NOASSERTION",
+			}, {
+				PackageName:             "pinned",
+				PackageSPDXIdentifier:   "c-pinned",
+				PackageVersion:          "2.0.0",
+				PackageSupplierPerson:   "Some Person",
+				PackageDownloadLocation: "git+https://github.com/some-org/pinned.git@0123456789abcdef0123456789abcdef01234567",
+			}, {
+				PackageName:                 "Good-Pkg",
+				PackageSPDXIdentifier:       "d-duplicate",
+				PackageVersion:              "1.0.0",
+				PackageSupplierOrganization: "The Org",
+				PackageDownloadLocation:     "https://example.com/good-pkg-1.0.0.tgz",
+			}} {
+				pkg := pkg
+				packages[pkg.PackageSPDXIdentifier] = &pkg
+			}
+
+			doc := spdx.Document2_2{
+				CreationInfo: &spdx.CreationInfo2_2{DocumentName: "SPDX SBOM"},
+				Packages:     packages,
+			}
+
+			report, err := ValidateSPDX(&doc, true)
+			Expect(err).To(Equal(ErrInvalidPackages))
+			Expect(report).To(HaveLen(3))
+			Expect(report).NotTo(HaveKey("SPDXRef-a-good-pkg"))
+			Expect(report["SPDXRef-b-no-source"]).To(HaveKey("source"))
+			Expect(report["SPDXRef-b-no-source"]).To(HaveKey("supplier"))
+			Expect(report["SPDXRef-b-no-source"]).To(HaveKey("version"))
+			Expect(report["SPDXRef-c-pinned"]).To(Equal(map[string]string{
+				"branch": "download location pins commit 0123456789abcdef0123456789abcdef01234567 rather than a branch, HEAD will be used",
+			}))
+			Expect(report["SPDXRef-d-duplicate"]).To(Equal(map[string]string{
+				"duplicate": "duplicate of package SPDXRef-a-good-pkg",
+			}))
+
+			delete(packages, "b-no-source")
+			delete(packages, "c-pinned")
+			delete(packages, "d-duplicate")
+
+			report, err = ValidateSPDX(&doc, true)
+			Expect(err).To(BeNil())
+			Expect(report).To(BeEmpty())
+
+			_, err = ValidateSPDX(doc, true)
+			Expect(err).NotTo(BeNil())
+			Expect(err).NotTo(Equal(ErrInvalidPackages))
+		})
+	})
+
+	g.Describe("Summarizing SPDX documents", func() {
+		g.It("should summarize both versions the same", func() {
+			documentRef := spdx.MakeDocElementID("", "DOCUMENT")
+			pkgRef := spdx.MakeDocElementID("", "some-cool-pkg")
+
+			doc21 := spdx.Document2_1{
+				CreationInfo: &spdx.CreationInfo2_1{
+					SPDXVersion:  "SPDX-2.1",
+					DocumentName: "SPDX SBOM",
+					CreatorTools: []string{"ionic"},
+				},
+				Packages: map[spdx.ElementID]*spdx.Package2_1{
+					pkgRef.ElementRefID: {
+						PackageName:             "some-cool-pkg",
+						PackageSPDXIdentifier:   pkgRef.ElementRefID,
+						PackageVersion:          "1.2.3",
+						FilesAnalyzed:           true,
+						PackageLicenseConcluded: "(MIT OR Apache-2.0)",
+						PackageLicenseDeclared:  "Apache-2.0 OR mit",
+						Files: map[spdx.ElementID]*spdx.File2_1{
+							"File-b": {FileName: "./b.go", FileSPDXIdentifier: "File-b", LicenseConcluded: "GPL-2.0-only", LicenseInfoInFile: []string{"GPL-2.0-only"}},
+							"File-a": {FileName: "./a.go", FileSPDXIdentifier: "File-a", LicenseConcluded: "MIT", LicenseInfoInFile: []string{"MIT"}},
+						},
+					},
+				},
+				Relationships: []*spdx.Relationship2_1{{RefA: documentRef, Relationship: "DESCRIBES", RefB: pkgRef}},
+			}
+
+			doc22 := spdx.Document2_2{
+				CreationInfo: &spdx.CreationInfo2_2{
+					SPDXVersion:  "SPDX-2.1",
+					DocumentName: "SPDX SBOM",
+					CreatorTools: []string{"ionic"},
+				},
+				Packages: map[spdx.ElementID]*spdx.Package2_2{
+					pkgRef.ElementRefID: {
+						PackageName:             "some-cool-pkg",
+						PackageSPDXIdentifier:   pkgRef.ElementRefID,
+						PackageVersion:          "1.2.3",
+						FilesAnalyzed:           true,
+						PackageLicenseConcluded: "(MIT OR Apache-2.0)",
+						PackageLicenseDeclared:  "Apache-2.0 OR mit",
+						Files: map[spdx.ElementID]*spdx.File2_2{
+							"File-b": {FileName: "./b.go", FileSPDXIdentifier: "File-b", LicenseConcluded: "GPL-2.0-only", LicenseInfoInFile: []string{"GPL-2.0-only"}},
+							"File-a": {FileName: "./a.go", FileSPDXIdentifier: "File-a", LicenseConcluded: "MIT", LicenseInfoInFile: []string{"MIT"}},
+						},
+					},
+				},
+				Relationships: []*spdx.Relationship2_2{{RefA: documentRef, Relationship: "DESCRIBES", RefB: pkgRef}},
+			}
+
+			s21, err := Summarize(&doc21)
+			Expect(err).To(BeNil())
+			s22, err := Summarize(&doc22)
+			Expect(err).To(BeNil())
+			Expect(s21).To(Equal(s22))
+
+			Expect(s21.CreatorTools).To(Equal([]string{"ionic"}))
+			Expect(s21.Packages).To(HaveLen(1))
+
+			pkg := s21.Packages[0]
+			Expect(pkg.ID).To(Equal("SPDXRef-some-cool-pkg"))
+			Expect(pkg.FileCount).To(Equal(2))
+			Expect(pkg.Files[0].Name).To(Equal("./a.go"))
+			Expect(pkg.Conflicts).To(Equal([]LicenseConflict{{
+				ID:        "SPDXRef-File-b",
+				Concluded: "GPL-2.0-only",
+				Expected:  "(MIT OR Apache-2.0)",
+			}}))
+
+			doc22.Packages[pkgRef.ElementRefID].PackageLicenseDeclared = "MIT"
+			s22, err = Summarize(&doc22)
+			Expect(err).To(BeNil())
+			Expect(s22.Packages[0].Conflicts).To(HaveLen(2))
+			Expect(s22.Packages[0].Conflicts[0].ID).To(Equal("SPDXRef-some-cool-pkg"))
+
+			_, err = Summarize(doc22)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
 	g.Describe("parse emails from SPDX creator information", func() {
 		g.It("should return an email if present", func() {
 			creatorInfo := "My Name (myemail@mail.net)"
@@ -0,0 +1,252 @@
+package spdx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdxlib"
+)
+
+// Summary is an overview of an SPDX document's creation info and the licenses
+// of the packages it describes
+type Summary struct {
+	SPDXVersion          string           `json:"spdx_version"`
+	DataLicense          string           `json:"data_license"`
+	DocumentName         string           `json:"document_name"`
+	DocumentNamespace    string           `json:"document_namespace"`
+	Created              string           `json:"created"`
+	CreatorPersons       []string         `json:"creator_persons,omitempty"`
+	CreatorOrganizations []string         `json:"creator_organizations,omitempty"`
+	CreatorTools         []string         `json:"creator_tools,omitempty"`
+	Packages             []PackageSummary `json:"packages"`
+}
+
+// PackageSummary is an overview of the licenses of a package and its files
+type PackageSummary struct {
+	ID                   string            `json:"id"`
+	Name                 string            `json:"name"`
+	Version              string            `json:"version,omitempty"`
+	FilesAnalyzed        bool              `json:"files_analyzed"`
+	FileCount            int               `json:"file_count"`
+	LicenseConcluded     string            `json:"license_concluded"`
+	LicenseDeclared      string            `json:"license_declared"`
+	LicenseInfoFromFiles []string          `json:"license_info_from_files,omitempty"`
+	Files                []FileSummary     `json:"files,omitempty"`
+	Conflicts            []LicenseConflict `json:"conflicts,omitempty"`
+}
+
+// FileSummary is an overview of the licenses of a file
+type FileSummary struct {
+	ID                string   `json:"id"`
+	Name              string   `json:"name"`
+	LicenseConcluded  string   `json:"license_concluded"`
+	LicenseInfoInFile []string `json:"license_info_in_file,omitempty"`
+}
+
+// LicenseConflict is a concluded license of a package or file that names
+// licenses the license it is expected to agree with does not.  A package's
+// concluded license is expected to agree with its declared license, and a
+// file's with its package's concluded license.
+type LicenseConflict struct {
+	ID        string `json:"id"`
+	Concluded string `json:"concluded"`
+	Expected  string `json:"expected"`
+}
+
+// Summarize returns a summary of an SPDX document (v2.1, v2.2, or v2.3) and
+// the packages it describes.  The given document must be of the type
+// *spdx.Document2_1 or *spdx.Document2_2, which also holds v2.3 documents.
+// Packages are in the order of their identifiers, and files in the order of
+// their names.
+func Summarize(doc interface{}) (*Summary, error) {
+	summary := &Summary{}
+
+	switch doc.(type) {
+	case *spdx.Document2_1:
+		docTyped := doc.(*spdx.Document2_1)
+		if ci := docTyped.CreationInfo; ci != nil {
+			summary.SPDXVersion = ci.SPDXVersion
+			summary.DataLicense = ci.DataLicense
+			summary.DocumentName = ci.DocumentName
+			summary.DocumentNamespace = ci.DocumentNamespace
+			summary.Created = ci.Created
+			summary.CreatorPersons = ci.CreatorPersons
+			summary.CreatorOrganizations = ci.CreatorOrganizations
+			summary.CreatorTools = ci.CreatorTools
+		}
+
+		pkgIDs, err := spdxlib.GetDescribedPackageIDs2_1(docTyped)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve described packages from SPDX 2.1 document: %s", err.Error())
+		}
+
+		for _, pkgID := range pkgIDs {
+			if pkg := docTyped.Packages[pkgID]; pkg != nil {
+				ps := PackageSummary{
+					ID:                   spdx.RenderElementID(pkg.PackageSPDXIdentifier),
+					Name:                 pkg.PackageName,
+					Version:              pkg.PackageVersion,
+					FilesAnalyzed:        pkg.FilesAnalyzed,
+					LicenseConcluded:     pkg.PackageLicenseConcluded,
+					LicenseDeclared:      pkg.PackageLicenseDeclared,
+					LicenseInfoFromFiles: pkg.PackageLicenseInfoFromFiles,
+				}
+
+				for _, f := range pkg.Files {
+					ps.Files = append(ps.Files, FileSummary{
+						ID:                spdx.RenderElementID(f.FileSPDXIdentifier),
+						Name:              f.FileName,
+						LicenseConcluded:  f.LicenseConcluded,
+						LicenseInfoInFile: f.LicenseInfoInFile,
+					})
+				}
+
+				summary.Packages = append(summary.Packages, finishPackageSummary(ps))
+			}
+		}
+	case *spdx.Document2_2:
+		docTyped := doc.(*spdx.Document2_2)
+		if ci := docTyped.CreationInfo; ci != nil {
+			summary.SPDXVersion = ci.SPDXVersion
+			summary.DataLicense = ci.DataLicense
+			summary.DocumentName = ci.DocumentName
+			summary.DocumentNamespace = ci.DocumentNamespace
+			summary.Created = ci.Created
+			summary.CreatorPersons = ci.CreatorPersons
+			summary.CreatorOrganizations = ci.CreatorOrganizations
+			summary.CreatorTools = ci.CreatorTools
+		}
+
+		pkgIDs, err := spdxlib.GetDescribedPackageIDs2_2(docTyped)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve described packages from SPDX 2.2 document: %s", err.Error())
+		}
+
+		for _, pkgID := range pkgIDs {
+			if pkg := docTyped.Packages[pkgID]; pkg != nil {
+				ps := PackageSummary{
+					ID:                   spdx.RenderElementID(pkg.PackageSPDXIdentifier),
+					Name:                 pkg.PackageName,
+					Version:              pkg.PackageVersion,
+					FilesAnalyzed:        pkg.FilesAnalyzed,
+					LicenseConcluded:     pkg.PackageLicenseConcluded,
+					LicenseDeclared:      pkg.PackageLicenseDeclared,
+					LicenseInfoFromFiles: pkg.PackageLicenseInfoFromFiles,
+				}
+
+				for _, f := range pkg.Files {
+					ps.Files = append(ps.Files, FileSummary{
+						ID:                spdx.RenderElementID(f.FileSPDXIdentifier),
+						Name:              f.FileName,
+						LicenseConcluded:  f.LicenseConcluded,
+						LicenseInfoInFile: f.LicenseInfoInFile,
+					})
+				}
+
+				summary.Packages = append(summary.Packages, finishPackageSummary(ps))
+			}
+		}
+	default:
+		return nil, fmt.Errorf("wrong document type given, need *spdx.Document2_1 or *spdx.Document2_2")
+	}
+
+	return summary, nil
+}
+
+// finishPackageSummary sorts a package summary's files, counts them, and finds
+// its license conflicts
+func finishPackageSummary(ps PackageSummary) PackageSummary {
+	sort.Slice(ps.Files, func(i, j int) bool {
+		if ps.Files[i].Name != ps.Files[j].Name {
+			return ps.Files[i].Name < ps.Files[j].Name
+		}
+
+		return ps.Files[i].ID < ps.Files[j].ID
+	})
+
+	ps.FileCount = len(ps.Files)
+
+	concluded := licenseIDs(ps.LicenseConcluded)
+	if declared := licenseIDs(ps.LicenseDeclared); concluded != nil && declared != nil && !sameLicenses(concluded, declared) {
+		ps.Conflicts = append(ps.Conflicts, LicenseConflict{
+			ID:        ps.ID,
+			Concluded: ps.LicenseConcluded,
+			Expected:  ps.LicenseDeclared,
+		})
+	}
+
+	if concluded == nil {
+		return ps
+	}
+
+	for _, f := range ps.Files {
+		for _, id := range licenseIDs(f.LicenseConcluded) {
+			if !containsLicense(concluded, id) {
+				ps.Conflicts = append(ps.Conflicts, LicenseConflict{
+					ID:        f.ID,
+					Concluded: f.LicenseConcluded,
+					Expected:  ps.LicenseConcluded,
+				})
+
+				break
+			}
+		}
+	}
+
+	return ps
+}
+
+// licenseIDs returns the sorted, distinct licenses named in a license
+// expression, or nil if the expression makes no assertion
+func licenseIDs(expression string) []string {
+	if expression == "" || expression == "NOASSERTION" {
+		return nil
+	}
+
+	expression = strings.NewReplacer("(", " ", ")", " ").Replace(expression)
+
+	ids := []string{}
+	for _, token := range strings.Fields(expression) {
+		switch strings.ToUpper(token) {
+		case "AND", "OR", "WITH":
+			continue
+		}
+
+		if !containsLicense(ids, token) {
+			ids = append(ids, token)
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// containsLicense returns whether a license is in a list, ignoring case as
+// SPDX license identifiers do
+func containsLicense(ids []string, id string) bool {
+	for _, other := range ids {
+		if strings.EqualFold(other, id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameLicenses returns whether two lists name the same licenses
+func sameLicenses(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, id := range a {
+		if !containsLicense(b, id) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,71 @@
+package spdx
+
+import (
+	"bytes"
+	"fmt"
+
+	tvparser2v1 "github.com/spdx/tools-golang/tvloader/parser2v1"
+	tvparser2v2 "github.com/spdx/tools-golang/tvloader/parser2v2"
+	"github.com/spdx/tools-golang/tvloader/reader"
+)
+
+// tagValue2_3Tags are the tags SPDX 2.3 adds to tag-value documents.  They are
+// dropped so the rest of a 2.3 document can be read as 2.2, which it only adds
+// to.
+var tagValue2_3Tags = map[string]bool{
+	"PrimaryPackagePurpose": true,
+	"ReleaseDate":           true,
+	"BuiltDate":             true,
+	"ValidUntilDate":        true,
+}
+
+// parseTagValue reads an SPDX tag-value document with the tools-golang parser
+// for its version
+func parseTagValue(b []byte) (*document, error) {
+	pairs, err := reader.ReadTagValues(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	version := ""
+	for _, p := range pairs {
+		if p.Tag == "SPDXVersion" {
+			version = p.Value
+			break
+		}
+	}
+
+	switch version {
+	case "SPDX-2.1":
+		doc, err := tvparser2v1.ParseTagValues(pairs)
+		if err != nil {
+			return nil, err
+		}
+
+		return fromDocument2_1(doc), nil
+	case "SPDX-2.2", "SPDX-2.3":
+		if version == "SPDX-2.3" {
+			pairs = without2_3Tags(pairs)
+		}
+
+		doc, err := tvparser2v2.ParseTagValues(pairs)
+		if err != nil {
+			return nil, err
+		}
+
+		return fromDocument2_2(doc), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedVersion, version)
+	}
+}
+
+func without2_3Tags(pairs []reader.TagValuePair) []reader.TagValuePair {
+	kept := make([]reader.TagValuePair, 0, len(pairs))
+	for _, p := range pairs {
+		if !tagValue2_3Tags[p.Tag] {
+			kept = append(kept, p)
+		}
+	}
+
+	return kept
+}
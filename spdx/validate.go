@@ -0,0 +1,80 @@
+package spdx
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var (
+	// ErrInvalidPackages is returned when packages of an SPDX document will
+	// not import cleanly as projects
+	ErrInvalidPackages = fmt.Errorf("document has invalid packages")
+)
+
+// ValidateSPDX checks the packages of an SPDX document that ProjectsFromSPDX
+// would import, and returns the problems found with each one keyed by its SPDX
+// identifier.  The problems of a package are keyed by field, the same as
+// projects.Project.Validate's.  A package is reported if it has no resolvable
+// source, pins a git commit rather than a branch, duplicates an earlier
+// package, or has no supplier or version.  ErrInvalidPackages is returned if
+// any package has problems.
+func ValidateSPDX(doc interface{}, includeDependencies bool) (map[string]map[string]string, error) {
+	packageInfos, err := packageInfosFromDocument(doc, includeDependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make(map[string]map[string]string)
+	seen := make(map[string]string)
+
+	for _, pkg := range packageInfos {
+		invalidFields := make(map[string]string)
+
+		if pkg.Name == "" {
+			invalidFields["name"] = "missing name"
+		}
+
+		ptype, source, _, commit := parseDownloadLocation(pkg.DownloadLocation)
+		switch ptype {
+		case "source_unavailable":
+			invalidFields["source"] = fmt.Sprintf("download location %q cannot be resolved to a source", pkg.DownloadLocation)
+		case "artifact":
+			u, err := url.Parse(source)
+			if err != nil || u.Scheme == "" || u.Host == "" {
+				invalidFields["source"] = "source must be a valid url"
+			}
+		}
+
+		if commit != "" {
+			invalidFields["branch"] = fmt.Sprintf("download location pins commit %v rather than a branch, HEAD will be used", commit)
+		}
+
+		if !pkg.HasSupplier {
+			invalidFields["supplier"] = "missing supplier"
+		}
+
+		if pkg.Version == "" {
+			invalidFields["version"] = "missing version"
+		}
+
+		if pkg.Name != "" {
+			key := strings.ToLower(pkg.Name) + "@" + strings.ToLower(pkg.Version)
+			if first, ok := seen[key]; ok {
+				invalidFields["duplicate"] = fmt.Sprintf("duplicate of package %v", first)
+			} else {
+				seen[key] = pkg.ID
+			}
+		}
+
+		if len(invalidFields) > 0 {
+			report[pkg.ID] = invalidFields
+		}
+	}
+
+	if len(report) > 0 {
+		return report, ErrInvalidPackages
+	}
+
+	return report, nil
+}
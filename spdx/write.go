@@ -0,0 +1,419 @@
+package spdx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spdx/tools-golang/spdx"
+)
+
+// WriteJSON writes an SPDX document as indented SPDX JSON.  The given document
+// must be of the type *spdx.Document2_1 or *spdx.Document2_2, and is written in
+// the SPDX version given in its creation info.  Packages and files are written
+// in order of their identifiers so the output is stable.
+func WriteJSON(w io.Writer, doc interface{}) error {
+	d, err := fromDocument(doc)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	err = enc.Encode(d)
+	if err != nil {
+		return fmt.Errorf("failed to write SPDX JSON document: %w", err)
+	}
+
+	return nil
+}
+
+// fromDocument converts a version specific document into a document
+func fromDocument(doc interface{}) (*document, error) {
+	switch d := doc.(type) {
+	case *spdx.Document2_1:
+		if d == nil || d.CreationInfo == nil {
+			return nil, fmt.Errorf("SPDX document has no creation info")
+		}
+
+		return fromDocument2_1(d), nil
+	case *spdx.Document2_2:
+		if d == nil || d.CreationInfo == nil {
+			return nil, fmt.Errorf("SPDX document has no creation info")
+		}
+
+		return fromDocument2_2(d), nil
+	default:
+		return nil, fmt.Errorf("wrong document type given, need *spdx.Document2_1 or *spdx.Document2_2")
+	}
+}
+
+// creatorsOf joins people, organizations, and tools into the creators of a
+// document
+func creatorsOf(persons, organizations, tools []string) []string {
+	creators := []string{}
+	for _, p := range persons {
+		creators = append(creators, "Person: "+p)
+	}
+
+	for _, o := range organizations {
+		creators = append(creators, "Organization: "+o)
+	}
+
+	for _, t := range tools {
+		creators = append(creators, "Tool: "+t)
+	}
+
+	return creators
+}
+
+// actorOf joins a person or organization, such as a supplier, into an actor
+func actorOf(person, organization string, noAssertion bool) string {
+	switch {
+	case noAssertion:
+		return "NOASSERTION"
+	case person != "":
+		return "Person: " + person
+	case organization != "":
+		return "Organization: " + organization
+	default:
+		return ""
+	}
+}
+
+// verificationCodeOf returns a package's verification code, if it has one
+func verificationCodeOf(code, excluded string) *verificationCode {
+	if code == "" {
+		return nil
+	}
+
+	v := &verificationCode{Value: code}
+	if excluded != "" {
+		v.ExcludedFiles = []string{excluded}
+	}
+
+	return v
+}
+
+// filesAnalyzedOf returns whether a package's files were analyzed, leaving it
+// out when the tag was not present and the default applies
+func filesAnalyzedOf(analyzed, present bool) *bool {
+	if !present && analyzed {
+		return nil
+	}
+
+	return &analyzed
+}
+
+func sortedChecksums(checksums map[spdx.ChecksumAlgorithm]spdx.Checksum) []checksum {
+	cs := []checksum{}
+	for _, c := range checksums {
+		cs = append(cs, checksum{Algorithm: string(c.Algorithm), Value: c.Value})
+	}
+
+	sort.Slice(cs, func(i, j int) bool { return cs[i].Algorithm < cs[j].Algorithm })
+
+	return cs
+}
+
+// legacyChecksums returns the SHA1, SHA256, and MD5 checksums of a v2.1
+// package or file
+func legacyChecksums(sha1, sha256, md5 string) []checksum {
+	cs := []checksum{}
+	if sha1 != "" {
+		cs = append(cs, checksum{Algorithm: string(spdx.SHA1), Value: sha1})
+	}
+
+	if sha256 != "" {
+		cs = append(cs, checksum{Algorithm: string(spdx.SHA256), Value: sha256})
+	}
+
+	if md5 != "" {
+		cs = append(cs, checksum{Algorithm: string(spdx.MD5), Value: md5})
+	}
+
+	return cs
+}
+
+// documentID returns the rendered identifier of a document, which defaults to
+// SPDXRef-DOCUMENT
+func documentID(id spdx.ElementID) string {
+	if id == "" {
+		id = "DOCUMENT"
+	}
+
+	return spdx.RenderElementID(id)
+}
+
+func sortedElementIDs(ids []spdx.ElementID) []spdx.ElementID {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func fromDocument2_2(doc *spdx.Document2_2) *document {
+	ci := doc.CreationInfo
+	d := &document{
+		SPDXVersion:       ci.SPDXVersion,
+		DataLicense:       ci.DataLicense,
+		SPDXID:            documentID(ci.SPDXIdentifier),
+		Name:              ci.DocumentName,
+		DocumentNamespace: ci.DocumentNamespace,
+		CreationInfo: creationInfo{
+			Created:            ci.Created,
+			Creators:           creatorsOf(ci.CreatorPersons, ci.CreatorOrganizations, ci.CreatorTools),
+			Comment:            ci.CreatorComment,
+			LicenseListVersion: ci.LicenseListVersion,
+		},
+		Comment: ci.DocumentComment,
+	}
+
+	refIDs := []string{}
+	for id := range ci.ExternalDocumentReferences {
+		refIDs = append(refIDs, id)
+	}
+
+	sort.Strings(refIDs)
+	for _, id := range refIDs {
+		ref := ci.ExternalDocumentReferences[id]
+		d.ExternalDocumentRefs = append(d.ExternalDocumentRefs, externalDocumentRef{
+			ExternalDocumentID: "DocumentRef-" + ref.DocumentRefID,
+			SPDXDocument:       ref.URI,
+			Checksum:           checksum{Algorithm: ref.Alg, Value: ref.Checksum},
+		})
+	}
+
+	pkgIDs := []spdx.ElementID{}
+	for id := range doc.Packages {
+		pkgIDs = append(pkgIDs, id)
+	}
+
+	for _, id := range sortedElementIDs(pkgIDs) {
+		p := doc.Packages[id]
+
+		pkg := packageSection{
+			SPDXID:               spdx.RenderElementID(p.PackageSPDXIdentifier),
+			Name:                 p.PackageName,
+			VersionInfo:          p.PackageVersion,
+			PackageFileName:      p.PackageFileName,
+			Supplier:             actorOf(p.PackageSupplierPerson, p.PackageSupplierOrganization, p.PackageSupplierNOASSERTION),
+			Originator:           actorOf(p.PackageOriginatorPerson, p.PackageOriginatorOrganization, p.PackageOriginatorNOASSERTION),
+			DownloadLocation:     p.PackageDownloadLocation,
+			FilesAnalyzed:        filesAnalyzedOf(p.FilesAnalyzed, p.IsFilesAnalyzedTagPresent),
+			VerificationCode:     verificationCodeOf(p.PackageVerificationCode, p.PackageVerificationCodeExcludedFile),
+			Checksums:            sortedChecksums(p.PackageChecksums),
+			Homepage:             p.PackageHomePage,
+			SourceInfo:           p.PackageSourceInfo,
+			LicenseConcluded:     p.PackageLicenseConcluded,
+			LicenseInfoFromFiles: p.PackageLicenseInfoFromFiles,
+			LicenseDeclared:      p.PackageLicenseDeclared,
+			LicenseComments:      p.PackageLicenseComments,
+			CopyrightText:        p.PackageCopyrightText,
+			Summary:              p.PackageSummary,
+			Description:          p.PackageDescription,
+			Comment:              p.PackageComment,
+			AttributionTexts:     p.PackageAttributionTexts,
+		}
+
+		for _, ref := range p.PackageExternalReferences {
+			pkg.ExternalRefs = append(pkg.ExternalRefs, externalRef{
+				Category: ref.Category,
+				Type:     ref.RefType,
+				Locator:  ref.Locator,
+				Comment:  ref.ExternalRefComment,
+			})
+		}
+
+		fileIDs := []spdx.ElementID{}
+		for fid := range p.Files {
+			fileIDs = append(fileIDs, fid)
+		}
+
+		for _, fid := range sortedElementIDs(fileIDs) {
+			pkg.HasFiles = append(pkg.HasFiles, spdx.RenderElementID(fid))
+			d.Files = append(d.Files, fileFrom2_2(p.Files[fid]))
+		}
+
+		d.Packages = append(d.Packages, pkg)
+	}
+
+	fileIDs := []spdx.ElementID{}
+	for id := range doc.UnpackagedFiles {
+		fileIDs = append(fileIDs, id)
+	}
+
+	for _, id := range sortedElementIDs(fileIDs) {
+		d.Files = append(d.Files, fileFrom2_2(doc.UnpackagedFiles[id]))
+	}
+
+	for _, l := range doc.OtherLicenses {
+		d.ExtractedLicenses = append(d.ExtractedLicenses, extractedLicense{
+			LicenseID:     l.LicenseIdentifier,
+			ExtractedText: l.ExtractedText,
+			Name:          l.LicenseName,
+			SeeAlsos:      l.LicenseCrossReferences,
+			Comment:       l.LicenseComment,
+		})
+	}
+
+	for _, r := range doc.Relationships {
+		d.Relationships = append(d.Relationships, relationship{
+			SPDXElementID:      spdx.RenderDocElementID(r.RefA),
+			RelationshipType:   r.Relationship,
+			RelatedSPDXElement: spdx.RenderDocElementID(r.RefB),
+			Comment:            r.RelationshipComment,
+		})
+	}
+
+	return d
+}
+
+func fileFrom2_2(f *spdx.File2_2) fileSection {
+	return fileSection{
+		SPDXID:             spdx.RenderElementID(f.FileSPDXIdentifier),
+		FileName:           f.FileName,
+		FileTypes:          f.FileType,
+		Checksums:          sortedChecksums(f.FileChecksums),
+		LicenseConcluded:   f.LicenseConcluded,
+		LicenseInfoInFiles: f.LicenseInfoInFile,
+		LicenseComments:    f.LicenseComments,
+		CopyrightText:      f.FileCopyrightText,
+		Comment:            f.FileComment,
+		NoticeText:         f.FileNotice,
+		FileContributors:   f.FileContributor,
+		AttributionTexts:   f.FileAttributionTexts,
+	}
+}
+
+func fromDocument2_1(doc *spdx.Document2_1) *document {
+	ci := doc.CreationInfo
+	d := &document{
+		SPDXVersion:       ci.SPDXVersion,
+		DataLicense:       ci.DataLicense,
+		SPDXID:            documentID(ci.SPDXIdentifier),
+		Name:              ci.DocumentName,
+		DocumentNamespace: ci.DocumentNamespace,
+		CreationInfo: creationInfo{
+			Created:            ci.Created,
+			Creators:           creatorsOf(ci.CreatorPersons, ci.CreatorOrganizations, ci.CreatorTools),
+			Comment:            ci.CreatorComment,
+			LicenseListVersion: ci.LicenseListVersion,
+		},
+		Comment: ci.DocumentComment,
+	}
+
+	refIDs := []string{}
+	for id := range ci.ExternalDocumentReferences {
+		refIDs = append(refIDs, id)
+	}
+
+	sort.Strings(refIDs)
+	for _, id := range refIDs {
+		ref := ci.ExternalDocumentReferences[id]
+		d.ExternalDocumentRefs = append(d.ExternalDocumentRefs, externalDocumentRef{
+			ExternalDocumentID: "DocumentRef-" + ref.DocumentRefID,
+			SPDXDocument:       ref.URI,
+			Checksum:           checksum{Algorithm: ref.Alg, Value: ref.Checksum},
+		})
+	}
+
+	pkgIDs := []spdx.ElementID{}
+	for id := range doc.Packages {
+		pkgIDs = append(pkgIDs, id)
+	}
+
+	for _, id := range sortedElementIDs(pkgIDs) {
+		p := doc.Packages[id]
+
+		pkg := packageSection{
+			SPDXID:               spdx.RenderElementID(p.PackageSPDXIdentifier),
+			Name:                 p.PackageName,
+			VersionInfo:          p.PackageVersion,
+			PackageFileName:      p.PackageFileName,
+			Supplier:             actorOf(p.PackageSupplierPerson, p.PackageSupplierOrganization, p.PackageSupplierNOASSERTION),
+			Originator:           actorOf(p.PackageOriginatorPerson, p.PackageOriginatorOrganization, p.PackageOriginatorNOASSERTION),
+			DownloadLocation:     p.PackageDownloadLocation,
+			FilesAnalyzed:        filesAnalyzedOf(p.FilesAnalyzed, p.IsFilesAnalyzedTagPresent),
+			VerificationCode:     verificationCodeOf(p.PackageVerificationCode, p.PackageVerificationCodeExcludedFile),
+			Checksums:            legacyChecksums(p.PackageChecksumSHA1, p.PackageChecksumSHA256, p.PackageChecksumMD5),
+			Homepage:             p.PackageHomePage,
+			SourceInfo:           p.PackageSourceInfo,
+			LicenseConcluded:     p.PackageLicenseConcluded,
+			LicenseInfoFromFiles: p.PackageLicenseInfoFromFiles,
+			LicenseDeclared:      p.PackageLicenseDeclared,
+			LicenseComments:      p.PackageLicenseComments,
+			CopyrightText:        p.PackageCopyrightText,
+			Summary:              p.PackageSummary,
+			Description:          p.PackageDescription,
+			Comment:              p.PackageComment,
+		}
+
+		for _, ref := range p.PackageExternalReferences {
+			pkg.ExternalRefs = append(pkg.ExternalRefs, externalRef{
+				Category: ref.Category,
+				Type:     ref.RefType,
+				Locator:  ref.Locator,
+				Comment:  ref.ExternalRefComment,
+			})
+		}
+
+		fileIDs := []spdx.ElementID{}
+		for fid := range p.Files {
+			fileIDs = append(fileIDs, fid)
+		}
+
+		for _, fid := range sortedElementIDs(fileIDs) {
+			pkg.HasFiles = append(pkg.HasFiles, spdx.RenderElementID(fid))
+			d.Files = append(d.Files, fileFrom2_1(p.Files[fid]))
+		}
+
+		d.Packages = append(d.Packages, pkg)
+	}
+
+	fileIDs := []spdx.ElementID{}
+	for id := range doc.UnpackagedFiles {
+		fileIDs = append(fileIDs, id)
+	}
+
+	for _, id := range sortedElementIDs(fileIDs) {
+		d.Files = append(d.Files, fileFrom2_1(doc.UnpackagedFiles[id]))
+	}
+
+	for _, l := range doc.OtherLicenses {
+		d.ExtractedLicenses = append(d.ExtractedLicenses, extractedLicense{
+			LicenseID:     l.LicenseIdentifier,
+			ExtractedText: l.ExtractedText,
+			Name:          l.LicenseName,
+			SeeAlsos:      l.LicenseCrossReferences,
+			Comment:       l.LicenseComment,
+		})
+	}
+
+	for _, r := range doc.Relationships {
+		d.Relationships = append(d.Relationships, relationship{
+			SPDXElementID:      spdx.RenderDocElementID(r.RefA),
+			RelationshipType:   r.Relationship,
+			RelatedSPDXElement: spdx.RenderDocElementID(r.RefB),
+			Comment:            r.RelationshipComment,
+		})
+	}
+
+	return d
+}
+
+func fileFrom2_1(f *spdx.File2_1) fileSection {
+	return fileSection{
+		SPDXID:             spdx.RenderElementID(f.FileSPDXIdentifier),
+		FileName:           f.FileName,
+		FileTypes:          f.FileType,
+		Checksums:          legacyChecksums(f.FileChecksumSHA1, f.FileChecksumSHA256, f.FileChecksumMD5),
+		LicenseConcluded:   f.LicenseConcluded,
+		LicenseInfoInFiles: f.LicenseInfoInFile,
+		LicenseComments:    f.LicenseComments,
+		CopyrightText:      f.FileCopyrightText,
+		Comment:            f.FileComment,
+		NoticeText:         f.FileNotice,
+		FileContributors:   f.FileContributor,
+	}
+}
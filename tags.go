@@ -20,18 +20,18 @@ func (ic *IonClient) CreateTag(teamID, name, description, token string) (*tags.T
 
 	b, err := json.Marshal(tag)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal tag params to JSON: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal tag params to JSON: %w", err)
 	}
 
 	b, err = ic.Post(tags.CreateTagEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create tag: %v", err.Error())
+		return nil, fmt.Errorf("failed to create tag: %w", err)
 	}
 
 	var t tags.Tag
 	err = json.Unmarshal(b, &t)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response from create: %v", err.Error())
+		return nil, fmt.Errorf("failed to read response from create: %w", err)
 	}
 
 	return &t, nil
@@ -49,18 +49,18 @@ func (ic *IonClient) UpdateTag(id, teamID, name, description, token string) (*ta
 
 	b, err := json.Marshal(tag)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal tag params to JSON: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal tag params to JSON: %w", err)
 	}
 
 	b, err = ic.Put(tags.UpdateTagEndpoint, token, nil, *bytes.NewBuffer(b), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update tag: %v", err.Error())
+		return nil, fmt.Errorf("failed to update tag: %w", err)
 	}
 
 	var t tags.Tag
 	err = json.Unmarshal(b, &t)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response from update: %v", err.Error())
+		return nil, fmt.Errorf("failed to read response from update: %w", err)
 	}
 
 	return &t, nil
@@ -75,13 +75,13 @@ func (ic *IonClient) GetTag(id, teamID, token string) (*tags.Tag, error) {
 
 	b, _, err := ic.Get(tags.GetTagEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tag: %v", err.Error())
+		return nil, fmt.Errorf("failed to get tag: %w", err)
 	}
 
 	var t tags.Tag
 	err = json.Unmarshal(b, &t)
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse tag: %v", err.Error())
+		return nil, fmt.Errorf("cannot parse tag: %w", err)
 	}
 
 	return &t, nil
@@ -95,13 +95,13 @@ func (ic *IonClient) GetTags(teamID, token string) ([]tags.Tag, error) {
 
 	b, _, err := ic.Get(tags.GetTagsEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tag: %v", err.Error())
+		return nil, fmt.Errorf("failed to get tag: %w", err)
 	}
 
 	var ts []tags.Tag
 	err = json.Unmarshal(b, &ts)
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse tag: %v", err.Error())
+		return nil, fmt.Errorf("cannot parse tag: %w", err)
 	}
 
 	return ts, nil
@@ -115,7 +115,7 @@ func (ic *IonClient) GetRawTags(teamID, token string) (json.RawMessage, error) {
 
 	b, _, err := ic.Get(tags.GetTagsEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tag: %v", err.Error())
+		return nil, fmt.Errorf("failed to get tag: %w", err)
 	}
 
 	return b, nil
@@ -130,7 +130,7 @@ func (ic *IonClient) GetRawTag(id, teamID, token string) (json.RawMessage, error
 
 	b, _, err := ic.Get(tags.GetTagEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tag: %v", err.Error())
+		return nil, fmt.Errorf("failed to get tag: %w", err)
 	}
 
 	return b, nil
@@ -26,19 +26,19 @@ type CreateTeamUserOptions struct {
 func (ic *IonClient) CreateTeamUser(opts CreateTeamUserOptions, token string) (*teamusers.TeamUser, error) {
 	b, err := json.Marshal(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	buff := bytes.NewBuffer(b)
 	b, err = ic.Post(teamusers.TeamsCreateTeamUserEndpoint, token, nil, *buff, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create team user: %v", err.Error())
+		return nil, fmt.Errorf("failed to create team user: %w", err)
 	}
 
 	var tu teamusers.TeamUser
 	err = json.Unmarshal(b, &tu)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse team user from response: %v", err.Error())
+		return nil, fmt.Errorf("failed to parse team user from response: %w", err)
 	}
 
 	return &tu, nil
@@ -54,13 +54,13 @@ func (ic *IonClient) GetTeamUser(teamID, userID, token string) (*teamusers.TeamU
 
 	b, _, err := ic.Get(teamusers.TeamsGetTeamUserEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get team: %v", err.Error())
+		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
 
 	var teamU teamusers.TeamUser
 	err = json.Unmarshal(b, &teamU)
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse team: %v", err.Error())
+		return nil, fmt.Errorf("cannot parse team: %w", err)
 	}
 
 	return &teamU, nil
@@ -74,19 +74,19 @@ func (ic *IonClient) UpdateTeamUser(teamuser *teamusers.TeamUser, token string)
 
 	b, err := json.Marshal(teamuser)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	buff := bytes.NewBuffer(b)
 	b, err = ic.Put(teamusers.TeamsUpdateTeamUserEndpoint, token, params, *buff, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update team user: %v", err.Error())
+		return nil, fmt.Errorf("failed to update team user: %w", err)
 	}
 
 	var tu teamusers.TeamUser
 	err = json.Unmarshal(b, &tu)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse team user from response: %v", err.Error())
+		return nil, fmt.Errorf("failed to parse team user from response: %w", err)
 	}
 
 	return &tu, nil
@@ -101,12 +101,12 @@ func (ic *IonClient) DeleteTeamUser(teamuser *teamusers.TeamUser, token string)
 
 	_, err := json.Marshal(teamuser)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	_, err = ic.Delete(teamusers.TeamsDeleteTeamUserEndpoint, token, params, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete team user: %v", err.Error())
+		return fmt.Errorf("failed to delete team user: %w", err)
 	}
 
 	params = &url.Values{}
@@ -118,7 +118,7 @@ func (ic *IonClient) DeleteTeamUser(teamuser *teamusers.TeamUser, token string)
 		var teamU teamusers.TeamUser
 		err = json.Unmarshal(b, &teamU)
 		if err != nil {
-			return fmt.Errorf("cannot parse team: %v", err.Error())
+			return fmt.Errorf("cannot parse team: %w", err)
 		}
 		return fmt.Errorf("failed to validate team user deletion: %v", b)
 	}
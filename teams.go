@@ -27,20 +27,20 @@ func (ic *IonClient) CreateTeam(opts CreateTeamOptions, token string) (*teams.Te
 
 	b, err := json.Marshal(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	buff := bytes.NewBuffer(b)
 
 	b, err = ic.Post(teams.TeamsCreateTeamEndpoint, token, nil, *buff, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create team: %v", err.Error())
+		return nil, fmt.Errorf("failed to create team: %w", err)
 	}
 
 	var t teams.Team
 	err = json.Unmarshal(b, &t)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse team from response: %v", err.Error())
+		return nil, fmt.Errorf("failed to parse team from response: %w", err)
 	}
 
 	return &t, nil
@@ -55,13 +55,13 @@ func (ic *IonClient) GetTeam(id, token string) (*teams.Team, error) {
 
 	b, _, err := ic.Get(teams.TeamsGetTeamEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get team: %v", err.Error())
+		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
 
 	var team teams.Team
 	err = json.Unmarshal(b, &team)
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse team: %v", err.Error())
+		return nil, fmt.Errorf("cannot parse team: %w", err)
 	}
 
 	return &team, nil
@@ -73,13 +73,13 @@ func (ic *IonClient) GetTeam(id, token string) (*teams.Team, error) {
 func (ic *IonClient) GetTeams(token string) ([]teams.Team, error) {
 	b, _, err := ic.Get(teams.TeamsGetTeamsEndpoint, token, nil, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get teams: %v", err.Error())
+		return nil, fmt.Errorf("failed to get teams: %w", err)
 	}
 
 	var ts []teams.Team
 	err = json.Unmarshal(b, &ts)
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse teams: %v", err.Error())
+		return nil, fmt.Errorf("cannot parse teams: %w", err)
 	}
 
 	return ts, nil
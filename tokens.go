@@ -0,0 +1,150 @@
+package ionic
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTokenEnvVar is the environment variable read by an EnvToken
+	// source when no variable name is given
+	DefaultTokenEnvVar = "IONCHANNEL_SECRET_KEY"
+
+	defaultSessionMaxAge        = 30 * time.Minute
+	defaultSessionRefreshBefore = time.Minute
+)
+
+// TokenSource represents anything that can supply the token used to
+// authenticate calls to the API.  Implementations must be safe for concurrent
+// use.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken is a TokenSource that always returns the same token
+type StaticToken string
+
+// Token returns the static token, or an error if it is empty
+func (t StaticToken) Token(ctx context.Context) (string, error) {
+	if t == "" {
+		return "", fmt.Errorf("static token is empty")
+	}
+
+	return string(t), nil
+}
+
+// EnvToken is a TokenSource that reads the token from an environment variable
+// each time one is needed.  An empty EnvToken reads DefaultTokenEnvVar.
+type EnvToken string
+
+// Token returns the value of the environment variable, or an error if it is
+// not set
+func (e EnvToken) Token(ctx context.Context) (string, error) {
+	name := string(e)
+	if name == "" {
+		name = DefaultTokenEnvVar
+	}
+
+	token := os.Getenv(name)
+	if token == "" {
+		return "", fmt.Errorf("environment variable %v is not set", name)
+	}
+
+	return token, nil
+}
+
+// SessionTokenSource is a TokenSource that logs in with a username and
+// password and reuses the session's token.  When the session's token carries
+// an expiry, the session is refreshed shortly before it expires, and a new
+// session is logged in if it can no longer be refreshed.  Otherwise a new
+// session is logged in once the session has been held for MaxAge.
+type SessionTokenSource struct {
+	// MaxAge is how long a session without a known expiry is used before
+	// logging in again
+	MaxAge time.Duration
+	// RefreshBefore is how long before its expiry a session is refreshed
+	RefreshBefore time.Duration
+
+	client   *IonClient
+	username string
+	password string
+
+	mu       sync.Mutex
+	session  *Session
+	loggedIn time.Time
+}
+
+// NewSessionTokenSource takes a client, username, and password.  It returns a
+// TokenSource that logs in through the client when a token is first needed.
+func NewSessionTokenSource(client *IonClient, username, password string) *SessionTokenSource {
+	return &SessionTokenSource{
+		MaxAge:        defaultSessionMaxAge,
+		RefreshBefore: defaultSessionRefreshBefore,
+		client:        client,
+		username:      username,
+		password:      password,
+	}
+}
+
+// Token returns the token of the current session, refreshing the session or
+// logging in first as needed
+func (s *SessionTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ic := s.client.WithContext(ctx)
+
+	if s.session != nil {
+		_, hasExpiry := s.session.ExpiresAt()
+
+		switch {
+		case !hasExpiry && time.Since(s.loggedIn) < s.MaxAge:
+			return s.session.BearerToken, nil
+		case hasExpiry && !s.session.ExpiresWithin(s.RefreshBefore):
+			return s.session.BearerToken, nil
+		case hasExpiry && !s.session.Expired():
+			sess, err := ic.RefreshSession(s.session.BearerToken)
+			if err == nil && sess.BearerToken != "" {
+				s.session = sess
+				return sess.BearerToken, nil
+			}
+		}
+	}
+
+	sess, err := ic.Login(s.username, s.password)
+	if err != nil {
+		return "", err
+	}
+
+	s.session = sess
+	s.loggedIn = time.Now()
+	return sess.BearerToken, nil
+}
+
+// Invalidate discards the current session so the next token requested logs in
+// again
+func (s *SessionTokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.session = nil
+}
+
+// Logout ends the current session, if there is one, so its token can no
+// longer be used.  The next token requested logs in again.
+func (s *SessionTokenSource) Logout(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.session == nil {
+		return nil
+	}
+
+	token := s.session.BearerToken
+	s.session = nil
+
+	return s.client.WithContext(ctx).Logout(token)
+}
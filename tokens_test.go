@@ -0,0 +1,197 @@
+package ionic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic/projects"
+	. "github.com/onsi/gomega"
+)
+
+func TestTokens(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Token Sources", func() {
+		g.It("should return a static token", func() {
+			tok, err := StaticToken("sometoken").Token(context.Background())
+			Expect(err).To(BeNil())
+			Expect(tok).To(Equal("sometoken"))
+
+			_, err = StaticToken("").Token(context.Background())
+			Expect(err).NotTo(BeNil())
+		})
+
+		g.It("should return a token from the environment", func() {
+			os.Setenv("IONIC_TEST_TOKEN", "envtoken")
+			defer os.Unsetenv("IONIC_TEST_TOKEN")
+
+			tok, err := EnvToken("IONIC_TEST_TOKEN").Token(context.Background())
+			Expect(err).To(BeNil())
+			Expect(tok).To(Equal("envtoken"))
+
+			_, err = EnvToken("IONIC_TEST_MISSING_TOKEN").Token(context.Background())
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("IONIC_TEST_MISSING_TOKEN"))
+		})
+
+		g.It("should authenticate project validation with the client's source", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer sourcetoken" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				switch r.URL.Path {
+				case "/v1/project/createProject", "/v1/project/updateProject":
+					fmt.Fprint(w, SampleValidProject)
+				case "/v1/ruleset/getRuleset":
+					w.WriteHeader(http.StatusOK)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			cli, _ := New(server.URL)
+			cli.SetTokenSource(StaticToken("sourcetoken"))
+
+			project, err := cli.CreateProject(&projects.Project{}, "bef86653-1926-4990-8ef8-5f26cd59d6fc", "")
+			Expect(err).To(BeNil())
+			Expect(*project.ID).To(Equal("334c183d-4d37-4515-84c4-0d0ed0fb8db0"))
+
+			_, err = cli.UpdateProject(project, "")
+			Expect(err).To(BeNil())
+
+			exists, err := cli.RuleSetExists(*project.RulesetID, *project.TeamID, "")
+			Expect(err).To(BeNil())
+			Expect(exists).To(BeTrue())
+		})
+
+		g.Describe("Session", func() {
+			var server *httptest.Server
+			var logins int32
+			var refreshes int32
+			var logouts int32
+			var auth string
+
+			g.BeforeEach(func() {
+				logins = 0
+				refreshes = 0
+				logouts = 0
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.URL.Path {
+					case "/v1/sessions/login":
+						atomic.AddInt32(&logins, 1)
+						exp := time.Now().Add(30 * time.Second).Unix()
+						fmt.Fprintf(w, `{"data":{"jwt":"%v"}}`, sampleJWT(exp))
+						return
+					case "/v1/sessions/refresh":
+						atomic.AddInt32(&refreshes, 1)
+						exp := time.Now().Add(time.Hour).Unix()
+						fmt.Fprintf(w, `{"data":{"jwt":"%v"}}`, sampleJWT(exp))
+						return
+					case "/v1/sessions/logout":
+						atomic.AddInt32(&logouts, 1)
+						w.WriteHeader(http.StatusNoContent)
+						return
+					}
+
+					auth = r.Header.Get("Authorization")
+					fmt.Fprint(w, `{"data":{"id":"someteam"}}`)
+				}))
+			})
+
+			g.AfterEach(func() {
+				server.Close()
+			})
+
+			g.It("should log in once and reuse the session", func() {
+				cli, _ := New(server.URL)
+				ts := NewSessionTokenSource(cli, "ion", "secretpass")
+
+				ts.RefreshBefore = 0
+
+				first, err := ts.Token(context.Background())
+				Expect(err).To(BeNil())
+
+				for i := 0; i < 3; i++ {
+					tok, err := ts.Token(context.Background())
+					Expect(err).To(BeNil())
+					Expect(tok).To(Equal(first))
+				}
+
+				Expect(logins).To(Equal(int32(1)))
+
+				ts.Invalidate()
+				_, err = ts.Token(context.Background())
+				Expect(err).To(BeNil())
+				Expect(logins).To(Equal(int32(2)))
+			})
+
+			g.It("should refresh a session close to expiring", func() {
+				cli, _ := New(server.URL)
+				ts := NewSessionTokenSource(cli, "ion", "secretpass")
+
+				first, _ := ts.Token(context.Background())
+				second, err := ts.Token(context.Background())
+				Expect(err).To(BeNil())
+				Expect(second).NotTo(Equal(first))
+				Expect(logins).To(Equal(int32(1)))
+				Expect(refreshes).To(Equal(int32(1)))
+
+				third, _ := ts.Token(context.Background())
+				Expect(third).To(Equal(second))
+				Expect(refreshes).To(Equal(int32(1)))
+			})
+
+			g.It("should log out of the session", func() {
+				cli, _ := New(server.URL)
+				ts := NewSessionTokenSource(cli, "ion", "secretpass")
+
+				Expect(ts.Logout(context.Background())).To(BeNil())
+				Expect(logouts).To(Equal(int32(0)))
+
+				ts.Token(context.Background())
+				Expect(ts.Logout(context.Background())).To(BeNil())
+				Expect(logouts).To(Equal(int32(1)))
+
+				ts.Token(context.Background())
+				Expect(logins).To(Equal(int32(2)))
+			})
+
+			g.It("should be used by a client for calls without a token", func() {
+				cli, _ := New(server.URL)
+				cli.SetTokenSource(NewSessionTokenSource(cli, "ion", "secretpass"))
+
+				_, err := cli.GetTeam("someteam", "")
+				Expect(err).To(BeNil())
+				Expect(auth).To(HavePrefix("Bearer "))
+				Expect(auth).NotTo(Equal("Bearer "))
+				Expect(logins).To(Equal(int32(1)))
+
+				_, err = cli.GetTeam("someteam", "calltoken")
+				Expect(err).To(BeNil())
+				Expect(auth).To(Equal("Bearer calltoken"))
+				Expect(logins).To(Equal(int32(1)))
+			})
+
+			g.It("should return an error when the source fails", func() {
+				cli, _ := New(server.URL)
+				cli.SetTokenSource(EnvToken("IONIC_TEST_MISSING_TOKEN"))
+
+				_, err := cli.GetTeam("someteam", "")
+				Expect(err).NotTo(BeNil())
+				Expect(strings.Contains(err.Error(), "failed to get token")).To(BeTrue())
+			})
+		})
+	})
+}
@@ -121,19 +121,19 @@ func (ic *IonClient) GetUserNames(ids []string, teamID, token string) ([]users.N
 
 	b, err := json.Marshal(byIDs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	buff := bytes.NewBuffer(b)
 	r, err := ic.Post(users.UsersGetUserNames, token, params, *buff, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user names: %v", err.Error())
+		return nil, fmt.Errorf("failed to get user names: %w", err)
 	}
 
 	var s []users.NameAndID
 	err = json.Unmarshal(r, &s)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal user names: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal user names: %w", err)
 	}
 
 	return s, nil
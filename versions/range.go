@@ -0,0 +1,170 @@
+package versions
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidRange is returned when a version range expression cannot be
+	// parsed
+	ErrInvalidRange = fmt.Errorf("invalid version range")
+
+	// operators are the comparison operators of constraints, longest first so
+	// they are matched before their prefixes
+	operators = []string{"==", "!=", "<=", ">=", "=", "<", ">"}
+)
+
+// Constraint is a comparison of a version with a bound, such as >= 1.2.0
+type Constraint struct {
+	Operator string `json:"operator" xml:"operator"`
+	Version  string `json:"version" xml:"version"`
+}
+
+// String returns the constraint as it is written in a range expression
+func (c Constraint) String() string {
+	return c.Operator + c.Version
+}
+
+// Allows returns whether a version satisfies the constraint, comparing
+// versions with the rules of the given ecosystem
+func (c Constraint) Allows(ecosystem, version string) bool {
+	cmp := Compare(ecosystem, version, c.Version)
+
+	switch c.Operator {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "!=":
+		return cmp != 0
+	default:
+		return cmp == 0
+	}
+}
+
+// Range is a set of constraints a version must satisfy all of.  An empty
+// range contains every version.
+type Range []Constraint
+
+// String returns the range as it is written in a range expression
+func (r Range) String() string {
+	constraints := make([]string, 0, len(r))
+	for _, c := range r {
+		constraints = append(constraints, c.String())
+	}
+
+	return strings.Join(constraints, ", ")
+}
+
+// Contains returns whether a version satisfies every constraint of the range,
+// comparing versions with the rules of the given ecosystem
+func (r Range) Contains(ecosystem, version string) bool {
+	for _, c := range r {
+		if !c.Allows(ecosystem, version) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ParseRanges parses a version range expression, such as
+// ">= 1.0.0, < 1.2.5 || 2.0.0", into the ranges it is the union of.  Ranges
+// are separated by ||, and the constraints of a range by commas or spaces.  A
+// version without an operator is an exact version.  * or - is a single range
+// containing every version, while an empty expression gives no versions and
+// has no ranges.
+func ParseRanges(expression string) ([]Range, error) {
+	expression = strings.TrimSpace(expression)
+
+	if expression == "" {
+		return []Range{}, nil
+	}
+
+	if expression == "*" || expression == "-" {
+		return []Range{{}}, nil
+	}
+
+	ranges := []Range{}
+	for _, group := range strings.Split(expression, "||") {
+		r, err := parseRange(group)
+		if err != nil {
+			return nil, err
+		}
+
+		ranges = append(ranges, r)
+	}
+
+	return ranges, nil
+}
+
+// FormatRanges writes ranges as the range expression ParseRanges reads
+func FormatRanges(ranges []Range) string {
+	groups := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		if len(r) == 0 {
+			return "*"
+		}
+
+		groups = append(groups, r.String())
+	}
+
+	return strings.Join(groups, " || ")
+}
+
+// parseRange parses the constraints of a single range
+func parseRange(group string) (Range, error) {
+	r := Range{}
+	operator := ""
+
+	for _, token := range strings.Fields(strings.Replace(group, ",", " ", -1)) {
+		op := operatorOf(token)
+		version := token[len(op):]
+
+		if operator != "" {
+			if op != "" {
+				return nil, fmt.Errorf("%w: operator %v has no version", ErrInvalidRange, operator)
+			}
+
+			op = operator
+		}
+
+		if version == "" {
+			operator = op
+			continue
+		}
+
+		if op == "" || op == "==" {
+			op = "="
+		}
+
+		r = append(r, Constraint{Operator: op, Version: version})
+		operator = ""
+	}
+
+	if operator != "" {
+		return nil, fmt.Errorf("%w: operator %v has no version", ErrInvalidRange, operator)
+	}
+
+	if len(r) == 0 {
+		return nil, fmt.Errorf("%w: empty range in %q", ErrInvalidRange, group)
+	}
+
+	return r, nil
+}
+
+// operatorOf returns the operator a constraint begins with, if any
+func operatorOf(token string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(token, op) {
+			return op
+		}
+	}
+
+	return ""
+}
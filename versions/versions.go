@@ -0,0 +1,507 @@
+// Package versions compares the versions of packages with the rules of their
+// ecosystems, and reads and writes the range expressions the versions of
+// products affected by vulnerabilities are given as.
+package versions
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// EcosystemNPM is the ecosystem of npm packages, versioned with semver
+	EcosystemNPM = "npm"
+	// EcosystemPyPI is the ecosystem of Python packages, versioned with PEP 440
+	EcosystemPyPI = "pypi"
+	// EcosystemMaven is the ecosystem of Maven artifacts
+	EcosystemMaven = "maven"
+	// EcosystemGo is the ecosystem of Go modules, versioned with semver
+	EcosystemGo = "golang"
+	// EcosystemCargo is the ecosystem of Rust crates, versioned with semver
+	EcosystemCargo = "cargo"
+	// EcosystemRubyGems is the ecosystem of Ruby gems
+	EcosystemRubyGems = "gem"
+	// EcosystemNuGet is the ecosystem of .NET packages, versioned with semver
+	EcosystemNuGet = "nuget"
+	// EcosystemComposer is the ecosystem of PHP packages, versioned with semver
+	EcosystemComposer = "composer"
+	// EcosystemHex is the ecosystem of Erlang and Elixir packages, versioned
+	// with semver
+	EcosystemHex = "hex"
+)
+
+var (
+	// ecosystems maps Ion Channel dependency types, OSV ecosystems, and
+	// package URL types to the ecosystems versions are compared in
+	ecosystems = map[string]string{
+		"npm":       EcosystemNPM,
+		"yarn":      EcosystemNPM,
+		"pypi":      EcosystemPyPI,
+		"python":    EcosystemPyPI,
+		"pip":       EcosystemPyPI,
+		"maven":     EcosystemMaven,
+		"go":        EcosystemGo,
+		"golang":    EcosystemGo,
+		"cargo":     EcosystemCargo,
+		"rust":      EcosystemCargo,
+		"crates.io": EcosystemCargo,
+		"gem":       EcosystemRubyGems,
+		"ruby":      EcosystemRubyGems,
+		"rubygems":  EcosystemRubyGems,
+		"nuget":     EcosystemNuGet,
+		"dotnet":    EcosystemNuGet,
+		"composer":  EcosystemComposer,
+		"php":       EcosystemComposer,
+		"packagist": EcosystemComposer,
+		"hex":       EcosystemHex,
+	}
+
+	// mavenQualifiers ranks the well known qualifiers of Maven versions, the
+	// same as Maven's ComparableVersion
+	mavenQualifiers = map[string]int{
+		"alpha":     0,
+		"a":         0,
+		"beta":      1,
+		"b":         1,
+		"milestone": 2,
+		"m":         2,
+		"rc":        3,
+		"cr":        3,
+		"snapshot":  4,
+		"":          5,
+		"ga":        5,
+		"final":     5,
+		"release":   5,
+		"sp":        6,
+	}
+
+	// preReleaseWords are the words that mark versions of unknown ecosystems
+	// as coming before the release they qualify
+	preReleaseWords = map[string]bool{
+		"alpha":     true,
+		"beta":      true,
+		"rc":        true,
+		"pre":       true,
+		"preview":   true,
+		"dev":       true,
+		"snapshot":  true,
+		"milestone": true,
+	}
+
+	pep440Regex  = regexp.MustCompile(`^v?(?:(\d+)!)?(\d+(?:\.\d+)*)(?:[-_.]?(a|alpha|b|beta|c|rc|pre|preview)[-_.]?(\d*))?(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d*))?(?:[-_.]?(dev)[-_.]?(\d*))?(?:\+[a-z0-9.]+)?$`)
+	segmentRegex = regexp.MustCompile(`[0-9]+|[a-z]+`)
+)
+
+// Ecosystem returns the ecosystem versions of a dependency type, OSV
+// ecosystem, or package URL type are compared in, ignoring case.  It is empty
+// for types it does not know.
+func Ecosystem(name string) string {
+	return ecosystems[strings.ToLower(strings.TrimSpace(name))]
+}
+
+// Compare compares two versions with the rules of an ecosystem, returning -1
+// if a comes before b, 1 if it comes after, and 0 if they are the same
+// version.  npm, Go, Cargo, NuGet, Composer, and Hex versions are compared as
+// semver, PyPI versions as PEP 440, Maven and RubyGems versions as their tools
+// do, and the versions of any other ecosystem by comparing their numbers and
+// words in turn.
+func Compare(ecosystem, a, b string) int {
+	a = strings.ToLower(strings.TrimSpace(a))
+	b = strings.ToLower(strings.TrimSpace(b))
+
+	if a == b {
+		return 0
+	}
+
+	switch Ecosystem(ecosystem) {
+	case EcosystemNPM, EcosystemGo, EcosystemCargo, EcosystemNuGet, EcosystemComposer, EcosystemHex:
+		return compareSemver(a, b)
+	case EcosystemPyPI:
+		return comparePEP440(a, b)
+	case EcosystemMaven:
+		return compareMaven(a, b)
+	case EcosystemRubyGems:
+		return compareRubyGems(a, b)
+	default:
+		return compareGeneric(a, b)
+	}
+}
+
+// compareSemver compares two semver versions, allowing a leading v and any
+// number of release numbers.  Build metadata is ignored.
+func compareSemver(a, b string) int {
+	aRelease, aPre := splitSemver(a)
+	bRelease, bPre := splitSemver(b)
+
+	if c := compareDotted(aRelease, bRelease); c != 0 {
+		return c
+	}
+
+	switch {
+	case aPre == "" && bPre == "":
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+
+	return compareDotted(aPre, bPre)
+}
+
+// splitSemver splits a semver version into its release and pre-release
+func splitSemver(version string) (string, string) {
+	version = strings.TrimLeft(version, "v=")
+
+	if i := strings.Index(version, "+"); i >= 0 {
+		version = version[:i]
+	}
+
+	if i := strings.Index(version, "-"); i >= 0 {
+		return version[:i], version[i+1:]
+	}
+
+	return version, ""
+}
+
+// compareDotted compares dot separated identifiers in turn, numbers by value
+// and before words, and words lexically.  Missing numbers are taken as zero
+// and missing words as coming first.
+func compareDotted(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var ap, bp string
+		if i < len(aParts) {
+			ap = aParts[i]
+		}
+
+		if i < len(bParts) {
+			bp = bParts[i]
+		}
+
+		an, aErr := strconv.ParseUint(orZero(ap, bp), 10, 64)
+		bn, bErr := strconv.ParseUint(orZero(bp, ap), 10, 64)
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareUints(an, bn); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(ap, bp); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return 0
+}
+
+// orZero returns a missing identifier as zero if the identifier it is
+// compared with is a number
+func orZero(identifier, other string) string {
+	if identifier == "" {
+		if _, err := strconv.ParseUint(other, 10, 64); err == nil {
+			return "0"
+		}
+	}
+
+	return identifier
+}
+
+// pep440Key is the parts of a PEP 440 version in the order they are compared
+type pep440Key struct {
+	epoch   uint64
+	release string
+	pre     [2]int64
+	post    int64
+	dev     int64
+}
+
+// comparePEP440 compares two PEP 440 versions.  Versions that are not valid
+// PEP 440 are compared as versions of an unknown ecosystem.
+func comparePEP440(a, b string) int {
+	aKey, aOK := parsePEP440(a)
+	bKey, bOK := parsePEP440(b)
+
+	if !aOK || !bOK {
+		return compareGeneric(a, b)
+	}
+
+	if c := compareUints(aKey.epoch, bKey.epoch); c != 0 {
+		return c
+	}
+
+	if c := compareDotted(aKey.release, bKey.release); c != 0 {
+		return c
+	}
+
+	for _, pair := range [][2]int64{
+		{aKey.pre[0], bKey.pre[0]},
+		{aKey.pre[1], bKey.pre[1]},
+		{aKey.post, bKey.post},
+		{aKey.dev, bKey.dev},
+	} {
+		if c := compareInts(pair[0], pair[1]); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+// parsePEP440 returns the comparison key of a PEP 440 version.  Releases
+// without a pre-release come after their pre-releases, and development
+// releases before them; releases without a post-release come before them,
+// and releases without a development release after them.
+func parsePEP440(version string) (pep440Key, bool) {
+	m := pep440Regex.FindStringSubmatch(version)
+	if m == nil {
+		return pep440Key{}, false
+	}
+
+	const (
+		first = -1 << 62
+		last  = 1 << 62
+	)
+
+	key := pep440Key{release: m[2], pre: [2]int64{last, 0}, post: first, dev: last}
+	key.epoch, _ = strconv.ParseUint(m[1], 10, 64)
+
+	switch m[3] {
+	case "a", "alpha":
+		key.pre = [2]int64{0, atoi(m[4])}
+	case "b", "beta":
+		key.pre = [2]int64{1, atoi(m[4])}
+	case "c", "rc", "pre", "preview":
+		key.pre = [2]int64{2, atoi(m[4])}
+	}
+
+	switch {
+	case m[5] != "":
+		key.post = atoi(m[5])
+	case m[6] != "":
+		key.post = atoi(m[7])
+	}
+
+	if m[8] != "" {
+		key.dev = atoi(m[9])
+
+		if m[3] == "" && key.post == first {
+			key.pre = [2]int64{first, 0}
+		}
+	}
+
+	return key, true
+}
+
+// compareMaven compares two Maven versions the way Maven's ComparableVersion
+// does.  Versions are split into numbers and qualifiers, missing numbers are
+// taken as zero and missing qualifiers as a release, well known qualifiers are
+// ranked, and other qualifiers come after them.
+func compareMaven(a, b string) int {
+	aTokens := mavenTokens(a)
+	bTokens := mavenTokens(b)
+
+	for i := 0; i < len(aTokens) || i < len(bTokens); i++ {
+		var at, bt string
+		if i < len(aTokens) {
+			at = aTokens[i]
+		}
+
+		if i < len(bTokens) {
+			bt = bTokens[i]
+		}
+
+		an, aErr := strconv.ParseUint(orZero(at, bt), 10, 64)
+		bn, bErr := strconv.ParseUint(orZero(bt, at), 10, 64)
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareUints(an, bn); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			return 1
+		case bErr == nil:
+			return -1
+		default:
+			if c := compareMavenQualifiers(at, bt); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return 0
+}
+
+// mavenTokens returns the numbers and qualifiers of a Maven version, dropping
+// the zeros and release qualifiers that end it or come before a qualifier, so
+// 1.0.0, 1-ga, and 1 are the same version
+func mavenTokens(version string) []string {
+	tokens := versionTokens(version)
+	kept := make([]string, 0, len(tokens))
+	droppable := true
+
+	for i := len(tokens) - 1; i >= 0; i-- {
+		t := tokens[i]
+		if droppable && (t == "0" || mavenQualifiers[t] == mavenQualifiers[""]) {
+			continue
+		}
+
+		kept = append([]string{t}, kept...)
+		_, err := strconv.ParseUint(t, 10, 64)
+		droppable = err != nil
+	}
+
+	return kept
+}
+
+// compareMavenQualifiers compares two Maven qualifiers by their rank
+func compareMavenQualifiers(a, b string) int {
+	aRank, aKnown := mavenQualifiers[a]
+	bRank, bKnown := mavenQualifiers[b]
+
+	switch {
+	case aKnown && bKnown:
+		return compareInts(int64(aRank), int64(bRank))
+	case aKnown:
+		return -1
+	case bKnown:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// compareRubyGems compares two gem versions the way RubyGems does.  Numbers
+// come after words, so versions with words are pre-releases, and missing
+// segments are taken as zero.
+func compareRubyGems(a, b string) int {
+	aSegments := segmentRegex.FindAllString(a, -1)
+	bSegments := segmentRegex.FindAllString(b, -1)
+
+	for i := 0; i < len(aSegments) || i < len(bSegments); i++ {
+		as, bs := "0", "0"
+		if i < len(aSegments) {
+			as = aSegments[i]
+		}
+
+		if i < len(bSegments) {
+			bs = bSegments[i]
+		}
+
+		an, aErr := strconv.ParseUint(as, 10, 64)
+		bn, bErr := strconv.ParseUint(bs, 10, 64)
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareUints(an, bn); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			return 1
+		case bErr == nil:
+			return -1
+		default:
+			if c := strings.Compare(as, bs); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return 0
+}
+
+// compareGeneric compares the numbers and words of two versions in turn.
+// Numbers are compared by value and come after words, and missing numbers are
+// taken as zero.  Pre-release words such as alpha and rc come before a
+// missing word, and other words after it, so 1.0-rc1 comes before 1.0 and
+// 1.0.1a after 1.0.1.
+func compareGeneric(a, b string) int {
+	aTokens := versionTokens(a)
+	bTokens := versionTokens(b)
+
+	for i := 0; i < len(aTokens) || i < len(bTokens); i++ {
+		var at, bt string
+		if i < len(aTokens) {
+			at = aTokens[i]
+		}
+
+		if i < len(bTokens) {
+			bt = bTokens[i]
+		}
+
+		an, aErr := strconv.ParseUint(orZero(at, bt), 10, 64)
+		bn, bErr := strconv.ParseUint(orZero(bt, at), 10, 64)
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareUints(an, bn); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			return 1
+		case bErr == nil:
+			return -1
+		case at == "":
+			if preReleaseWords[bt] {
+				return 1
+			}
+
+			return -1
+		case bt == "":
+			if preReleaseWords[at] {
+				return -1
+			}
+
+			return 1
+		default:
+			if c := strings.Compare(at, bt); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return 0
+}
+
+// versionTokens splits a version into its numbers and words, dropping the
+// separators between them
+func versionTokens(version string) []string {
+	return segmentRegex.FindAllString(strings.TrimPrefix(version, "v"), -1)
+}
+
+func compareUints(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func atoi(s string) int64 {
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
@@ -0,0 +1,118 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestVersions(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Ecosystem", func() {
+		g.It("should normalize dependency types and OSV ecosystems", func() {
+			Expect(Ecosystem("yarn")).To(Equal(EcosystemNPM))
+			Expect(Ecosystem("PyPI")).To(Equal(EcosystemPyPI))
+			Expect(Ecosystem("crates.io")).To(Equal(EcosystemCargo))
+			Expect(Ecosystem("RubyGems")).To(Equal(EcosystemRubyGems))
+			Expect(Ecosystem("Go")).To(Equal(EcosystemGo))
+			Expect(Ecosystem("Debian")).To(Equal(""))
+		})
+	})
+
+	g.Describe("Compare Versions", func() {
+		g.It("should order versions in each ecosystem", func() {
+			table := []struct {
+				Ecosystem string
+				Lower     string
+				Higher    string
+			}{
+				{"npm", "1.2.3", "1.10.0"},
+				{"npm", "1.0.0-alpha", "1.0.0-alpha.1"},
+				{"npm", "1.0.0-alpha.beta", "1.0.0-beta"},
+				{"npm", "1.0.0-beta.2", "1.0.0-beta.11"},
+				{"npm", "1.0.0-rc.1", "1.0.0"},
+				{"golang", "v0.0.0-20210101000000-abcdef123456", "v0.1.0"},
+				{"golang", "v1.9.9", "v2.0.0+incompatible"},
+				{"nuget", "4.1.0", "4.1.0.1"},
+				{"pypi", "1.0.dev1", "1.0a1"},
+				{"pypi", "1.0a1", "1.0b2"},
+				{"pypi", "1.0b2", "1.0rc1"},
+				{"pypi", "1.0rc1", "1.0"},
+				{"pypi", "1.0", "1.0.post1"},
+				{"pypi", "1.0.post1.dev1", "1.0.post1"},
+				{"pypi", "2.0", "1!0.5"},
+				{"maven", "1.0-alpha-1", "1.0-beta"},
+				{"maven", "1.0-rc1", "1.0-SNAPSHOT"},
+				{"maven", "1.0-SNAPSHOT", "1.0"},
+				{"maven", "1.0", "1.0-sp1"},
+				{"maven", "2.9.10", "2.9.10.1"},
+				{"gem", "1.0.0.rc1", "1.0.0"},
+				{"gem", "1.0.0.a", "1.0.0.b"},
+				{"gem", "1.9", "1.10"},
+				{"", "1.0.1", "1.0.1a"},
+				{"", "2.0-rc1", "2.0"},
+				{"", "2.4.49", "2.4.50"},
+			}
+
+			for _, row := range table {
+				Expect(Compare(row.Ecosystem, row.Lower, row.Higher)).To(Equal(-1), row.Ecosystem+" "+row.Lower+" < "+row.Higher)
+				Expect(Compare(row.Ecosystem, row.Higher, row.Lower)).To(Equal(1), row.Ecosystem+" "+row.Higher+" > "+row.Lower)
+			}
+		})
+
+		g.It("should treat equivalent versions as the same", func() {
+			Expect(Compare("npm", "v1.2.3", "1.2.3+build.5")).To(Equal(0))
+			Expect(Compare("pypi", "1.0.0", "1.0")).To(Equal(0))
+			Expect(Compare("pypi", "1.0-RC1", "1.0rc1")).To(Equal(0))
+			Expect(Compare("maven", "1.0.0", "1-ga")).To(Equal(0))
+			Expect(Compare("gem", "1.0", "1.0.0")).To(Equal(0))
+		})
+	})
+
+	g.Describe("Ranges", func() {
+		g.It("should parse range expressions", func() {
+			ranges, err := ParseRanges(">= 1.0.0, <1.2.5 || =2.0.0 || 3.0.0 <3.1.0")
+			Expect(err).To(BeNil())
+			Expect(ranges).To(Equal([]Range{
+				{{Operator: ">=", Version: "1.0.0"}, {Operator: "<", Version: "1.2.5"}},
+				{{Operator: "=", Version: "2.0.0"}},
+				{{Operator: "=", Version: "3.0.0"}, {Operator: "<", Version: "3.1.0"}},
+			}))
+			Expect(FormatRanges(ranges)).To(Equal(">=1.0.0, <1.2.5 || =2.0.0 || =3.0.0, <3.1.0"))
+
+			ranges, err = ParseRanges("*")
+			Expect(err).To(BeNil())
+			Expect(ranges).To(Equal([]Range{{}}))
+			Expect(FormatRanges(ranges)).To(Equal("*"))
+
+			ranges, err = ParseRanges(" ")
+			Expect(err).To(BeNil())
+			Expect(ranges).To(BeEmpty())
+		})
+
+		g.It("should reject invalid range expressions", func() {
+			_, err := ParseRanges(">= 1.0.0, <")
+			Expect(errors.Is(err, ErrInvalidRange)).To(BeTrue())
+
+			_, err = ParseRanges("1.0.0 || ")
+			Expect(errors.Is(err, ErrInvalidRange)).To(BeTrue())
+
+			_, err = ParseRanges(">= <1.0.0")
+			Expect(errors.Is(err, ErrInvalidRange)).To(BeTrue())
+		})
+
+		g.It("should contain versions in the range", func() {
+			r := Range{{Operator: ">=", Version: "2.0.0"}, {Operator: "<", Version: "2.15.0"}, {Operator: "!=", Version: "2.3.1"}}
+			Expect(r.Contains("maven", "2.0")).To(BeTrue())
+			Expect(r.Contains("maven", "2.14.1")).To(BeTrue())
+			Expect(r.Contains("maven", "2.15.0")).To(BeFalse())
+			Expect(r.Contains("maven", "2.0-beta9")).To(BeFalse())
+			Expect(r.Contains("maven", "2.3.1")).To(BeFalse())
+			Expect(Range{}.Contains("npm", "0.0.1")).To(BeTrue())
+		})
+	})
+}
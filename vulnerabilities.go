@@ -20,20 +20,20 @@ import (
 func (ic *IonClient) AddVulnerability(newVuln *vulnerabilities.VulnerabilityInput, token string) (*vulnerabilities.Vulnerability, error) {
 	nv, err := json.Marshal(newVuln)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal new vuln into payload: %v", err.Error())
+		return nil, fmt.Errorf("failed to marshal new vuln into payload: %w", err)
 	}
 
 	p := bytes.NewBuffer(nv)
 
 	b, err := ic.Post(vulnerabilities.PostVulnerabilityEndpoint, token, nil, *p, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to add vulnerability: %v", err.Error())
+		return nil, fmt.Errorf("failed to add vulnerability: %w", err)
 	}
 
 	var v vulnerabilities.Vulnerability
 	err = json.Unmarshal(b, &v)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal json into vuln: %v", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal json into vuln: %w", err)
 	}
 
 	return &v, nil
@@ -52,13 +52,13 @@ func (ic *IonClient) GetVulnerabilities(product, version, token string, page *pa
 
 	b, _, err := ic.Get(vulnerabilities.GetVulnerabilitiesEndpoint, token, params, nil, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get vulnerabilities: %v", err.Error())
+		return nil, fmt.Errorf("failed to get vulnerabilities: %w", err)
 	}
 
 	var vulns []vulnerabilities.Vulnerability
 	err = json.Unmarshal(b, &vulns)
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse vulnerabilities: %v", err.Error())
+		return nil, fmt.Errorf("cannot parse vulnerabilities: %w", err)
 	}
 
 	return vulns, nil
@@ -74,18 +74,18 @@ func (ic *IonClient) GetVulnerabilitiesInFile(filePath, token string) ([]vulnera
 
 	fw, err := bw.CreateFormFile("file", filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %v", err.Error())
+		return nil, fmt.Errorf("failed to create form file: %w", err)
 	}
 
 	fh, err := os.Open(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %v", err.Error())
+		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 	defer fh.Close()
 
 	_, err = io.Copy(fw, fh)
 	if err != nil {
-		return nil, fmt.Errorf("failed to copy file to buffer: %v", err.Error())
+		return nil, fmt.Errorf("failed to copy file to buffer: %w", err)
 	}
 
 	h := http.Header{}
@@ -94,13 +94,13 @@ func (ic *IonClient) GetVulnerabilitiesInFile(filePath, token string) ([]vulnera
 
 	b, err := ic.Post(vulnerabilities.GetVulnerabilitiesInFileEndpoint, token, nil, *buff, h)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get vulnerabilities: %v", err.Error())
+		return nil, fmt.Errorf("failed to get vulnerabilities: %w", err)
 	}
 
 	var vulns []vulnerabilities.Vulnerability
 	err = json.Unmarshal(b, &vulns)
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse vulnerabilities: %v", err.Error())
+		return nil, fmt.Errorf("cannot parse vulnerabilities: %w", err)
 	}
 
 	return vulns, nil
@@ -114,13 +114,13 @@ func (ic *IonClient) GetVulnerability(id, token string) (*vulnerabilities.Vulner
 
 	b, _, err := ic.Get(vulnerabilities.GetVulnerabilityEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get vulnerability: %v", err.Error())
+		return nil, fmt.Errorf("failed to get vulnerability: %w", err)
 	}
 
 	var vuln vulnerabilities.Vulnerability
 	err = json.Unmarshal(b, &vuln)
 	if err != nil {
-		return nil, fmt.Errorf("cannot parse vulnerability: %v", err.Error())
+		return nil, fmt.Errorf("cannot parse vulnerability: %w", err)
 	}
 
 	return &vuln, nil
@@ -134,7 +134,7 @@ func (ic *IonClient) GetRawVulnerability(id, token string) (json.RawMessage, err
 
 	b, _, err := ic.Get(vulnerabilities.GetVulnerabilityEndpoint, token, params, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get vulnerability: %v", err.Error())
+		return nil, fmt.Errorf("failed to get vulnerability: %w", err)
 	}
 
 	return b, nil
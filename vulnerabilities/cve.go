@@ -0,0 +1,610 @@
+package vulnerabilities
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ion-channel/ionic/products"
+	"github.com/ion-channel/ionic/versions"
+)
+
+const (
+	// CVEDataVersion is the version of the CVE JSON record format
+	// vulnerabilities are written in
+	CVEDataVersion = "5.1"
+)
+
+var (
+	// cveCollectionURLs are the package repositories of ecosystems given as
+	// the collection URLs of the packages CVE records affect
+	cveCollectionURLs = map[string]string{
+		versions.EcosystemNPM:      "https://www.npmjs.com",
+		versions.EcosystemPyPI:     "https://pypi.org",
+		versions.EcosystemMaven:    "https://repo.maven.apache.org/maven2",
+		versions.EcosystemGo:       "https://pkg.go.dev",
+		versions.EcosystemCargo:    "https://crates.io",
+		versions.EcosystemRubyGems: "https://rubygems.org",
+		versions.EcosystemNuGet:    "https://www.nuget.org",
+		versions.EcosystemComposer: "https://packagist.org",
+		versions.EcosystemHex:      "https://hex.pm",
+	}
+
+	// cveCollectionHosts maps the hosts of package repositories to their
+	// ecosystems
+	cveCollectionHosts = map[string]string{
+		"npmjs.com":             versions.EcosystemNPM,
+		"registry.npmjs.org":    versions.EcosystemNPM,
+		"pypi.org":              versions.EcosystemPyPI,
+		"pypi.python.org":       versions.EcosystemPyPI,
+		"repo.maven.apache.org": versions.EcosystemMaven,
+		"repo1.maven.org":       versions.EcosystemMaven,
+		"pkg.go.dev":            versions.EcosystemGo,
+		"proxy.golang.org":      versions.EcosystemGo,
+		"crates.io":             versions.EcosystemCargo,
+		"rubygems.org":          versions.EcosystemRubyGems,
+		"nuget.org":             versions.EcosystemNuGet,
+		"api.nuget.org":         versions.EcosystemNuGet,
+		"packagist.org":         versions.EcosystemComposer,
+		"hex.pm":                versions.EcosystemHex,
+	}
+
+	// cveVersionTypes maps ecosystems to the version types of CVE records
+	cveVersionTypes = map[string]string{
+		versions.EcosystemNPM:      "semver",
+		versions.EcosystemGo:       "semver",
+		versions.EcosystemCargo:    "semver",
+		versions.EcosystemNuGet:    "semver",
+		versions.EcosystemComposer: "semver",
+		versions.EcosystemHex:      "semver",
+		versions.EcosystemMaven:    "maven",
+		versions.EcosystemPyPI:     "python",
+	}
+
+	// cveTimeLayouts are the layouts of the timestamps in CVE records, which
+	// may leave out the time zone when it is UTC
+	cveTimeLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05.999999999",
+	}
+)
+
+// CVERecord represents a vulnerability in the CVE JSON 5.x record format
+type CVERecord struct {
+	DataType    string        `json:"dataType"`
+	DataVersion string        `json:"dataVersion"`
+	CVEMetadata CVEMetadata   `json:"cveMetadata"`
+	Containers  CVEContainers `json:"containers"`
+}
+
+// CVEMetadata represents the identifier, state, and dates of a CVE record
+type CVEMetadata struct {
+	CVEID             string     `json:"cveId"`
+	AssignerOrgID     string     `json:"assignerOrgId,omitempty"`
+	AssignerShortName string     `json:"assignerShortName,omitempty"`
+	State             string     `json:"state"`
+	DatePublished     *time.Time `json:"datePublished,omitempty"`
+	DateUpdated       *time.Time `json:"dateUpdated,omitempty"`
+}
+
+// CVEContainers represents the information given about a CVE by its CVE
+// numbering authority and by any authorized data publishers
+type CVEContainers struct {
+	CNA CVEContainer   `json:"cna"`
+	ADP []CVEContainer `json:"adp,omitempty"`
+}
+
+// CVEContainer represents the information a provider gives about a CVE
+type CVEContainer struct {
+	ProviderMetadata CVEProviderMetadata `json:"providerMetadata"`
+	Title            string              `json:"title,omitempty"`
+	Descriptions     []CVEDescription    `json:"descriptions,omitempty"`
+	Affected         []CVEAffected       `json:"affected,omitempty"`
+	Metrics          []CVEMetric         `json:"metrics,omitempty"`
+	References       []CVEReference      `json:"references,omitempty"`
+}
+
+// CVEProviderMetadata represents the organization providing information
+// about a CVE
+type CVEProviderMetadata struct {
+	OrgID     string `json:"orgId"`
+	ShortName string `json:"shortName,omitempty"`
+}
+
+// CVEDescription represents a description of a CVE in a language
+type CVEDescription struct {
+	Lang  string `json:"lang"`
+	Value string `json:"value"`
+}
+
+// CVEAffected represents a product affected by a CVE and its versions
+type CVEAffected struct {
+	Vendor        string       `json:"vendor,omitempty"`
+	Product       string       `json:"product,omitempty"`
+	CollectionURL string       `json:"collectionURL,omitempty"`
+	PackageName   string       `json:"packageName,omitempty"`
+	CPEs          []string     `json:"cpes,omitempty"`
+	DefaultStatus string       `json:"defaultStatus,omitempty"`
+	Versions      []CVEVersion `json:"versions,omitempty"`
+}
+
+// CVEVersion represents a version or range of versions of a product and
+// whether it is affected by a CVE
+type CVEVersion struct {
+	Version         string `json:"version"`
+	Status          string `json:"status"`
+	VersionType     string `json:"versionType,omitempty"`
+	LessThan        string `json:"lessThan,omitempty"`
+	LessThanOrEqual string `json:"lessThanOrEqual,omitempty"`
+}
+
+// CVEMetric represents a CVSS score of a CVE, only one of which is given
+type CVEMetric struct {
+	CVSSv40 *CVECVSS `json:"cvssV4_0,omitempty"`
+	CVSSv31 *CVECVSS `json:"cvssV3_1,omitempty"`
+	CVSSv30 *CVECVSS `json:"cvssV3_0,omitempty"`
+	CVSSv20 *CVECVSS `json:"cvssV2_0,omitempty"`
+}
+
+// CVECVSS represents a CVSS vector of a CVE and its base score
+type CVECVSS struct {
+	Version      string  `json:"version"`
+	VectorString string  `json:"vectorString"`
+	BaseScore    float64 `json:"baseScore"`
+	BaseSeverity string  `json:"baseSeverity,omitempty"`
+}
+
+// CVEReference represents a link to more information about a CVE
+type CVEReference struct {
+	URL  string   `json:"url"`
+	Name string   `json:"name,omitempty"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+// UnmarshalJSON parses the metadata of a CVE record, reading timestamps
+// without a time zone as UTC
+func (m *CVEMetadata) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		CVEID             string `json:"cveId"`
+		AssignerOrgID     string `json:"assignerOrgId"`
+		AssignerShortName string `json:"assignerShortName"`
+		State             string `json:"state"`
+		DatePublished     string `json:"datePublished"`
+		DateUpdated       string `json:"dateUpdated"`
+	}
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	published, err := parseCVETime(raw.DatePublished)
+	if err != nil {
+		return err
+	}
+
+	updated, err := parseCVETime(raw.DateUpdated)
+	if err != nil {
+		return err
+	}
+
+	*m = CVEMetadata{
+		CVEID:             raw.CVEID,
+		AssignerOrgID:     raw.AssignerOrgID,
+		AssignerShortName: raw.AssignerShortName,
+		State:             raw.State,
+		DatePublished:     published,
+		DateUpdated:       updated,
+	}
+
+	return nil
+}
+
+// Vulnerability returns the CVE record as a vulnerability.  The scores of the
+// CVE numbering authority are used, or those of the first data publisher that
+// gives any if it gives none.  The products it affects are named by their
+// vendor and product, or package name, with the range expression of their
+// affected versions as their version, or every version if they are affected
+// by default.  Their external ID is their first CPE, or the package URL of
+// their package if they are in a known package repository.
+func (c *CVERecord) Vulnerability() *Vulnerability {
+	cna := c.Containers.CNA
+
+	v := &Vulnerability{
+		ExternalID: c.CVEMetadata.CVEID,
+		Title:      cna.Title,
+		Source:     []Source{{Name: "CVE"}},
+	}
+
+	if c.CVEMetadata.DatePublished != nil {
+		v.PublishedAt = *c.CVEMetadata.DatePublished
+	}
+
+	if c.CVEMetadata.DateUpdated != nil {
+		v.ModifiedAt = *c.CVEMetadata.DateUpdated
+	}
+
+	for _, d := range cna.Descriptions {
+		if strings.HasPrefix(d.Lang, "en") {
+			v.Summary = d.Value
+			break
+		}
+	}
+
+	metrics := cna.Metrics
+	for _, adp := range c.Containers.ADP {
+		if len(metrics) > 0 {
+			break
+		}
+
+		metrics = adp.Metrics
+	}
+
+	for _, m := range metrics {
+		switch {
+		case m.CVSSv40 != nil:
+			v.ScoreDetails.CVSSv4 = NewV4FromShorthand(m.CVSSv40.VectorString)
+		case m.CVSSv31 != nil:
+			v.ScoreDetails.CVSSv3 = NewV3FromShorthand(m.CVSSv31.VectorString)
+		case m.CVSSv30 != nil && v.ScoreDetails.CVSSv3 == nil:
+			v.ScoreDetails.CVSSv3 = NewV3FromShorthand(m.CVSSv30.VectorString)
+		case m.CVSSv20 != nil:
+			v.ScoreDetails.CVSSv2 = NewV2FromShorthand(m.CVSSv20.VectorString)
+		}
+	}
+
+	v.SetScoreFromDetails()
+
+	for _, ref := range cna.References {
+		r := Reference{URL: ref.URL, Text: ref.Name}
+		if len(ref.Tags) > 0 {
+			r.Type = ref.Tags[0]
+		}
+
+		v.References = append(v.References, r)
+	}
+
+	for _, a := range cna.Affected {
+		if p, ok := a.product(); ok {
+			v.Dependencies = append(v.Dependencies, p)
+		}
+	}
+
+	return v
+}
+
+// CVERecord returns the vulnerability as a published CVE JSON 5.x record.
+// The record's CVE ID is the vulnerability's external ID, or the first of its
+// aliases that is a CVE ID if its external ID is not, since CVE records have
+// no other identifiers.  Ranges of versions a lower and upper bound cannot
+// describe, such as those excluding a version, are left out.
+func (v *Vulnerability) CVERecord() *CVERecord {
+	c := &CVERecord{
+		DataType:    "CVE_RECORD",
+		DataVersion: CVEDataVersion,
+		CVEMetadata: CVEMetadata{
+			CVEID: v.ExternalID,
+			State: "PUBLISHED",
+		},
+	}
+
+	if !strings.HasPrefix(v.ExternalID, "CVE-") {
+		for _, alias := range v.Aliases {
+			if strings.HasPrefix(alias, "CVE-") {
+				c.CVEMetadata.CVEID = alias
+				break
+			}
+		}
+	}
+
+	if !v.PublishedAt.IsZero() {
+		published := v.PublishedAt
+		c.CVEMetadata.DatePublished = &published
+	}
+
+	if !v.ModifiedAt.IsZero() {
+		updated := v.ModifiedAt
+		c.CVEMetadata.DateUpdated = &updated
+	}
+
+	cna := &c.Containers.CNA
+	cna.Title = v.Title
+
+	if v.Summary != "" {
+		cna.Descriptions = []CVEDescription{{Lang: "en", Value: v.Summary}}
+	}
+
+	for _, vector := range v.cvssVectors() {
+		cvss := &CVECVSS{VectorString: vector[1:]}
+
+		switch vector[:1] {
+		case "2":
+			cv, _ := ParseCVSSv2Vector(cvss.VectorString)
+			cvss.Version = "2.0"
+			if cv != nil {
+				cvss.BaseScore = cv.BaseScore()
+			}
+
+			cna.Metrics = append(cna.Metrics, CVEMetric{CVSSv20: cvss})
+		case "3":
+			cv, _ := ParseCVSSv3Vector(cvss.VectorString)
+			cvss.Version = "3.0"
+			if cv != nil {
+				cvss.Version = cv.Version
+				cvss.BaseScore = cv.BaseScore()
+				cvss.BaseSeverity = cv.BaseSeverity()
+			}
+
+			if cvss.Version == "3.1" {
+				cna.Metrics = append(cna.Metrics, CVEMetric{CVSSv31: cvss})
+			} else {
+				cna.Metrics = append(cna.Metrics, CVEMetric{CVSSv30: cvss})
+			}
+		case "4":
+			cv, _ := ParseCVSSv4Vector(cvss.VectorString)
+			cvss.Version = "4.0"
+			if cv != nil {
+				cvss.BaseScore = cv.BaseScore()
+				cvss.BaseSeverity = CVSSv4Severity(cvss.BaseScore)
+			}
+
+			cna.Metrics = append(cna.Metrics, CVEMetric{CVSSv40: cvss})
+		}
+	}
+
+	for _, ref := range v.References {
+		r := CVEReference{URL: ref.URL, Name: ref.Text}
+		if ref.Type != "" {
+			r.Tags = []string{ref.Type}
+		}
+
+		cna.References = append(cna.References, r)
+	}
+
+	for _, p := range v.Dependencies {
+		cna.Affected = append(cna.Affected, cveAffected(p))
+	}
+
+	return c
+}
+
+// product returns the product a CVE affects, and whether any of its versions
+// are affected
+func (a CVEAffected) product() (products.Product, bool) {
+	p := products.Product{
+		Org:  a.Vendor,
+		Name: a.Product,
+	}
+
+	if p.Name == "" {
+		p.Name = a.PackageName
+	}
+
+	ecosystem := collectionEcosystem(a.CollectionURL)
+	if ecosystem == versions.EcosystemMaven && a.Product == "" {
+		if parts := strings.SplitN(p.Name, ":", 2); len(parts) == 2 {
+			p.Org, p.Name = parts[0], parts[1]
+		}
+	}
+
+	switch {
+	case len(a.CPEs) > 0:
+		p.ExternalID = a.CPEs[0]
+	case ecosystem != "" && a.PackageName != "":
+		p.ExternalID = "pkg:" + ecosystem + "/" + strings.Replace(a.PackageName, ":", "/", 1)
+	}
+
+	ranges := []versions.Range{}
+	for _, ver := range a.Versions {
+		if ver.Status != "affected" {
+			continue
+		}
+
+		r := versions.Range{}
+		switch {
+		case ver.LessThan == "" && ver.LessThanOrEqual == "":
+			r = append(r, versions.Constraint{Operator: "=", Version: ver.Version})
+		default:
+			if ver.Version != "" && ver.Version != "0" {
+				r = append(r, versions.Constraint{Operator: ">=", Version: ver.Version})
+			}
+
+			if ver.LessThan != "" && ver.LessThan != "*" {
+				r = append(r, versions.Constraint{Operator: "<", Version: ver.LessThan})
+			} else if ver.LessThanOrEqual != "" && ver.LessThanOrEqual != "*" {
+				r = append(r, versions.Constraint{Operator: "<=", Version: ver.LessThanOrEqual})
+			}
+		}
+
+		ranges = append(ranges, r)
+	}
+
+	if a.DefaultStatus == "affected" {
+		except, ok := exceptVersions(ecosystem, a.Versions)
+		if !ok {
+			return p, false
+		}
+
+		ranges = except
+	}
+
+	if len(ranges) == 0 {
+		return p, false
+	}
+
+	p.Version = versions.FormatRanges(ranges)
+
+	return p, p.Name != ""
+}
+
+// cveSpan is a span of versions a CVE lists, from an inclusive lower bound to
+// an upper bound.  An empty bound leaves that end of the span open.
+type cveSpan struct {
+	lower     string
+	upper     string
+	inclusive bool
+}
+
+// exceptVersions returns the ranges of versions of a product a CVE affects by
+// default, which are every version except those it lists with another status.
+// It is not ok if such a version cannot be read as a span of versions, such as
+// a git commit, since the product could then only be described by widening it
+// to versions that are not affected.
+func exceptVersions(ecosystem string, vers []CVEVersion) ([]versions.Range, bool) {
+	spans := []cveSpan{}
+	for _, ver := range vers {
+		if ver.Status == "affected" {
+			continue
+		}
+
+		if ver.VersionType == "git" || ver.Version == "" || ver.Version == "*" {
+			return nil, false
+		}
+
+		s := cveSpan{lower: ver.Version, upper: ver.Version, inclusive: true}
+		switch {
+		case ver.LessThan != "":
+			s.upper, s.inclusive = ver.LessThan, false
+		case ver.LessThanOrEqual != "":
+			s.upper = ver.LessThanOrEqual
+		}
+
+		if s.lower == "0" && s.upper != s.lower {
+			s.lower = ""
+		}
+
+		if s.upper == "*" {
+			s.upper = ""
+		}
+
+		spans = append(spans, s)
+	}
+
+	if len(spans) == 0 {
+		return []versions.Range{{}}, true
+	}
+
+	sort.SliceStable(spans, func(i, j int) bool {
+		if spans[i].lower == "" || spans[j].lower == "" {
+			return spans[i].lower == "" && spans[j].lower != ""
+		}
+
+		return versions.Compare(ecosystem, spans[i].lower, spans[j].lower) < 0
+	})
+
+	// end is the upper bound of the versions excepted so far, and the affected
+	// versions between it and the next span are a range of their own
+	ranges := []versions.Range{}
+	end := versions.Constraint{}
+	for i, s := range spans {
+		if s.lower != "" && (i == 0 || versions.Compare(ecosystem, s.lower, end.Version) > 0) {
+			r := versions.Range{}
+			if i > 0 {
+				r = append(r, end)
+			}
+
+			ranges = append(ranges, append(r, versions.Constraint{Operator: "<", Version: s.lower}))
+		}
+
+		if s.upper == "" {
+			return ranges, true
+		}
+
+		cmp := 1
+		if i > 0 {
+			cmp = versions.Compare(ecosystem, s.upper, end.Version)
+		}
+
+		if cmp > 0 || cmp == 0 && s.inclusive {
+			end = versions.Constraint{Operator: ">=", Version: s.upper}
+			if s.inclusive {
+				end.Operator = ">"
+			}
+		}
+	}
+
+	return append(ranges, versions.Range{end}), true
+}
+
+// cveAffected returns a product as a product a CVE affects
+func cveAffected(p products.Product) CVEAffected {
+	a := CVEAffected{
+		Vendor:        p.Org,
+		Product:       p.Name,
+		DefaultStatus: "unaffected",
+	}
+
+	ecosystem := purlEcosystem(p.ExternalID)
+	if collectionURL, ok := cveCollectionURLs[ecosystem]; ok {
+		a.CollectionURL = collectionURL
+		a.PackageName = packageName(p, ecosystem)
+	}
+
+	if strings.HasPrefix(p.ExternalID, "cpe:") {
+		a.CPEs = []string{p.ExternalID}
+	}
+
+	versionType, ok := cveVersionTypes[ecosystem]
+	if !ok {
+		versionType = "custom"
+	}
+
+	for _, r := range productRanges(p) {
+		lower, upper, op, ok := bounds(r)
+		switch {
+		case !ok:
+			continue
+		case op == "=":
+			a.Versions = append(a.Versions, CVEVersion{Version: lower, Status: "affected"})
+			continue
+		case lower == "" && op == "":
+			a.DefaultStatus = "affected"
+			continue
+		case lower == "":
+			lower = "0"
+		}
+
+		ver := CVEVersion{Version: lower, Status: "affected", VersionType: versionType}
+		switch op {
+		case "<":
+			ver.LessThan = upper
+		case "<=":
+			ver.LessThanOrEqual = upper
+		default:
+			ver.LessThanOrEqual = "*"
+		}
+
+		a.Versions = append(a.Versions, ver)
+	}
+
+	return a
+}
+
+// collectionEcosystem returns the ecosystem of the package repository at a
+// collection URL, or nothing if it is not a known package repository
+func collectionEcosystem(collectionURL string) string {
+	u, err := url.Parse(collectionURL)
+	if err != nil {
+		return ""
+	}
+
+	return cveCollectionHosts[strings.TrimPrefix(strings.ToLower(u.Host), "www.")]
+}
+
+// parseCVETime parses a timestamp of a CVE record, which is nil if it is not
+// given
+func parseCVETime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	for _, layout := range cveTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid timestamp %q", s)
+}
@@ -0,0 +1,196 @@
+package vulnerabilities
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic/products"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	sampleCVERecord = `{
+		"dataType": "CVE_RECORD",
+		"dataVersion": "5.0",
+		"cveMetadata": {
+			"cveId": "CVE-2021-44228",
+			"assignerOrgId": "f0158376-9dc2-43b6-827c-5f631a4d8d09",
+			"assignerShortName": "apache",
+			"state": "PUBLISHED",
+			"dateReserved": "2021-11-26T00:00:00",
+			"datePublished": "2021-12-10T00:00:00",
+			"dateUpdated": "2024-08-04T04:17:23.123Z"
+		},
+		"containers": {
+			"cna": {
+				"providerMetadata": {"orgId": "f0158376-9dc2-43b6-827c-5f631a4d8d09", "shortName": "apache"},
+				"title": "Apache Log4j2 JNDI features do not protect against attacker controlled LDAP and other JNDI related endpoints",
+				"descriptions": [{"lang": "en", "value": "Apache Log4j2 2.0-beta9 through 2.15.0 JNDI features do not protect against attacker controlled endpoints."}],
+				"affected": [{
+					"vendor": "Apache Software Foundation",
+					"product": "Apache Log4j2",
+					"defaultStatus": "unaffected",
+					"versions": [
+						{"version": "2.0-beta9", "lessThan": "2.3.1", "status": "affected", "versionType": "custom"},
+						{"version": "2.4", "lessThan": "2.12.2", "status": "affected", "versionType": "custom"},
+						{"version": "2.13.0", "lessThanOrEqual": "2.15.0", "status": "affected", "versionType": "custom"},
+						{"version": "2.16.0", "status": "unaffected"}
+					]
+				}, {
+					"collectionURL": "https://repo1.maven.org/maven2",
+					"packageName": "org.apache.logging.log4j:log4j-core",
+					"versions": [{"version": "2.14.1", "status": "affected"}]
+				}, {
+					"vendor": "Apache Software Foundation",
+					"product": "Apache Chainsaw",
+					"defaultStatus": "unknown"
+				}],
+				"references": [
+					{"url": "https://logging.apache.org/log4j/2.x/security.html", "name": "Log4j security", "tags": ["vendor-advisory"]},
+					{"url": "http://www.openwall.com/lists/oss-security/2021/12/10/1"}
+				]
+			},
+			"adp": [{
+				"providerMetadata": {"orgId": "134c704f-9b21-4f2e-91b3-4a467353bcc0", "shortName": "CISA-ADP"},
+				"metrics": [{"cvssV3_1": {"version": "3.1", "vectorString": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H", "baseScore": 10, "baseSeverity": "CRITICAL"}}]
+			}]
+		}
+	}`
+)
+
+func TestCVERecord(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("CVE Record", func() {
+		g.It("should convert a CVE record to a vulnerability", func() {
+			var c CVERecord
+			Expect(json.Unmarshal([]byte(sampleCVERecord), &c)).To(BeNil())
+
+			v := c.Vulnerability()
+			Expect(v.ExternalID).To(Equal("CVE-2021-44228"))
+			Expect(v.Title).To(HavePrefix("Apache Log4j2 JNDI features"))
+			Expect(v.Summary).To(HavePrefix("Apache Log4j2 2.0-beta9 through 2.15.0"))
+			Expect(v.Source).To(Equal([]Source{{Name: "CVE"}}))
+			Expect(v.PublishedAt).To(Equal(time.Date(2021, 12, 10, 0, 0, 0, 0, time.UTC)))
+			Expect(v.ModifiedAt.Equal(time.Date(2024, 8, 4, 4, 17, 23, 123000000, time.UTC))).To(BeTrue())
+			Expect(v.Score).To(Equal("10.0"))
+			Expect(v.Vector).To(Equal("CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"))
+			Expect(v.References).To(Equal([]Reference{
+				{Type: "vendor-advisory", URL: "https://logging.apache.org/log4j/2.x/security.html", Text: "Log4j security"},
+				{URL: "http://www.openwall.com/lists/oss-security/2021/12/10/1"},
+			}))
+			Expect(v.Dependencies).To(Equal([]products.Product{{
+				Org:     "Apache Software Foundation",
+				Name:    "Apache Log4j2",
+				Version: ">=2.0-beta9, <2.3.1 || >=2.4, <2.12.2 || >=2.13.0, <=2.15.0",
+			}, {
+				Org:        "org.apache.logging.log4j",
+				Name:       "log4j-core",
+				Version:    "=2.14.1",
+				ExternalID: "pkg:maven/org.apache.logging.log4j/log4j-core",
+			}}))
+		})
+
+		g.It("should leave out the versions of a product not affected by default", func() {
+			var c CVERecord
+			Expect(json.Unmarshal([]byte(`{"cveMetadata": {"cveId": "CVE-2024-0003"}, "containers": {"cna": {"affected": [{
+				"vendor": "example", "product": "fixed-in-two",
+				"defaultStatus": "affected",
+				"versions": [{"version": "2.0", "status": "unaffected", "lessThan": "*"}]
+			}, {
+				"vendor": "example", "product": "backported",
+				"defaultStatus": "affected",
+				"versions": [
+					{"version": "3.0", "status": "unaffected", "lessThanOrEqual": "*", "versionType": "semver"},
+					{"version": "1.5.0", "status": "unaffected"},
+					{"version": "1.0", "status": "affected", "lessThan": "2.0"},
+					{"version": "2.1.0", "status": "unaffected", "lessThan": "2.3.0", "versionType": "semver"},
+					{"version": "2.2.0", "status": "unknown", "lessThanOrEqual": "2.4.0", "versionType": "semver"}
+				]
+			}, {
+				"vendor": "example", "product": "every-version",
+				"defaultStatus": "affected"
+			}, {
+				"vendor": "example", "product": "by-commit",
+				"defaultStatus": "affected",
+				"versions": [{"version": "0", "status": "unaffected", "lessThan": "abcdef", "versionType": "git"}]
+			}]}}}`), &c)).To(BeNil())
+
+			v := c.Vulnerability()
+			Expect(v.Dependencies).To(Equal([]products.Product{
+				{Org: "example", Name: "fixed-in-two", Version: "<2.0"},
+				{Org: "example", Name: "backported", Version: "<1.5.0 || >1.5.0, <2.1.0 || >2.4.0, <3.0"},
+				{Org: "example", Name: "every-version", Version: "*"},
+			}))
+		})
+
+		g.It("should reject invalid timestamps", func() {
+			var c CVERecord
+			err := json.Unmarshal([]byte(`{"cveMetadata": {"cveId": "CVE-2021-44228", "datePublished": "yesterday"}}`), &c)
+			Expect(err).NotTo(BeNil())
+		})
+
+		g.It("should round trip a vulnerability", func() {
+			v := &Vulnerability{
+				ExternalID:  "CVE-2024-0001",
+				Title:       "Path traversal",
+				Summary:     "Archive extraction writes outside of the target directory.",
+				PublishedAt: time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC),
+				ModifiedAt:  time.Date(2024, 3, 5, 8, 30, 0, 0, time.UTC),
+				ScoreDetails: ScoreDetails{
+					CVSSv2: NewV2FromShorthand("AV:N/AC:L/Au:N/C:P/I:P/A:P"),
+					CVSSv3: NewV3FromShorthand("CVSS:3.0/AV:N/AC:L/PR:N/UI:R/S:U/C:H/I:H/A:N"),
+					CVSSv4: NewV4FromShorthand("CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N"),
+				},
+				References: []Reference{
+					{Type: "vendor-advisory", URL: "https://example.com/advisory", Text: "Advisory"},
+					{URL: "https://example.com/blog"},
+				},
+				Dependencies: []products.Product{
+					{Org: "com.example", Name: "unpacker", Version: ">=1.0, <1.4.2 || >=2.0-rc1, <=2.0.3 || =2.1.0", ExternalID: "pkg:maven/com.example/unpacker"},
+					{Name: "unpacker", Version: "<0.9.1 || >=3.0.0", ExternalID: "pkg:pypi/unpacker"},
+					{Org: "apache", Name: "http_server", Version: "=2.4.49", ExternalID: "cpe:2.3:a:apache:http_server:2.4.49:*:*:*:*:*:*:*"},
+					{Org: "example", Name: "appliance", Version: "*"},
+				},
+			}
+
+			b, err := json.Marshal(v.CVERecord())
+			Expect(err).To(BeNil())
+			Expect(string(b)).To(ContainSubstring(`"dataVersion":"5.1"`))
+			Expect(string(b)).To(ContainSubstring(`"state":"PUBLISHED"`))
+			Expect(string(b)).To(ContainSubstring(`"collectionURL":"https://repo.maven.apache.org/maven2","packageName":"com.example:unpacker"`))
+			Expect(string(b)).To(ContainSubstring(`{"version":"2.0-rc1","status":"affected","versionType":"maven","lessThanOrEqual":"2.0.3"}`))
+			Expect(string(b)).To(ContainSubstring(`{"version":"3.0.0","status":"affected","versionType":"python","lessThanOrEqual":"*"}`))
+			Expect(string(b)).To(ContainSubstring(`"cvssV3_0":{"version":"3.0"`))
+
+			var c CVERecord
+			Expect(json.Unmarshal(b, &c)).To(BeNil())
+
+			rt := c.Vulnerability()
+			Expect(rt.ExternalID).To(Equal(v.ExternalID))
+			Expect(rt.Title).To(Equal(v.Title))
+			Expect(rt.Summary).To(Equal(v.Summary))
+			Expect(rt.PublishedAt.Equal(v.PublishedAt)).To(BeTrue())
+			Expect(rt.ModifiedAt.Equal(v.ModifiedAt)).To(BeTrue())
+			Expect(rt.ScoreDetails).To(Equal(v.ScoreDetails))
+			Expect(rt.Score).To(Equal("9.3"))
+			Expect(rt.References).To(Equal(v.References))
+			Expect(rt.Dependencies).To(Equal(v.Dependencies))
+		})
+
+		g.It("should use a CVE alias as the CVE ID", func() {
+			v := &Vulnerability{
+				ExternalID: "GHSA-aaaa-bbbb-cccc",
+				Aliases:    []string{"PYSEC-2024-1", "CVE-2024-0001"},
+			}
+
+			c := v.CVERecord()
+			Expect(c.CVEMetadata.CVEID).To(Equal("CVE-2024-0001"))
+			Expect(c.CVEMetadata.DatePublished).To(BeNil())
+			Expect(c.Containers.CNA.Descriptions).To(BeEmpty())
+		})
+	})
+}
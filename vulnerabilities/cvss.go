@@ -0,0 +1,496 @@
+package vulnerabilities
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	// ErrInvalidVector is returned when a CVSS vector string cannot be parsed
+	// or holds metrics its version does not have
+	ErrInvalidVector = fmt.Errorf("invalid CVSS vector")
+)
+
+// cvssMetric is a metric of a CVSS vector and the values it may take.  An
+// optional metric takes its first value when it is not given.
+type cvssMetric struct {
+	name     string
+	values   []string
+	optional bool
+}
+
+// cvssv3Metrics are the metrics of a CVSS v3 vector in the order they are
+// written
+var cvssv3Metrics = []cvssMetric{
+	{"AV", []string{"N", "A", "L", "P"}, false},
+	{"AC", []string{"L", "H"}, false},
+	{"PR", []string{"N", "L", "H"}, false},
+	{"UI", []string{"N", "R"}, false},
+	{"S", []string{"U", "C"}, false},
+	{"C", []string{"H", "L", "N"}, false},
+	{"I", []string{"H", "L", "N"}, false},
+	{"A", []string{"H", "L", "N"}, false},
+	{"E", []string{"X", "H", "F", "P", "U"}, true},
+	{"RL", []string{"X", "U", "W", "T", "O"}, true},
+	{"RC", []string{"X", "C", "R", "U"}, true},
+	{"CR", []string{"X", "H", "M", "L"}, true},
+	{"IR", []string{"X", "H", "M", "L"}, true},
+	{"AR", []string{"X", "H", "M", "L"}, true},
+	{"MAV", []string{"X", "N", "A", "L", "P"}, true},
+	{"MAC", []string{"X", "L", "H"}, true},
+	{"MPR", []string{"X", "N", "L", "H"}, true},
+	{"MUI", []string{"X", "N", "R"}, true},
+	{"MS", []string{"X", "U", "C"}, true},
+	{"MC", []string{"X", "H", "L", "N"}, true},
+	{"MI", []string{"X", "H", "L", "N"}, true},
+	{"MA", []string{"X", "H", "L", "N"}, true},
+}
+
+// cvssv3Weights are the weights of CVSS v3 metric values.  Modified metrics
+// use the weights of the metric they modify, and privileges required weighs
+// more when the scope is changed.
+var cvssv3Weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"PR": {"N": 0.85, "L": 0.62, "H": 0.27},
+	"UI": {"N": 0.85, "R": 0.62},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+	"E":  {"X": 1, "H": 1, "F": 0.97, "P": 0.94, "U": 0.91},
+	"RL": {"X": 1, "U": 1, "W": 0.97, "T": 0.96, "O": 0.95},
+	"RC": {"X": 1, "C": 1, "R": 0.96, "U": 0.92},
+	"CR": {"X": 1, "H": 1.5, "M": 1, "L": 0.5},
+	"IR": {"X": 1, "H": 1.5, "M": 1, "L": 0.5},
+	"AR": {"X": 1, "H": 1.5, "M": 1, "L": 0.5},
+}
+
+var cvssv3ChangedPRWeights = map[string]float64{"N": 0.85, "L": 0.68, "H": 0.5}
+
+// cvssv2Metrics are the metrics of a CVSS v2 vector in the order they are
+// written
+var cvssv2Metrics = []cvssMetric{
+	{"AV", []string{"L", "A", "N"}, false},
+	{"AC", []string{"H", "M", "L"}, false},
+	{"Au", []string{"M", "S", "N"}, false},
+	{"C", []string{"N", "P", "C"}, false},
+	{"I", []string{"N", "P", "C"}, false},
+	{"A", []string{"N", "P", "C"}, false},
+	{"E", []string{"ND", "U", "POC", "F", "H"}, true},
+	{"RL", []string{"ND", "OF", "TF", "W", "U"}, true},
+	{"RC", []string{"ND", "UC", "UR", "C"}, true},
+	{"CDP", []string{"ND", "N", "L", "LM", "MH", "H"}, true},
+	{"TD", []string{"ND", "N", "L", "M", "H"}, true},
+	{"CR", []string{"ND", "L", "M", "H"}, true},
+	{"IR", []string{"ND", "L", "M", "H"}, true},
+	{"AR", []string{"ND", "L", "M", "H"}, true},
+}
+
+// cvssv2Weights are the weights of CVSS v2 metric values
+var cvssv2Weights = map[string]map[string]float64{
+	"AV":  {"L": 0.395, "A": 0.646, "N": 1},
+	"AC":  {"H": 0.35, "M": 0.61, "L": 0.71},
+	"Au":  {"M": 0.45, "S": 0.56, "N": 0.704},
+	"C":   {"N": 0, "P": 0.275, "C": 0.66},
+	"I":   {"N": 0, "P": 0.275, "C": 0.66},
+	"A":   {"N": 0, "P": 0.275, "C": 0.66},
+	"E":   {"ND": 1, "U": 0.85, "POC": 0.9, "F": 0.95, "H": 1},
+	"RL":  {"ND": 1, "OF": 0.87, "TF": 0.9, "W": 0.95, "U": 1},
+	"RC":  {"ND": 1, "UC": 0.9, "UR": 0.95, "C": 1},
+	"CDP": {"ND": 0, "N": 0, "L": 0.1, "LM": 0.3, "MH": 0.4, "H": 0.5},
+	"TD":  {"ND": 1, "N": 0, "L": 0.25, "M": 0.75, "H": 1},
+	"CR":  {"ND": 1, "L": 0.5, "M": 1, "H": 1.51},
+	"IR":  {"ND": 1, "L": 0.5, "M": 1, "H": 1.51},
+	"AR":  {"ND": 1, "L": 0.5, "M": 1, "H": 1.51},
+}
+
+// CVSSv3Vector is a CVSS v3.0 or v3.1 vector, from which its base, temporal,
+// and environmental scores can be calculated
+type CVSSv3Vector struct {
+	// Version is the CVSS version of the vector, either 3.0 or 3.1
+	Version string
+	metrics map[string]string
+}
+
+// ParseCVSSv3Vector parses a CVSS v3.0 or v3.1 vector string, such as
+// CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H.  The vector must give every
+// base metric, and may give any temporal and environmental metrics.
+func ParseCVSSv3Vector(vector string) (*CVSSv3Vector, error) {
+	var version string
+	switch {
+	case strings.HasPrefix(vector, "CVSS:3.0/"):
+		version = "3.0"
+	case strings.HasPrefix(vector, "CVSS:3.1/"):
+		version = "3.1"
+	default:
+		return nil, fmt.Errorf("%w %q: must begin with CVSS:3.0/ or CVSS:3.1/", ErrInvalidVector, vector)
+	}
+
+	metrics, err := parseMetrics(vector, vector[len("CVSS:3.x/"):], cvssv3Metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CVSSv3Vector{Version: version, metrics: metrics}, nil
+}
+
+// Get returns the value of a metric of the vector, which is X for temporal
+// and environmental metrics that are not defined
+func (v *CVSSv3Vector) Get(metric string) string {
+	return v.metrics[metric]
+}
+
+// Set changes the value of a metric of the vector, such as setting the
+// environmental requirements to rescore a vulnerability with
+func (v *CVSSv3Vector) Set(metric, value string) error {
+	return setMetric(v.metrics, metric, value, cvssv3Metrics)
+}
+
+// String returns the vector string of the vector, in the order of the
+// specification and leaving out metrics that are not defined
+func (v *CVSSv3Vector) String() string {
+	return "CVSS:" + v.Version + "/" + formatMetrics(v.metrics, cvssv3Metrics)
+}
+
+// BaseScore returns the base score of the vector
+func (v *CVSSv3Vector) BaseScore() float64 {
+	return v.score(v.metrics["S"], v.weight("AV"), v.weight("AC"), v.weight("PR"), v.weight("UI"), v.iss(), 1, false)
+}
+
+// TemporalScore returns the temporal score of the vector, which is the base
+// score if it has no temporal metrics
+func (v *CVSSv3Vector) TemporalScore() float64 {
+	return v.roundUp(v.BaseScore() * v.weight("E") * v.weight("RL") * v.weight("RC"))
+}
+
+// EnvironmentalScore returns the environmental score of the vector, which
+// accounts for its base, temporal, and environmental metrics.  Undefined
+// modified metrics take the values of the base metrics they modify.
+func (v *CVSSv3Vector) EnvironmentalScore() float64 {
+	c := v.modifiedWeight("C") * v.weight("CR")
+	i := v.modifiedWeight("I") * v.weight("IR")
+	a := v.modifiedWeight("A") * v.weight("AR")
+	miss := math.Min(1-(1-c)*(1-i)*(1-a), 0.915)
+
+	temporal := v.weight("E") * v.weight("RL") * v.weight("RC")
+
+	return v.score(v.modified("S"), v.modifiedWeight("AV"), v.modifiedWeight("AC"), v.modifiedWeight("PR"), v.modifiedWeight("UI"), miss, temporal, true)
+}
+
+// BaseSeverity returns the severity of the vector's base score
+func (v *CVSSv3Vector) BaseSeverity() string {
+	return CVSSv3Severity(v.BaseScore())
+}
+
+// Severity returns the severity of the vector's environmental score, which
+// accounts for all of its metrics
+func (v *CVSSv3Vector) Severity() string {
+	return CVSSv3Severity(v.EnvironmentalScore())
+}
+
+// CVSSv3 returns the expanded representation of the vector, with its base
+// score and severity
+func (v *CVSSv3Vector) CVSSv3() *CVSSv3 {
+	return NewV3FromShorthand(v.String())
+}
+
+// score calculates a base score, or an environmental score from modified
+// metrics, for the scope, exploitability weights, and impact subscore.  The
+// temporal weight is applied after rounding.  CVSS v3.1 changed the impact of
+// a changed scope for environmental scores only.
+func (v *CVSSv3Vector) score(scope string, av, ac, pr, ui, iss, temporal float64, environmental bool) float64 {
+	var impact float64
+	if scope == "C" {
+		if environmental && v.Version == "3.1" {
+			impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss*0.9731-0.02, 13)
+		} else {
+			impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+		}
+	} else {
+		impact = 6.42 * iss
+	}
+
+	if impact <= 0 {
+		return 0
+	}
+
+	exploitability := 8.22 * av * ac * pr * ui
+
+	base := impact + exploitability
+	if scope == "C" {
+		base *= 1.08
+	}
+
+	score := v.roundUp(math.Min(base, 10))
+	if temporal != 1 {
+		score = v.roundUp(score * temporal)
+	}
+
+	return score
+}
+
+// iss returns the impact subscore of the base metrics
+func (v *CVSSv3Vector) iss() float64 {
+	return 1 - (1-v.weight("C"))*(1-v.weight("I"))*(1-v.weight("A"))
+}
+
+// weight returns the weight of a metric's value
+func (v *CVSSv3Vector) weight(metric string) float64 {
+	if metric == "PR" && v.metrics["S"] == "C" {
+		return cvssv3ChangedPRWeights[v.metrics["PR"]]
+	}
+
+	return cvssv3Weights[metric][v.metrics[metric]]
+}
+
+// modified returns the value of the modified metric for a base metric, which
+// is the base metric's value if the modified metric is not defined
+func (v *CVSSv3Vector) modified(metric string) string {
+	if value := v.metrics["M"+metric]; value != "X" {
+		return value
+	}
+
+	return v.metrics[metric]
+}
+
+// modifiedWeight returns the weight of the modified value of a base metric
+func (v *CVSSv3Vector) modifiedWeight(metric string) float64 {
+	if metric == "PR" && v.modified("S") == "C" {
+		return cvssv3ChangedPRWeights[v.modified("PR")]
+	}
+
+	return cvssv3Weights[metric][v.modified(metric)]
+}
+
+// roundUp rounds a score up to one decimal place, the way the vector's
+// version of the specification does
+func (v *CVSSv3Vector) roundUp(score float64) float64 {
+	if v.Version == "3.0" {
+		return math.Ceil(score*10) / 10
+	}
+
+	// CVSS v3.1 rounds up through integers to avoid floating point errors
+	i := int64(math.Round(score * 100000))
+	if i%10000 == 0 {
+		return float64(i) / 100000
+	}
+
+	return float64(i/10000+1) / 10
+}
+
+// CVSSv3Severity returns the qualitative severity of a CVSS v3 score: NONE,
+// LOW, MEDIUM, HIGH, or CRITICAL
+func CVSSv3Severity(score float64) string {
+	switch {
+	case score >= 9:
+		return "CRITICAL"
+	case score >= 7:
+		return "HIGH"
+	case score >= 4:
+		return "MEDIUM"
+	case score > 0:
+		return "LOW"
+	default:
+		return "NONE"
+	}
+}
+
+// CVSSv2Vector is a CVSS v2 vector, from which its base, temporal, and
+// environmental scores can be calculated
+type CVSSv2Vector struct {
+	metrics map[string]string
+}
+
+// ParseCVSSv2Vector parses a CVSS v2 vector string, such as
+// AV:N/AC:L/Au:N/C:P/I:P/A:P, which may be wrapped in parentheses.  The vector
+// must give every base metric, and may give any temporal and environmental
+// metrics.
+func ParseCVSSv2Vector(vector string) (*CVSSv2Vector, error) {
+	metrics := vector
+	if strings.HasPrefix(metrics, "(") && strings.HasSuffix(metrics, ")") {
+		metrics = metrics[1 : len(metrics)-1]
+	}
+
+	m, err := parseMetrics(vector, metrics, cvssv2Metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CVSSv2Vector{metrics: m}, nil
+}
+
+// Get returns the value of a metric of the vector, which is ND for temporal
+// and environmental metrics that are not defined
+func (v *CVSSv2Vector) Get(metric string) string {
+	return v.metrics[metric]
+}
+
+// Set changes the value of a metric of the vector, such as setting the
+// environmental requirements to rescore a vulnerability with
+func (v *CVSSv2Vector) Set(metric, value string) error {
+	return setMetric(v.metrics, metric, value, cvssv2Metrics)
+}
+
+// String returns the vector string of the vector, in the order of the
+// specification and leaving out metrics that are not defined
+func (v *CVSSv2Vector) String() string {
+	return formatMetrics(v.metrics, cvssv2Metrics)
+}
+
+// BaseScore returns the base score of the vector
+func (v *CVSSv2Vector) BaseScore() float64 {
+	return v.score(v.impact(1, 1, 1))
+}
+
+// TemporalScore returns the temporal score of the vector, which is the base
+// score if it has no temporal metrics
+func (v *CVSSv2Vector) TemporalScore() float64 {
+	return v.temporal(v.BaseScore())
+}
+
+// EnvironmentalScore returns the environmental score of the vector, which
+// accounts for its base, temporal, and environmental metrics.  It is the
+// temporal score if the vector has no environmental metrics.
+func (v *CVSSv2Vector) EnvironmentalScore() float64 {
+	if v.metrics["CDP"] == "ND" && v.metrics["TD"] == "ND" && v.metrics["CR"] == "ND" && v.metrics["IR"] == "ND" && v.metrics["AR"] == "ND" {
+		return v.TemporalScore()
+	}
+
+	impact := math.Min(10, v.impact(v.weight("CR"), v.weight("IR"), v.weight("AR")))
+	temporal := v.temporal(v.score(impact))
+
+	return round1((temporal + (10-temporal)*v.weight("CDP")) * v.weight("TD"))
+}
+
+// BaseSeverity returns the severity of the vector's base score
+func (v *CVSSv2Vector) BaseSeverity() string {
+	return CVSSv2Severity(v.BaseScore())
+}
+
+// Severity returns the severity of the vector's environmental score, which
+// accounts for all of its metrics
+func (v *CVSSv2Vector) Severity() string {
+	return CVSSv2Severity(v.EnvironmentalScore())
+}
+
+// CVSSv2 returns the expanded representation of the vector, with its base
+// score
+func (v *CVSSv2Vector) CVSSv2() *CVSSv2 {
+	return NewV2FromShorthand(v.String())
+}
+
+// impact returns the impact subscore, weighing each impact by its requirement
+func (v *CVSSv2Vector) impact(cr, ir, ar float64) float64 {
+	return 10.41 * (1 - (1-v.weight("C")*cr)*(1-v.weight("I")*ir)*(1-v.weight("A")*ar))
+}
+
+// score returns the base score for an impact subscore
+func (v *CVSSv2Vector) score(impact float64) float64 {
+	if impact == 0 {
+		return 0
+	}
+
+	exploitability := 20 * v.weight("AV") * v.weight("AC") * v.weight("Au")
+
+	return round1((0.6*impact + 0.4*exploitability - 1.5) * 1.176)
+}
+
+// temporal returns the temporal score for a base score
+func (v *CVSSv2Vector) temporal(base float64) float64 {
+	return round1(base * v.weight("E") * v.weight("RL") * v.weight("RC"))
+}
+
+// weight returns the weight of a metric's value
+func (v *CVSSv2Vector) weight(metric string) float64 {
+	return cvssv2Weights[metric][v.metrics[metric]]
+}
+
+// CVSSv2Severity returns the qualitative severity of a CVSS v2 score, as NVD
+// gives it: LOW, MEDIUM, or HIGH
+func CVSSv2Severity(score float64) string {
+	switch {
+	case score >= 7:
+		return "HIGH"
+	case score >= 4:
+		return "MEDIUM"
+	default:
+		return "LOW"
+	}
+}
+
+// round1 rounds a score to one decimal place
+func round1(score float64) float64 {
+	return math.Round(score*10) / 10
+}
+
+// parseMetrics parses the slash separated metrics of a vector, checking that
+// each is known and given once with a valid value, and that the vector gives
+// every metric that is not optional
+func parseMetrics(vector, metrics string, defs []cvssMetric) (map[string]string, error) {
+	m := make(map[string]string)
+
+	for _, part := range strings.Split(metrics, "/") {
+		kv := strings.Split(part, ":")
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w %q: malformed metric %q", ErrInvalidVector, vector, part)
+		}
+
+		if _, ok := m[kv[0]]; ok {
+			return nil, fmt.Errorf("%w %q: metric %v is given more than once", ErrInvalidVector, vector, kv[0])
+		}
+
+		err := setMetric(m, kv[0], kv[1], defs)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %v", ErrInvalidVector, vector, err.Error())
+		}
+	}
+
+	for _, def := range defs {
+		if _, ok := m[def.name]; !ok {
+			if !def.optional {
+				return nil, fmt.Errorf("%w %q: missing metric %v", ErrInvalidVector, vector, def.name)
+			}
+
+			m[def.name] = def.values[0]
+		}
+	}
+
+	return m, nil
+}
+
+// setMetric sets the value of a metric, if it is one of the metric's values
+func setMetric(m map[string]string, metric, value string, defs []cvssMetric) error {
+	for _, def := range defs {
+		if def.name != metric {
+			continue
+		}
+
+		for _, v := range def.values {
+			if v == value {
+				m[metric] = value
+				return nil
+			}
+		}
+
+		return fmt.Errorf("invalid value %q for metric %v", value, metric)
+	}
+
+	return fmt.Errorf("unknown metric %q", metric)
+}
+
+// formatMetrics writes metrics in the order of their definitions, leaving out
+// optional metrics that are not defined
+func formatMetrics(m map[string]string, defs []cvssMetric) string {
+	var parts []string
+	for _, def := range defs {
+		value := m[def.name]
+		if def.optional && value == def.values[0] {
+			continue
+		}
+
+		parts = append(parts, def.name+":"+value)
+	}
+
+	return strings.Join(parts, "/")
+}
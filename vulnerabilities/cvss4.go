@@ -0,0 +1,397 @@
+package vulnerabilities
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// cvssv4Metrics are the metrics of a CVSS v4.0 vector in the order they are
+// written
+var cvssv4Metrics = []cvssMetric{
+	{"AV", []string{"N", "A", "L", "P"}, false},
+	{"AC", []string{"L", "H"}, false},
+	{"AT", []string{"N", "P"}, false},
+	{"PR", []string{"N", "L", "H"}, false},
+	{"UI", []string{"N", "P", "A"}, false},
+	{"VC", []string{"H", "L", "N"}, false},
+	{"VI", []string{"H", "L", "N"}, false},
+	{"VA", []string{"H", "L", "N"}, false},
+	{"SC", []string{"H", "L", "N"}, false},
+	{"SI", []string{"H", "L", "N"}, false},
+	{"SA", []string{"H", "L", "N"}, false},
+	{"E", []string{"X", "A", "P", "U"}, true},
+	{"CR", []string{"X", "H", "M", "L"}, true},
+	{"IR", []string{"X", "H", "M", "L"}, true},
+	{"AR", []string{"X", "H", "M", "L"}, true},
+	{"MAV", []string{"X", "N", "A", "L", "P"}, true},
+	{"MAC", []string{"X", "L", "H"}, true},
+	{"MAT", []string{"X", "N", "P"}, true},
+	{"MPR", []string{"X", "N", "L", "H"}, true},
+	{"MUI", []string{"X", "N", "P", "A"}, true},
+	{"MVC", []string{"X", "H", "L", "N"}, true},
+	{"MVI", []string{"X", "H", "L", "N"}, true},
+	{"MVA", []string{"X", "H", "L", "N"}, true},
+	{"MSC", []string{"X", "H", "L", "N"}, true},
+	{"MSI", []string{"X", "S", "H", "L", "N"}, true},
+	{"MSA", []string{"X", "S", "H", "L", "N"}, true},
+	{"S", []string{"X", "N", "P"}, true},
+	{"AU", []string{"X", "N", "Y"}, true},
+	{"R", []string{"X", "A", "U", "I"}, true},
+	{"V", []string{"X", "D", "C"}, true},
+	{"RE", []string{"X", "L", "M", "H"}, true},
+	{"U", []string{"X", "Clear", "Green", "Amber", "Red"}, true},
+}
+
+// cvssv4Levels are the values of the CVSS v4.0 metrics that are scored, from
+// most to least severe.  A value's severity distance from another is how far
+// apart they are.
+var cvssv4Levels = map[string][]string{
+	"AV": {"N", "A", "L", "P"},
+	"PR": {"N", "L", "H"},
+	"UI": {"N", "P", "A"},
+	"AC": {"L", "H"},
+	"AT": {"N", "P"},
+	"VC": {"H", "L", "N"},
+	"VI": {"H", "L", "N"},
+	"VA": {"H", "L", "N"},
+	"SC": {"H", "L", "N"},
+	"SI": {"S", "H", "L", "N"},
+	"SA": {"S", "H", "L", "N"},
+	"CR": {"H", "M", "L"},
+	"IR": {"H", "M", "L"},
+	"AR": {"H", "M", "L"},
+}
+
+// cvssv4HighestSeverityVectors are the most severe vectors of each level of
+// the equivalence classes EQ1, EQ2, and EQ4, and of each pair of levels of EQ3
+// and EQ6, which are scored together
+var cvssv4HighestSeverityVectors = map[string]map[string][]string{
+	"EQ1": {
+		"0": {"AV:N/PR:N/UI:N"},
+		"1": {"AV:A/PR:N/UI:N", "AV:N/PR:L/UI:N", "AV:N/PR:N/UI:P"},
+		"2": {"AV:P/PR:N/UI:N", "AV:A/PR:L/UI:P"},
+	},
+	"EQ2": {
+		"0": {"AC:L/AT:N"},
+		"1": {"AC:L/AT:P", "AC:H/AT:N"},
+	},
+	"EQ3EQ6": {
+		"00": {"VC:H/VI:H/VA:H/CR:H/IR:H/AR:H"},
+		"01": {"VC:H/VI:H/VA:L/CR:M/IR:M/AR:H", "VC:H/VI:H/VA:H/CR:M/IR:M/AR:M"},
+		"10": {"VC:L/VI:H/VA:H/CR:H/IR:H/AR:H", "VC:H/VI:L/VA:H/CR:H/IR:H/AR:H"},
+		"11": {
+			"VC:H/VI:L/VA:H/CR:M/IR:H/AR:M",
+			"VC:H/VI:L/VA:L/CR:M/IR:H/AR:H",
+			"VC:L/VI:H/VA:H/CR:H/IR:M/AR:M",
+			"VC:L/VI:H/VA:L/CR:H/IR:M/AR:H",
+			"VC:L/VI:L/VA:H/CR:H/IR:H/AR:M",
+		},
+		"21": {"VC:L/VI:L/VA:L/CR:H/IR:H/AR:H"},
+	},
+	"EQ4": {
+		"0": {"SC:H/SI:S/SA:S"},
+		"1": {"SC:H/SI:H/SA:H"},
+		"2": {"SC:L/SI:L/SA:L"},
+	},
+}
+
+// cvssv4Depths are the number of severity steps within each level of the
+// equivalence classes, plus one
+var cvssv4Depths = map[string]map[string]float64{
+	"EQ1":    {"0": 1, "1": 4, "2": 5},
+	"EQ2":    {"0": 1, "1": 2},
+	"EQ3EQ6": {"00": 7, "01": 6, "10": 8, "11": 8, "21": 10},
+	"EQ4":    {"0": 6, "1": 5, "2": 4},
+}
+
+// CVSSv4Vector is a CVSS v4.0 vector, from which its score can be calculated
+type CVSSv4Vector struct {
+	metrics map[string]string
+}
+
+// ParseCVSSv4Vector parses a CVSS v4.0 vector string, such as
+// CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N.  The vector
+// must give every base metric, and may give any threat, environmental, and
+// supplemental metrics.
+func ParseCVSSv4Vector(vector string) (*CVSSv4Vector, error) {
+	if !strings.HasPrefix(vector, "CVSS:4.0/") {
+		return nil, fmt.Errorf("%w %q: must begin with CVSS:4.0/", ErrInvalidVector, vector)
+	}
+
+	metrics, err := parseMetrics(vector, vector[len("CVSS:4.0/"):], cvssv4Metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CVSSv4Vector{metrics: metrics}, nil
+}
+
+// Get returns the value of a metric of the vector, which is X for threat,
+// environmental, and supplemental metrics that are not defined
+func (v *CVSSv4Vector) Get(metric string) string {
+	return v.metrics[metric]
+}
+
+// Set changes the value of a metric of the vector, such as setting the
+// environmental requirements to rescore a vulnerability with
+func (v *CVSSv4Vector) Set(metric, value string) error {
+	return setMetric(v.metrics, metric, value, cvssv4Metrics)
+}
+
+// String returns the vector string of the vector, in the order of the
+// specification and leaving out metrics that are not defined
+func (v *CVSSv4Vector) String() string {
+	return "CVSS:4.0/" + formatMetrics(v.metrics, cvssv4Metrics)
+}
+
+// Nomenclature returns which groups of metrics the vector's score accounts
+// for: CVSS-B for base metrics only, CVSS-BT with threat metrics, CVSS-BE with
+// environmental metrics, or CVSS-BTE with both
+func (v *CVSSv4Vector) Nomenclature() string {
+	nomenclature := "CVSS-B"
+	if v.metrics["E"] != "X" {
+		nomenclature += "T"
+	}
+
+	for _, def := range cvssv4Metrics {
+		if def.name == "CR" || def.name == "IR" || def.name == "AR" || strings.HasPrefix(def.name, "M") {
+			if v.metrics[def.name] != "X" {
+				return nomenclature + "E"
+			}
+		}
+	}
+
+	return nomenclature
+}
+
+// Score returns the score of the vector, which accounts for all of its base,
+// threat, and environmental metrics.  It is interpolated within the vector's
+// MacroVector, as the specification's reference calculator does.
+func (v *CVSSv4Vector) Score() float64 {
+	impacted := false
+	for _, metric := range []string{"VC", "VI", "VA", "SC", "SI", "SA"} {
+		if v.effective(metric) != "N" {
+			impacted = true
+		}
+	}
+
+	if !impacted {
+		return 0
+	}
+
+	eq := v.macroVector()
+	score := cvssv4MacroVectorScores[strings.Join(eq, "")]
+
+	// the scores of the next lower MacroVector in each equivalence class.  EQ3
+	// and EQ6 are lowered together, as only some pairs of their levels exist.
+	lower := make(map[string]float64)
+	for class, index := range map[string]int{"EQ1": 0, "EQ2": 1, "EQ4": 3, "EQ5": 4} {
+		if s, ok := lowerMacroVectorScore(eq, index); ok {
+			lower[class] = s
+		}
+	}
+
+	eq3eq6 := eq[2] + eq[5]
+	switch eq3eq6 {
+	case "00":
+		// either may be lowered, and the more severe is taken
+		left, _ := lowerMacroVectorScore(eq, 5)
+		right, _ := lowerMacroVectorScore(eq, 2)
+		lower["EQ3EQ6"] = math.Max(left, right)
+	case "10":
+		if s, ok := lowerMacroVectorScore(eq, 5); ok {
+			lower["EQ3EQ6"] = s
+		}
+	default:
+		if s, ok := lowerMacroVectorScore(eq, 2); ok {
+			lower["EQ3EQ6"] = s
+		}
+	}
+
+	// each class lowers the score by the proportion of the distance to its
+	// next lower MacroVector the vector's severity is from the highest, and
+	// the mean is taken.  The severity within EQ5 has no depth, as it is a
+	// single metric.
+	distances := v.severityDistances(eq)
+	levels := map[string]string{"EQ1": eq[0], "EQ2": eq[1], "EQ3EQ6": eq3eq6, "EQ4": eq[3]}
+
+	var total float64
+	for _, class := range []string{"EQ1", "EQ2", "EQ3EQ6", "EQ4"} {
+		if s, ok := lower[class]; ok {
+			total += (score - s) * distances[class] / cvssv4Depths[class][levels[class]]
+		}
+	}
+
+	if len(lower) > 0 {
+		score -= total / float64(len(lower))
+	}
+
+	return round1(math.Min(math.Max(score, 0), 10))
+}
+
+// BaseScore returns the score of the vector's base metrics alone, its CVSS-B
+// score
+func (v *CVSSv4Vector) BaseScore() float64 {
+	base := &CVSSv4Vector{metrics: make(map[string]string)}
+	for _, def := range cvssv4Metrics {
+		base.metrics[def.name] = v.metrics[def.name]
+		if def.optional {
+			base.metrics[def.name] = def.values[0]
+		}
+	}
+
+	return base.Score()
+}
+
+// Severity returns the severity of the vector's score
+func (v *CVSSv4Vector) Severity() string {
+	return CVSSv4Severity(v.Score())
+}
+
+// CVSSv4 returns the expanded representation of the vector, with its base
+// score and severity
+func (v *CVSSv4Vector) CVSSv4() *CVSSv4 {
+	return NewV4FromShorthand(v.String())
+}
+
+// lowerMacroVectorScore returns the score of the MacroVector one level lower
+// than the given one in an equivalence class, if there is one
+func lowerMacroVectorScore(eq []string, class int) (float64, bool) {
+	next := make([]string, len(eq))
+	copy(next, eq)
+	next[class] = string(next[class][0] + 1)
+
+	s, ok := cvssv4MacroVectorScores[strings.Join(next, "")]
+	return s, ok
+}
+
+// effective returns the value a metric is scored with: its modified value if
+// it has one, and the most severe value for undefined threat and requirement
+// metrics
+func (v *CVSSv4Vector) effective(metric string) string {
+	if modified, ok := v.metrics["M"+metric]; ok && modified != "X" {
+		return modified
+	}
+
+	value := v.metrics[metric]
+	if value == "X" {
+		switch metric {
+		case "E":
+			return "A"
+		case "CR", "IR", "AR":
+			return "H"
+		}
+	}
+
+	return value
+}
+
+// macroVector returns the levels of the vector's equivalence classes EQ1
+// through EQ6
+func (v *CVSSv4Vector) macroVector() []string {
+	m := v.effective
+	eq := []string{"0", "0", "0", "0", "0", "0"}
+
+	switch {
+	case m("AV") == "N" && m("PR") == "N" && m("UI") == "N":
+		eq[0] = "0"
+	case (m("AV") == "N" || m("PR") == "N" || m("UI") == "N") && m("AV") != "P":
+		eq[0] = "1"
+	default:
+		eq[0] = "2"
+	}
+
+	if m("AC") != "L" || m("AT") != "N" {
+		eq[1] = "1"
+	}
+
+	switch {
+	case m("VC") == "H" && m("VI") == "H":
+		eq[2] = "0"
+	case m("VC") == "H" || m("VI") == "H" || m("VA") == "H":
+		eq[2] = "1"
+	default:
+		eq[2] = "2"
+	}
+
+	switch {
+	case m("SI") == "S" || m("SA") == "S":
+		eq[3] = "0"
+	case m("SC") == "H" || m("SI") == "H" || m("SA") == "H":
+		eq[3] = "1"
+	default:
+		eq[3] = "2"
+	}
+
+	switch m("E") {
+	case "P":
+		eq[4] = "1"
+	case "U":
+		eq[4] = "2"
+	}
+
+	if !(m("CR") == "H" && m("VC") == "H" || m("IR") == "H" && m("VI") == "H" || m("AR") == "H" && m("VA") == "H") {
+		eq[5] = "1"
+	}
+
+	return eq
+}
+
+// severityDistances returns how much less severe the vector is than the
+// highest severity vector of its MacroVector in each equivalence class.  The
+// first highest severity vector the vector is no more severe than is used.
+func (v *CVSSv4Vector) severityDistances(eq []string) map[string]float64 {
+	distances := make(map[string]float64)
+
+	for _, eq1 := range cvssv4HighestSeverityVectors["EQ1"][eq[0]] {
+		for _, eq2 := range cvssv4HighestSeverityVectors["EQ2"][eq[1]] {
+			for _, eq3eq6 := range cvssv4HighestSeverityVectors["EQ3EQ6"][eq[2]+eq[5]] {
+				for _, eq4 := range cvssv4HighestSeverityVectors["EQ4"][eq[3]] {
+					max := strings.Join([]string{eq1, eq2, eq3eq6, eq4}, "/")
+
+					d := make(map[string]float64)
+					farther := false
+
+					for _, part := range strings.Split(max, "/") {
+						kv := strings.Split(part, ":")
+						d[kv[0]] = float64(indexOf(cvssv4Levels[kv[0]], v.effective(kv[0])) - indexOf(cvssv4Levels[kv[0]], kv[1]))
+						if d[kv[0]] < 0 {
+							farther = true
+						}
+					}
+
+					if farther {
+						continue
+					}
+
+					distances["EQ1"] = d["AV"] + d["PR"] + d["UI"]
+					distances["EQ2"] = d["AC"] + d["AT"]
+					distances["EQ3EQ6"] = d["VC"] + d["VI"] + d["VA"] + d["CR"] + d["IR"] + d["AR"]
+					distances["EQ4"] = d["SC"] + d["SI"] + d["SA"]
+
+					return distances
+				}
+			}
+		}
+	}
+
+	return distances
+}
+
+// indexOf returns the index of a value in a list, or -1 if it is not there
+func indexOf(values []string, value string) int {
+	for ii, v := range values {
+		if v == value {
+			return ii
+		}
+	}
+
+	return -1
+}
+
+// CVSSv4Severity returns the qualitative severity of a CVSS v4.0 score, which
+// uses the same ranges as CVSS v3: NONE, LOW, MEDIUM, HIGH, or CRITICAL
+func CVSSv4Severity(score float64) string {
+	return CVSSv3Severity(score)
+}
@@ -0,0 +1,197 @@
+package vulnerabilities
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestCVSS(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("CVSS v3", func() {
+		g.It("should calculate scores", func() {
+			table := []struct {
+				Vector        string
+				Base          float64
+				Temporal      float64
+				Environmental float64
+				Severity      string
+			}{
+				{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 9.8, 9.8, 9.8, "CRITICAL"},
+				{"CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N", 6.1, 6.1, 6.1, "MEDIUM"},
+				{"CVSS:3.0/AV:N/AC:L/PR:L/UI:N/S:C/C:H/I:H/A:H", 9.9, 9.9, 9.9, "CRITICAL"},
+				{"CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:U/C:N/I:N/A:N", 0, 0, 0, "NONE"},
+				{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/E:P/RL:O/RC:C", 9.8, 8.8, 8.8, "HIGH"},
+				{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/CR:L/IR:L/AR:L", 9.8, 9.8, 8.0, "HIGH"},
+				{"CVSS:3.1/AV:N/AC:L/PR:L/UI:N/S:C/C:H/I:H/A:H/CR:H/MAV:L/MS:U", 9.9, 9.9, 7.8, "HIGH"},
+			}
+
+			for _, row := range table {
+				v, err := ParseCVSSv3Vector(row.Vector)
+				Expect(err).To(BeNil())
+				Expect(v.BaseScore()).To(Equal(row.Base))
+				Expect(v.TemporalScore()).To(Equal(row.Temporal))
+				Expect(v.EnvironmentalScore()).To(Equal(row.Environmental))
+				Expect(v.Severity()).To(Equal(row.Severity))
+				Expect(v.String()).To(Equal(row.Vector))
+			}
+		})
+
+		g.It("should rescore with environmental requirements", func() {
+			v, err := ParseCVSSv3Vector("CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/RC:X")
+			Expect(err).To(BeNil())
+			Expect(v.String()).To(Equal("CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"))
+			Expect(v.Get("CR")).To(Equal("X"))
+
+			for _, metric := range []string{"CR", "IR", "AR"} {
+				Expect(v.Set(metric, "L")).To(BeNil())
+			}
+
+			Expect(v.BaseSeverity()).To(Equal("CRITICAL"))
+			Expect(v.EnvironmentalScore()).To(Equal(8.0))
+			Expect(v.CVSSv3().BaseScore).To(Equal(9.8))
+
+			Expect(v.Set("CR", "Q")).NotTo(BeNil())
+			Expect(v.Set("ZZ", "X")).NotTo(BeNil())
+		})
+
+		g.It("should reject invalid vectors", func() {
+			for _, vector := range []string{
+				"AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+				"CVSS:2.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+				"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H",
+				"CVSS:3.1/AV:N/AV:L/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+				"CVSS:3.1/AV:Q/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+				"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/Au:N",
+				"CVSS:3.1/AV:N/AC",
+			} {
+				_, err := ParseCVSSv3Vector(vector)
+				Expect(errors.Is(err, ErrInvalidVector)).To(BeTrue())
+			}
+		})
+
+		g.It("should give severities", func() {
+			Expect(CVSSv3Severity(0)).To(Equal("NONE"))
+			Expect(CVSSv3Severity(0.1)).To(Equal("LOW"))
+			Expect(CVSSv3Severity(4)).To(Equal("MEDIUM"))
+			Expect(CVSSv3Severity(8.9)).To(Equal("HIGH"))
+			Expect(CVSSv3Severity(9)).To(Equal("CRITICAL"))
+		})
+	})
+
+	g.Describe("CVSS v4.0", func() {
+		g.It("should calculate scores", func() {
+			table := []struct {
+				Vector       string
+				Score        float64
+				Nomenclature string
+			}{
+				{"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:H/SI:H/SA:H", 10.0, "CVSS-B"},
+				{"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:N/VI:N/VA:N/SC:N/SI:N/SA:N", 0.0, "CVSS-B"},
+				{"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N", 9.3, "CVSS-B"},
+				{"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:N/VI:N/VA:N/SC:H/SI:H/SA:H", 7.9, "CVSS-B"},
+				{"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:H/SI:H/SA:H/E:U", 9.1, "CVSS-BT"},
+				{"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:H/SI:H/SA:H/MVI:L/MSA:S", 9.8, "CVSS-BE"},
+				{"CVSS:4.0/AV:P/AC:H/AT:P/PR:H/UI:A/VC:L/VI:N/VA:N/SC:N/SI:N/SA:N", 1.0, "CVSS-B"},
+				{"CVSS:4.0/AV:L/AC:L/AT:N/PR:L/UI:P/VC:N/VI:H/VA:H/SC:N/SI:L/SA:L", 5.2, "CVSS-B"},
+				{"CVSS:4.0/AV:L/AC:L/AT:N/PR:L/UI:P/VC:N/VI:H/VA:H/SC:N/SI:L/SA:L/E:P/CR:H/IR:M/AR:H/MAV:A/MAT:P/MPR:N/MVI:H/MVA:N/MSI:H/MSA:N/S:N/V:C/U:Amber", 4.7, "CVSS-BTE"},
+				{"CVSS:4.0/AV:N/AC:H/AT:N/PR:H/UI:N/VC:N/VI:N/VA:H/SC:H/SI:H/SA:H/CR:L/IR:L/AR:L", 5.8, "CVSS-BE"},
+			}
+
+			for _, row := range table {
+				v, err := ParseCVSSv4Vector(row.Vector)
+				Expect(err).To(BeNil())
+				Expect(v.Score()).To(Equal(row.Score))
+				Expect(v.Nomenclature()).To(Equal(row.Nomenclature))
+				Expect(v.String()).To(Equal(row.Vector))
+			}
+		})
+
+		g.It("should score the base metrics alone", func() {
+			v, err := ParseCVSSv4Vector("CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:H/SI:H/SA:H/E:U")
+			Expect(err).To(BeNil())
+			Expect(v.BaseScore()).To(Equal(10.0))
+			Expect(v.Severity()).To(Equal("CRITICAL"))
+			Expect(v.Get("E")).To(Equal("U"))
+
+			Expect(v.Set("E", "X")).To(BeNil())
+			Expect(v.Set("U", "Red")).To(BeNil())
+			Expect(v.String()).To(Equal("CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:H/SI:H/SA:H/U:Red"))
+
+			cvssv4 := v.CVSSv4()
+			Expect(cvssv4.BaseScore).To(Equal(10.0))
+			Expect(cvssv4.BaseSeverity).To(Equal("CRITICAL"))
+		})
+
+		g.It("should reject invalid vectors", func() {
+			for _, vector := range []string{
+				"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+				"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:H/SI:H",
+				"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:H/SI:S/SA:H",
+				"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:H/SI:H/SA:H/U:red",
+			} {
+				_, err := ParseCVSSv4Vector(vector)
+				Expect(errors.Is(err, ErrInvalidVector)).To(BeTrue())
+			}
+		})
+	})
+
+	g.Describe("CVSS v2", func() {
+		g.It("should calculate scores", func() {
+			// the examples of the CVSS v2 guide
+			table := []struct {
+				Vector        string
+				Base          float64
+				Temporal      float64
+				Environmental float64
+			}{
+				{"AV:N/AC:L/Au:N/C:P/I:P/A:P", 7.5, 7.5, 7.5},
+				{"AV:N/AC:L/Au:N/C:N/I:N/A:C/E:F/RL:OF/RC:C/CDP:H/TD:H/CR:M/IR:M/AR:H", 7.8, 6.4, 9.2},
+				{"AV:N/AC:L/Au:N/C:C/I:C/A:C/E:F/RL:OF/RC:C/CDP:H/TD:H/CR:M/IR:M/AR:L", 10.0, 8.3, 9.0},
+			}
+
+			for _, row := range table {
+				v, err := ParseCVSSv2Vector(row.Vector)
+				Expect(err).To(BeNil())
+				Expect(v.BaseScore()).To(Equal(row.Base))
+				Expect(v.TemporalScore()).To(Equal(row.Temporal))
+				Expect(v.EnvironmentalScore()).To(Equal(row.Environmental))
+				Expect(v.String()).To(Equal(row.Vector))
+			}
+		})
+
+		g.It("should read vectors in parentheses", func() {
+			v, err := ParseCVSSv2Vector("(AV:L/AC:H/Au:M/C:N/I:N/A:N)")
+			Expect(err).To(BeNil())
+			Expect(v.String()).To(Equal("AV:L/AC:H/Au:M/C:N/I:N/A:N"))
+			Expect(v.BaseScore()).To(Equal(0.0))
+			Expect(v.Severity()).To(Equal("LOW"))
+			Expect(v.Get("E")).To(Equal("ND"))
+
+			Expect(v.Set("TD", "N")).To(BeNil())
+			Expect(v.CVSSv2().AccessVector).To(Equal("local"))
+		})
+
+		g.It("should reject invalid vectors", func() {
+			for _, vector := range []string{
+				"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+				"AV:N/AC:L/Au:N/C:P/I:P",
+				"AV:N/AC:L/AU:N/C:P/I:P/A:P",
+				"AV:N/AC:L/Au:N/C:P/I:P/A:H",
+			} {
+				_, err := ParseCVSSv2Vector(vector)
+				Expect(errors.Is(err, ErrInvalidVector)).To(BeTrue())
+			}
+		})
+
+		g.It("should give severities", func() {
+			Expect(CVSSv2Severity(3.9)).To(Equal("LOW"))
+			Expect(CVSSv2Severity(4)).To(Equal("MEDIUM"))
+			Expect(CVSSv2Severity(7)).To(Equal("HIGH"))
+		})
+	})
+}
@@ -0,0 +1,423 @@
+package vulnerabilities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ion-channel/ionic/products"
+	"github.com/ion-channel/ionic/versions"
+)
+
+const (
+	// OSVSchemaVersion is the version of the OSV schema vulnerabilities are
+	// written in
+	OSVSchemaVersion = "1.6.0"
+)
+
+var (
+	// osvEcosystems maps ecosystems to the names OSV gives them
+	osvEcosystems = map[string]string{
+		versions.EcosystemNPM:      "npm",
+		versions.EcosystemPyPI:     "PyPI",
+		versions.EcosystemMaven:    "Maven",
+		versions.EcosystemGo:       "Go",
+		versions.EcosystemCargo:    "crates.io",
+		versions.EcosystemRubyGems: "RubyGems",
+		versions.EcosystemNuGet:    "NuGet",
+		versions.EcosystemComposer: "Packagist",
+		versions.EcosystemHex:      "Hex",
+	}
+
+	// osvReferenceTypes are the types of references OSV knows
+	osvReferenceTypes = map[string]bool{
+		"ADVISORY":   true,
+		"ARTICLE":    true,
+		"DETECTION":  true,
+		"DISCUSSION": true,
+		"REPORT":     true,
+		"FIX":        true,
+		"INTRODUCED": true,
+		"GIT":        true,
+		"PACKAGE":    true,
+		"EVIDENCE":   true,
+		"WEB":        true,
+	}
+)
+
+// OSV represents a vulnerability in the Open Source Vulnerability format
+type OSV struct {
+	SchemaVersion string         `json:"schema_version,omitempty"`
+	ID            string         `json:"id"`
+	Modified      time.Time      `json:"modified"`
+	Published     *time.Time     `json:"published,omitempty"`
+	Aliases       []string       `json:"aliases,omitempty"`
+	Summary       string         `json:"summary,omitempty"`
+	Details       string         `json:"details,omitempty"`
+	Severity      []OSVSeverity  `json:"severity,omitempty"`
+	Affected      []OSVAffected  `json:"affected,omitempty"`
+	References    []OSVReference `json:"references,omitempty"`
+}
+
+// OSVSeverity represents a CVSS vector of an OSV vulnerability
+type OSVSeverity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// OSVAffected represents a package affected by an OSV vulnerability and the
+// ranges of its versions that are affected
+type OSVAffected struct {
+	Package  OSVPackage `json:"package"`
+	Ranges   []OSVRange `json:"ranges,omitempty"`
+	Versions []string   `json:"versions,omitempty"`
+}
+
+// OSVPackage represents a package of an ecosystem OSV knows
+type OSVPackage struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Purl      string `json:"purl,omitempty"`
+}
+
+// OSVRange represents the events that introduce and fix a vulnerability in
+// the versions of a package
+type OSVRange struct {
+	Type   string     `json:"type"`
+	Repo   string     `json:"repo,omitempty"`
+	Events []OSVEvent `json:"events"`
+}
+
+// OSVEvent represents a version that introduces or fixes a vulnerability,
+// only one of which is given
+type OSVEvent struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// OSVReference represents a link to more information about an OSV
+// vulnerability
+type OSVReference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// Vulnerability returns the OSV vulnerability as a vulnerability.  The
+// products it affects are named by their package, with the range expression
+// of their SEMVER and ECOSYSTEM ranges and any other versions listed as their
+// version, and the package URL of their package as their external ID.
+// Packages with no such ranges or versions are left out.
+func (o *OSV) Vulnerability() *Vulnerability {
+	v := &Vulnerability{
+		ExternalID: o.ID,
+		Aliases:    o.Aliases,
+		Title:      o.Summary,
+		Summary:    o.Details,
+		Source:     []Source{{Name: "OSV"}},
+		ModifiedAt: o.Modified,
+	}
+
+	if o.Published != nil {
+		v.PublishedAt = *o.Published
+	}
+
+	for _, s := range o.Severity {
+		switch s.Type {
+		case "CVSS_V2":
+			v.ScoreDetails.CVSSv2 = NewV2FromShorthand(s.Score)
+		case "CVSS_V3":
+			v.ScoreDetails.CVSSv3 = NewV3FromShorthand(s.Score)
+		case "CVSS_V4":
+			v.ScoreDetails.CVSSv4 = NewV4FromShorthand(s.Score)
+		}
+	}
+
+	v.SetScoreFromDetails()
+
+	for _, ref := range o.References {
+		v.References = append(v.References, Reference{
+			Type: strings.ToLower(ref.Type),
+			URL:  ref.URL,
+		})
+	}
+
+	for _, a := range o.Affected {
+		ecosystem := productEcosystem(strings.SplitN(a.Package.Ecosystem, ":", 2)[0])
+
+		ranges := []versions.Range{}
+		for _, r := range a.Ranges {
+			if r.Type == "SEMVER" || r.Type == "ECOSYSTEM" {
+				ranges = append(ranges, osvEventRanges(r.Events)...)
+			}
+		}
+
+		for _, version := range a.Versions {
+			if !containsVersion(ranges, ecosystem, version) {
+				ranges = append(ranges, versions.Range{{Operator: "=", Version: version}})
+			}
+		}
+
+		if len(ranges) == 0 {
+			continue
+		}
+
+		p := products.Product{
+			Name:       a.Package.Name,
+			Version:    versions.FormatRanges(ranges),
+			ExternalID: a.Package.Purl,
+		}
+
+		if ecosystem == versions.EcosystemMaven {
+			if parts := strings.SplitN(p.Name, ":", 2); len(parts) == 2 {
+				p.Org, p.Name = parts[0], parts[1]
+			}
+		}
+
+		if p.ExternalID == "" && ecosystem != "" {
+			p.ExternalID = "pkg:" + ecosystem + "/" + strings.Replace(a.Package.Name, ":", "/", 1)
+		}
+
+		v.Dependencies = append(v.Dependencies, p)
+	}
+
+	return v
+}
+
+// OSV returns the vulnerability in the Open Source Vulnerability format.
+// Products whose external ID is not a package URL have no ecosystem OSV knows
+// and are left out, as are ranges of versions events cannot describe, such as
+// those excluding a version.
+func (v *Vulnerability) OSV() *OSV {
+	o := &OSV{
+		SchemaVersion: OSVSchemaVersion,
+		ID:            v.ExternalID,
+		Modified:      v.ModifiedAt,
+		Aliases:       v.Aliases,
+		Summary:       v.Title,
+		Details:       v.Summary,
+	}
+
+	if !v.PublishedAt.IsZero() {
+		published := v.PublishedAt
+		o.Published = &published
+	}
+
+	for _, vector := range v.cvssVectors() {
+		o.Severity = append(o.Severity, OSVSeverity{Type: "CVSS_V" + vector[:1], Score: vector[1:]})
+	}
+
+	for _, ref := range v.References {
+		refType := strings.ToUpper(ref.Type)
+		if !osvReferenceTypes[refType] {
+			refType = "WEB"
+		}
+
+		o.References = append(o.References, OSVReference{Type: refType, URL: ref.URL})
+	}
+
+	for _, p := range v.Dependencies {
+		ecosystem := purlEcosystem(p.ExternalID)
+		if ecosystem == "" {
+			continue
+		}
+
+		a := OSVAffected{
+			Package: OSVPackage{
+				Ecosystem: ecosystem,
+				Name:      packageName(p, ecosystem),
+				Purl:      p.ExternalID,
+			},
+		}
+
+		if name, ok := osvEcosystems[ecosystem]; ok {
+			a.Package.Ecosystem = name
+		}
+
+		r := OSVRange{Type: "ECOSYSTEM"}
+		for _, vr := range productRanges(p) {
+			lower, upper, op, ok := bounds(vr)
+			switch {
+			case !ok:
+				continue
+			case op == "=":
+				a.Versions = append(a.Versions, lower)
+				continue
+			case lower == "":
+				lower = "0"
+			}
+
+			r.Events = append(r.Events, OSVEvent{Introduced: lower})
+
+			switch op {
+			case "<":
+				r.Events = append(r.Events, OSVEvent{Fixed: upper})
+			case "<=":
+				r.Events = append(r.Events, OSVEvent{LastAffected: upper})
+			}
+		}
+
+		if len(r.Events) > 0 {
+			a.Ranges = append(a.Ranges, r)
+		}
+
+		if len(a.Ranges) > 0 || len(a.Versions) > 0 {
+			o.Affected = append(o.Affected, a)
+		}
+	}
+
+	return o
+}
+
+// osvEventRanges returns the ranges of versions the events of an OSV range
+// introduce, ending each at the next fix, limit, or last affected version
+func osvEventRanges(events []OSVEvent) []versions.Range {
+	ranges := []versions.Range{}
+	var r versions.Range
+	open := false
+
+	for _, event := range events {
+		switch {
+		case event.Introduced != "":
+			if open {
+				ranges = append(ranges, r)
+			}
+
+			r = versions.Range{}
+			if event.Introduced != "0" {
+				r = append(r, versions.Constraint{Operator: ">=", Version: event.Introduced})
+			}
+
+			open = true
+		case event.Fixed != "" && open:
+			ranges = append(ranges, append(r, versions.Constraint{Operator: "<", Version: event.Fixed}))
+			open = false
+		case event.Limit != "" && open:
+			ranges = append(ranges, append(r, versions.Constraint{Operator: "<", Version: event.Limit}))
+			open = false
+		case event.LastAffected != "" && open:
+			ranges = append(ranges, append(r, versions.Constraint{Operator: "<=", Version: event.LastAffected}))
+			open = false
+		}
+	}
+
+	if open {
+		ranges = append(ranges, r)
+	}
+
+	return ranges
+}
+
+// cvssVectors returns the CVSS vectors of the vulnerability's score details,
+// oldest first, each prefixed by its major version.  The vulnerability's
+// vector is used if its score details have none.
+func (v *Vulnerability) cvssVectors() []string {
+	vectors := []string{}
+
+	if d := v.ScoreDetails.CVSSv2; d != nil && d.VectorString != "" {
+		vectors = append(vectors, "2"+d.VectorString)
+	}
+
+	if d := v.ScoreDetails.CVSSv3; d != nil && d.VectorString != "" {
+		vectors = append(vectors, "3"+d.VectorString)
+	}
+
+	if d := v.ScoreDetails.CVSSv4; d != nil && d.VectorString != "" {
+		vectors = append(vectors, "4"+d.VectorString)
+	}
+
+	if len(vectors) == 0 {
+		if s := v.NewestCVSS(); s != nil && s.Vector != "" {
+			vectors = append(vectors, s.Version[:1]+s.Vector)
+		}
+	}
+
+	return vectors
+}
+
+// productEcosystem returns the ecosystem of an OSV ecosystem or package URL
+// type, or the name itself in lower case if it is not a known ecosystem
+func productEcosystem(name string) string {
+	if ecosystem := versions.Ecosystem(name); ecosystem != "" {
+		return ecosystem
+	}
+
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// purlEcosystem returns the ecosystem of a package URL, or nothing if the
+// external ID of a product is not a package URL
+func purlEcosystem(externalID string) string {
+	if !strings.HasPrefix(externalID, "pkg:") {
+		return ""
+	}
+
+	return productEcosystem(strings.SplitN(strings.TrimPrefix(externalID, "pkg:"), "/", 2)[0])
+}
+
+// packageName returns the name of a product's package in its ecosystem, which
+// is group:artifact for Maven
+func packageName(p products.Product, ecosystem string) string {
+	if ecosystem == versions.EcosystemMaven && p.Org != "" {
+		return p.Org + ":" + p.Name
+	}
+
+	return p.Name
+}
+
+// productRanges returns the ranges of a product's version, which is an exact
+// version if it is not a valid range expression
+func productRanges(p products.Product) []versions.Range {
+	ranges, err := versions.ParseRanges(p.Version)
+	if err != nil {
+		return []versions.Range{{{Operator: "=", Version: strings.TrimSpace(p.Version)}}}
+	}
+
+	return ranges
+}
+
+// bounds returns the lower and upper bounds of a range, and the operator of
+// its upper bound.  A range of an exact version has that version as its
+// lower bound and = as its operator.  It is not ok if the range cannot be
+// described by an inclusive lower bound and an upper bound.
+func bounds(r versions.Range) (string, string, string, bool) {
+	lower, upper, op := "", "", ""
+
+	for _, c := range r {
+		switch c.Operator {
+		case ">=":
+			if lower != "" {
+				return "", "", "", false
+			}
+
+			lower = c.Version
+		case "<", "<=":
+			if op != "" {
+				return "", "", "", false
+			}
+
+			upper, op = c.Version, c.Operator
+		case "=":
+			if len(r) != 1 {
+				return "", "", "", false
+			}
+
+			return c.Version, "", "=", true
+		default:
+			return "", "", "", false
+		}
+	}
+
+	return lower, upper, op, true
+}
+
+// containsVersion returns whether any of the ranges contain a version
+func containsVersion(ranges []versions.Range, ecosystem, version string) bool {
+	for _, r := range ranges {
+		if r.Contains(ecosystem, version) {
+			return true
+		}
+	}
+
+	return false
+}
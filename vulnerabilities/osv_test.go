@@ -0,0 +1,142 @@
+package vulnerabilities
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/franela/goblin"
+	"github.com/ion-channel/ionic/products"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	sampleOSVEntry = `{
+		"schema_version": "1.4.0",
+		"id": "GHSA-jfh8-c2jp-5v3q",
+		"modified": "2023-01-10T05:04:30Z",
+		"published": "2021-12-10T00:40:56Z",
+		"aliases": ["CVE-2021-44228"],
+		"summary": "Remote code injection in Log4j",
+		"details": "Log4j2 JNDI features do not protect against attacker controlled LDAP endpoints.",
+		"severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"}],
+		"affected": [{
+			"package": {"ecosystem": "Maven", "name": "org.apache.logging.log4j:log4j-core", "purl": "pkg:maven/org.apache.logging.log4j/log4j-core"},
+			"ranges": [{"type": "ECOSYSTEM", "events": [
+				{"introduced": "2.13.0"}, {"fixed": "2.15.0"},
+				{"introduced": "2.0-beta9"}, {"fixed": "2.12.2"}
+			]}],
+			"versions": ["2.0-beta9", "2.14.1", "3.0.0-beta1"]
+		}, {
+			"package": {"ecosystem": "Go", "name": "github.com/example/module"},
+			"ranges": [{"type": "GIT", "repo": "https://github.com/example/module", "events": [{"introduced": "0"}, {"fixed": "abcdef"}]}]
+		}],
+		"references": [
+			{"type": "ADVISORY", "url": "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"},
+			{"type": "FIX", "url": "https://github.com/apache/logging-log4j2/pull/608"}
+		]
+	}`
+)
+
+func TestOSV(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("OSV", func() {
+		g.It("should convert an OSV entry to a vulnerability", func() {
+			var o OSV
+			Expect(json.Unmarshal([]byte(sampleOSVEntry), &o)).To(BeNil())
+
+			v := o.Vulnerability()
+			Expect(v.ExternalID).To(Equal("GHSA-jfh8-c2jp-5v3q"))
+			Expect(v.Aliases).To(Equal([]string{"CVE-2021-44228"}))
+			Expect(v.Title).To(Equal("Remote code injection in Log4j"))
+			Expect(v.Summary).To(HavePrefix("Log4j2 JNDI features"))
+			Expect(v.Source).To(Equal([]Source{{Name: "OSV"}}))
+			Expect(v.PublishedAt).To(Equal(time.Date(2021, 12, 10, 0, 40, 56, 0, time.UTC)))
+			Expect(v.ModifiedAt).To(Equal(time.Date(2023, 1, 10, 5, 4, 30, 0, time.UTC)))
+			Expect(v.Score).To(Equal("10.0"))
+			Expect(v.ScoreVersion).To(Equal("3.1"))
+			Expect(v.ScoreDetails.CVSSv3.Scope).To(Equal("changed"))
+			Expect(v.References).To(Equal([]Reference{
+				{Type: "advisory", URL: "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"},
+				{Type: "fix", URL: "https://github.com/apache/logging-log4j2/pull/608"},
+			}))
+			Expect(v.Dependencies).To(Equal([]products.Product{{
+				Org:        "org.apache.logging.log4j",
+				Name:       "log4j-core",
+				Version:    ">=2.13.0, <2.15.0 || >=2.0-beta9, <2.12.2 || =3.0.0-beta1",
+				ExternalID: "pkg:maven/org.apache.logging.log4j/log4j-core",
+			}}))
+		})
+
+		g.It("should round trip a vulnerability", func() {
+			v := &Vulnerability{
+				ExternalID:  "GHSA-aaaa-bbbb-cccc",
+				Aliases:     []string{"CVE-2024-0001", "PYSEC-2024-1"},
+				Title:       "Path traversal",
+				Summary:     "Archive extraction writes outside of the target directory.",
+				PublishedAt: time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC),
+				ModifiedAt:  time.Date(2024, 3, 5, 8, 30, 0, 0, time.UTC),
+				ScoreDetails: ScoreDetails{
+					CVSSv2: NewV2FromShorthand("AV:N/AC:L/Au:N/C:P/I:P/A:P"),
+					CVSSv3: NewV3FromShorthand("CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:U/C:H/I:H/A:N"),
+					CVSSv4: NewV4FromShorthand("CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N"),
+				},
+				References: []Reference{
+					{Type: "advisory", URL: "https://example.com/advisory"},
+					{Type: "package", URL: "https://pypi.org/project/unpacker"},
+				},
+				Dependencies: []products.Product{
+					{Org: "com.example", Name: "unpacker", Version: ">=1.0, <1.4.2 || >=2.0-rc1, <=2.0.3", ExternalID: "pkg:maven/com.example/unpacker"},
+					{Name: "unpacker", Version: "<0.9.1 || =3.0.0", ExternalID: "pkg:pypi/unpacker"},
+					{Name: "@example/unpacker", Version: ">=4.0.0", ExternalID: "pkg:npm/%40example/unpacker"},
+					{Name: "unpacker-go", Version: "*", ExternalID: "pkg:golang/github.com/example/unpacker-go"},
+				},
+			}
+
+			b, err := json.Marshal(v.OSV())
+			Expect(err).To(BeNil())
+			Expect(string(b)).To(ContainSubstring(`"schema_version":"1.6.0"`))
+			Expect(string(b)).To(ContainSubstring(`"ecosystem":"Maven","name":"com.example:unpacker"`))
+			Expect(string(b)).To(ContainSubstring(`{"introduced":"0"},{"fixed":"0.9.1"}`))
+			Expect(string(b)).To(ContainSubstring(`"versions":["3.0.0"]`))
+			Expect(string(b)).To(ContainSubstring(`{"type":"CVSS_V2","score":"AV:N/AC:L/Au:N/C:P/I:P/A:P"}`))
+
+			var o OSV
+			Expect(json.Unmarshal(b, &o)).To(BeNil())
+
+			rt := o.Vulnerability()
+			Expect(rt.ExternalID).To(Equal(v.ExternalID))
+			Expect(rt.Aliases).To(Equal(v.Aliases))
+			Expect(rt.Title).To(Equal(v.Title))
+			Expect(rt.Summary).To(Equal(v.Summary))
+			Expect(rt.PublishedAt.Equal(v.PublishedAt)).To(BeTrue())
+			Expect(rt.ModifiedAt.Equal(v.ModifiedAt)).To(BeTrue())
+			Expect(rt.ScoreDetails).To(Equal(v.ScoreDetails))
+			Expect(rt.Score).To(Equal("9.3"))
+			Expect(rt.ScoreVersion).To(Equal("4.0"))
+			Expect(rt.References).To(Equal(v.References))
+			Expect(rt.Dependencies).To(Equal(v.Dependencies))
+		})
+
+		g.It("should leave out what OSV cannot describe", func() {
+			v := &Vulnerability{
+				ExternalID: "CVE-2024-0002",
+				References: []Reference{{Type: "x_refsource_MISC", URL: "https://example.com"}},
+				Dependencies: []products.Product{
+					{Org: "apache", Name: "http_server", Version: "=2.4.49", ExternalID: "cpe:2.3:a:apache:http_server:2.4.49:*:*:*:*:*:*:*"},
+					{Name: "leftpad", Version: ">1.0.0 || != 1.2.0 || <=1.5.0", ExternalID: "pkg:npm/leftpad"},
+				},
+			}
+
+			o := v.OSV()
+			Expect(o.Published).To(BeNil())
+			Expect(o.Severity).To(BeEmpty())
+			Expect(o.References).To(Equal([]OSVReference{{Type: "WEB", URL: "https://example.com"}}))
+			Expect(o.Affected).To(HaveLen(1))
+			Expect(o.Affected[0].Package).To(Equal(OSVPackage{Ecosystem: "npm", Name: "leftpad", Purl: "pkg:npm/leftpad"}))
+			Expect(o.Affected[0].Ranges).To(Equal([]OSVRange{{Type: "ECOSYSTEM", Events: []OSVEvent{{Introduced: "0"}, {LastAffected: "1.5.0"}}}}))
+		})
+	})
+}
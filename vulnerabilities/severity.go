@@ -0,0 +1,108 @@
+package vulnerabilities
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Severity is the normalized severity of a vulnerability
+type Severity string
+
+const (
+	// SeverityCritical is the severity of vulnerabilities scored 9.0 or higher
+	SeverityCritical Severity = "critical"
+	// SeverityHigh is the severity of vulnerabilities scored 7.0 to 8.9
+	SeverityHigh Severity = "high"
+	// SeverityMedium is the severity of vulnerabilities scored 4.0 to 6.9
+	SeverityMedium Severity = "medium"
+	// SeverityLow is the severity of vulnerabilities scored 0.1 to 3.9
+	SeverityLow Severity = "low"
+	// SeverityNone is the severity of vulnerabilities scored 0.0
+	SeverityNone Severity = "none"
+	// SeverityUnknown is the severity of vulnerabilities without a score
+	SeverityUnknown Severity = "unknown"
+)
+
+// ParseSeverity normalizes the name of a severity, ignoring case.  The
+// moderate and important severities some advisories use are medium and high.
+// Names it does not know are SeverityUnknown.
+func ParseSeverity(name string) Severity {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "critical":
+		return SeverityCritical
+	case "high", "important":
+		return SeverityHigh
+	case "medium", "moderate":
+		return SeverityMedium
+	case "low":
+		return SeverityLow
+	case "none":
+		return SeverityNone
+	default:
+		return SeverityUnknown
+	}
+}
+
+// SeverityFromScore returns the severity of a score on the CVSS v3 scale
+func SeverityFromScore(score float64) Severity {
+	return ParseSeverity(CVSSv3Severity(score))
+}
+
+// EffectiveScore returns the score of the vulnerability, or 0 if it has no
+// score.  The score is taken in order of precedence from the newest CVSS
+// score of its score details (v4.0, then v3.x, then v2), its NPM score, the
+// score of its CVSS vector, and finally its Score.  This is the CVSS v3, v2,
+// NPM, Score order with two additions: CVSS v4.0 is ahead of v3 as the newest
+// version, and a vector without score details is scored ahead of Score.
+// Scores with neither a vector nor a score, such as the empty scores the API
+// returns, are skipped.
+func (v *Vulnerability) EffectiveScore() float64 {
+	score, _ := v.effectiveScore()
+	return score
+}
+
+// Severity returns the normalized severity of the vulnerability's effective
+// score.  This is the severity given with the score, or the score's severity
+// on the CVSS v3 scale if none is given, except that a Score of CVSS v2 has
+// the v2 severity.  It is SeverityUnknown if the vulnerability has no score.
+func (v *Vulnerability) Severity() Severity {
+	_, severity := v.effectiveScore()
+	return severity
+}
+
+// effectiveScore returns the effective score of the vulnerability and its
+// severity
+func (v *Vulnerability) effectiveScore() (float64, Severity) {
+	if s := v.ScoreDetails.NewestCVSS(); s != nil {
+		return s.Score, severityOf(s.Severity, s.Score)
+	}
+
+	if npm := v.ScoreDetails.NPM; npm != nil && (npm.BaseScore != 0 || npm.BaseSeverity != "") {
+		return npm.BaseScore, severityOf(npm.BaseSeverity, npm.BaseScore)
+	}
+
+	if s := v.NewestCVSS(); s != nil {
+		return s.Score, severityOf(s.Severity, s.Score)
+	}
+
+	score, err := strconv.ParseFloat(strings.TrimSpace(v.Score), 64)
+	if err != nil {
+		return 0, SeverityUnknown
+	}
+
+	if strings.HasPrefix(v.ScoreVersion, "2") {
+		return score, ParseSeverity(CVSSv2Severity(score))
+	}
+
+	return score, SeverityFromScore(score)
+}
+
+// severityOf returns the severity given with a score, or the score's severity
+// on the CVSS v3 scale if none is given
+func severityOf(name string, score float64) Severity {
+	if severity := ParseSeverity(name); severity != SeverityUnknown {
+		return severity
+	}
+
+	return SeverityFromScore(score)
+}
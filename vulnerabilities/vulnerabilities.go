@@ -2,6 +2,7 @@ package vulnerabilities
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
@@ -24,6 +25,7 @@ const (
 type Vulnerability struct {
 	ID                          int                `json:"id" xml:"id"`
 	ExternalID                  string             `json:"external_id" xml:"exteral_id"`
+	Aliases                     []string           `json:"aliases,omitempty" xml:"aliases"`
 	Source                      []Source           `json:"source" xml:"source"`
 	Title                       string             `json:"title" xml:"title"`
 	Summary                     string             `json:"summary" xml:"summary"`
@@ -75,6 +77,7 @@ type Source struct {
 type ScoreDetails struct {
 	CVSSv2 *CVSSv2 `json:"cvssv2,omitempty" xml:"cvssv2"`
 	CVSSv3 *CVSSv3 `json:"cvssv3,omitempty" xml:"cvssv3"`
+	CVSSv4 *CVSSv4 `json:"cvssv4,omitempty" xml:"cvssv4"`
 	NPM    *NPM    `json:"npm,omitempty" xml:"npm"`
 }
 
@@ -115,6 +118,107 @@ type CVSSv3 struct {
 	BaseSeverity          string  `json:"baseSeverity" xml:"baseSeverity"`
 }
 
+// CVSSv4 represents the variables that go into determining the CVSS v4.0
+// score for a given vulnerability
+type CVSSv4 struct {
+	VectorString              string  `json:"vectorString" xml:"vectorString"`
+	AttackVector              string  `json:"attackVector" xml:"attackVector"`
+	AttackComplexity          string  `json:"attackComplexity" xml:"attackComplexity"`
+	AttackRequirements        string  `json:"attackRequirements" xml:"attackRequirements"`
+	PrivilegesRequired        string  `json:"privilegesRequired" xml:"privilegesRequired"`
+	UserInteraction           string  `json:"userInteraction" xml:"userInteraction"`
+	VulnConfidentialityImpact string  `json:"vulnConfidentialityImpact" xml:"vulnConfidentialityImpact"`
+	VulnIntegrityImpact       string  `json:"vulnIntegrityImpact" xml:"vulnIntegrityImpact"`
+	VulnAvailabilityImpact    string  `json:"vulnAvailabilityImpact" xml:"vulnAvailabilityImpact"`
+	SubConfidentialityImpact  string  `json:"subConfidentialityImpact" xml:"subConfidentialityImpact"`
+	SubIntegrityImpact        string  `json:"subIntegrityImpact" xml:"subIntegrityImpact"`
+	SubAvailabilityImpact     string  `json:"subAvailabilityImpact" xml:"subAvailabilityImpact"`
+	ExploitMaturity           string  `json:"exploitMaturity,omitempty" xml:"exploitMaturity"`
+	BaseScore                 float64 `json:"baseScore" xml:"baseScore"`
+	BaseSeverity              string  `json:"baseSeverity" xml:"baseSeverity"`
+}
+
+// CVSSScore is the score of a vulnerability in a version of CVSS
+type CVSSScore struct {
+	Version  string  `json:"version" xml:"version"`
+	Vector   string  `json:"vector" xml:"vector"`
+	Score    float64 `json:"score" xml:"score"`
+	Severity string  `json:"severity" xml:"severity"`
+}
+
+// NewestCVSS returns the score of the newest version of CVSS the details
+// have, preferring CVSS v4.0, then v3.x, then v2.  Scores with neither a
+// vector nor a score, such as the empty scores the API returns, are skipped.
+// It returns nil if the details have no CVSS score.
+func (d ScoreDetails) NewestCVSS() *CVSSScore {
+	if d.CVSSv4 != nil && (d.CVSSv4.VectorString != "" || d.CVSSv4.BaseScore != 0) {
+		s := &CVSSScore{Version: "4.0", Vector: d.CVSSv4.VectorString, Score: d.CVSSv4.BaseScore, Severity: d.CVSSv4.BaseSeverity}
+		if s.Severity == "" {
+			s.Severity = CVSSv4Severity(s.Score)
+		}
+
+		return s
+	}
+
+	if d.CVSSv3 != nil && (d.CVSSv3.VectorString != "" || d.CVSSv3.BaseScore != 0) {
+		s := &CVSSScore{Version: "3.0", Vector: d.CVSSv3.VectorString, Score: d.CVSSv3.BaseScore, Severity: d.CVSSv3.BaseSeverity}
+		if strings.HasPrefix(s.Vector, "CVSS:3.1/") {
+			s.Version = "3.1"
+		}
+
+		if s.Severity == "" {
+			s.Severity = CVSSv3Severity(s.Score)
+		}
+
+		return s
+	}
+
+	if d.CVSSv2 != nil && (d.CVSSv2.VectorString != "" || d.CVSSv2.BaseScore != 0) {
+		return &CVSSScore{Version: "2.0", Vector: d.CVSSv2.VectorString, Score: d.CVSSv2.BaseScore, Severity: CVSSv2Severity(d.CVSSv2.BaseScore)}
+	}
+
+	return nil
+}
+
+// SetScoreFromDetails fills in the score, score system, score version, and
+// vector of the vulnerability from the newest CVSS score of its score
+// details.  They are left as they are if the details have no CVSS score.
+func (v *Vulnerability) SetScoreFromDetails() {
+	s := v.ScoreDetails.NewestCVSS()
+	if s == nil {
+		return
+	}
+
+	v.Score = fmt.Sprintf("%.1f", s.Score)
+	v.ScoreVersion = s.Version
+	v.ScoreSystem = "CVSS"
+	v.Vector = s.Vector
+}
+
+// NewestCVSS returns the score of the newest version of CVSS the
+// vulnerability's score details have.  If they have none, the vulnerability's
+// vector is scored if it is a valid CVSS vector.  It returns nil if the
+// vulnerability has no CVSS score.
+func (v *Vulnerability) NewestCVSS() *CVSSScore {
+	if s := v.ScoreDetails.NewestCVSS(); s != nil {
+		return s
+	}
+
+	if cv, err := ParseCVSSv4Vector(v.Vector); err == nil {
+		return &CVSSScore{Version: "4.0", Vector: cv.String(), Score: cv.BaseScore(), Severity: CVSSv4Severity(cv.BaseScore())}
+	}
+
+	if cv, err := ParseCVSSv3Vector(v.Vector); err == nil {
+		return &CVSSScore{Version: cv.Version, Vector: cv.String(), Score: cv.BaseScore(), Severity: cv.BaseSeverity()}
+	}
+
+	if cv, err := ParseCVSSv2Vector(v.Vector); err == nil {
+		return &CVSSScore{Version: "2.0", Vector: cv.String(), Score: cv.BaseScore(), Severity: cv.BaseSeverity()}
+	}
+
+	return nil
+}
+
 // Reference represents a location where a CVE may have been referenced
 type Reference struct {
 	Type   string `json:"type" xml:"type"`
@@ -124,16 +228,31 @@ type Reference struct {
 }
 
 // NewV3FromShorthand takes a shorthand representation of a CVSSv3 and returns
-// an expanded struct representation
+// an expanded struct representation.  The vector string, base score, and base
+// severity are filled in when the shorthand is a valid vector, which is taken
+// to be CVSS v3.0 if it has no version prefix.
 func NewV3FromShorthand(shorthand string) *CVSSv3 {
 	shorthand = strings.ToUpper(shorthand)
-	shorthand = strings.TrimPrefix(shorthand, "CVSS:3.0/")
-	metrics := strings.Split(shorthand, "/")
+	if !strings.HasPrefix(shorthand, "CVSS:") {
+		shorthand = "CVSS:3.0/" + shorthand
+	}
 
 	sv := &CVSSv3{}
 
+	if v, err := ParseCVSSv3Vector(shorthand); err == nil {
+		sv.VectorString = v.String()
+		sv.BaseScore = v.BaseScore()
+		sv.BaseSeverity = v.BaseSeverity()
+	}
+
+	metrics := strings.Split(shorthand, "/")
+
 	for _, metric := range metrics {
 		parts := strings.Split(metric, ":")
+		if len(parts) != 2 {
+			continue
+		}
+
 		switch parts[0] {
 		case "AV":
 			switch parts[1] {
@@ -176,6 +295,151 @@ func NewV3FromShorthand(shorthand string) *CVSSv3 {
 	return sv
 }
 
+// NewV2FromShorthand takes a shorthand representation of a CVSSv2 and returns
+// an expanded struct representation.  The vector string and base score are
+// filled in when the shorthand is a valid vector.
+func NewV2FromShorthand(shorthand string) *CVSSv2 {
+	shorthand = strings.TrimSuffix(strings.TrimPrefix(shorthand, "("), ")")
+
+	sv := &CVSSv2{}
+
+	if v, err := ParseCVSSv2Vector(shorthand); err == nil {
+		sv.VectorString = v.String()
+		sv.BaseScore = v.BaseScore()
+	}
+
+	metrics := strings.Split(shorthand, "/")
+
+	for _, metric := range metrics {
+		parts := strings.Split(metric, ":")
+		if len(parts) != 2 {
+			continue
+		}
+
+		switch parts[0] {
+		case "AV":
+			switch parts[1] {
+			case "N":
+				sv.AccessVector = "network"
+			case "A":
+				sv.AccessVector = "adjacent_network"
+			case "L":
+				sv.AccessVector = "local"
+			}
+		case "AC":
+			switch parts[1] {
+			case "L":
+				sv.AccessComplexity = "low"
+			case "M":
+				sv.AccessComplexity = "medium"
+			case "H":
+				sv.AccessComplexity = "high"
+			}
+		case "Au":
+			switch parts[1] {
+			case "N":
+				sv.Authentication = "none"
+			case "S":
+				sv.Authentication = "single"
+			case "M":
+				sv.Authentication = "multiple"
+			}
+		case "C":
+			sv.ConfidentialityImpact = parseNonePartialComplete(parts[1])
+		case "I":
+			sv.IntegrityImpact = parseNonePartialComplete(parts[1])
+		case "A":
+			sv.AvailabilityImpact = parseNonePartialComplete(parts[1])
+		}
+	}
+
+	return sv
+}
+
+// NewV4FromShorthand takes a shorthand representation of a CVSSv4 and returns
+// an expanded struct representation.  The vector string, base score, and base
+// severity are filled in when the shorthand is a valid vector, which may leave
+// out the CVSS:4.0/ prefix.
+func NewV4FromShorthand(shorthand string) *CVSSv4 {
+	if !strings.HasPrefix(shorthand, "CVSS:") {
+		shorthand = "CVSS:4.0/" + shorthand
+	}
+
+	sv := &CVSSv4{}
+
+	if v, err := ParseCVSSv4Vector(shorthand); err == nil {
+		sv.VectorString = v.String()
+		sv.BaseScore = v.BaseScore()
+		sv.BaseSeverity = CVSSv4Severity(sv.BaseScore)
+	}
+
+	metrics := strings.Split(shorthand, "/")
+
+	for _, metric := range metrics {
+		parts := strings.Split(metric, ":")
+		if len(parts) != 2 {
+			continue
+		}
+
+		switch parts[0] {
+		case "AV":
+			switch parts[1] {
+			case "N":
+				sv.AttackVector = "network"
+			case "A":
+				sv.AttackVector = "adjacent"
+			case "L":
+				sv.AttackVector = "local"
+			case "P":
+				sv.AttackVector = "physical"
+			}
+		case "AC":
+			sv.AttackComplexity = parseLowHighNone(parts[1])
+		case "AT":
+			switch parts[1] {
+			case "N":
+				sv.AttackRequirements = "none"
+			case "P":
+				sv.AttackRequirements = "present"
+			}
+		case "PR":
+			sv.PrivilegesRequired = parseLowHighNone(parts[1])
+		case "UI":
+			switch parts[1] {
+			case "N":
+				sv.UserInteraction = "none"
+			case "P":
+				sv.UserInteraction = "passive"
+			case "A":
+				sv.UserInteraction = "active"
+			}
+		case "VC":
+			sv.VulnConfidentialityImpact = parseLowHighNone(parts[1])
+		case "VI":
+			sv.VulnIntegrityImpact = parseLowHighNone(parts[1])
+		case "VA":
+			sv.VulnAvailabilityImpact = parseLowHighNone(parts[1])
+		case "SC":
+			sv.SubConfidentialityImpact = parseLowHighNone(parts[1])
+		case "SI":
+			sv.SubIntegrityImpact = parseLowHighNone(parts[1])
+		case "SA":
+			sv.SubAvailabilityImpact = parseLowHighNone(parts[1])
+		case "E":
+			switch parts[1] {
+			case "A":
+				sv.ExploitMaturity = "attacked"
+			case "P":
+				sv.ExploitMaturity = "proof_of_concept"
+			case "U":
+				sv.ExploitMaturity = "unreported"
+			}
+		}
+	}
+
+	return sv
+}
+
 func parseLowHighNone(lhn string) string {
 	switch lhn {
 	case "N":
@@ -188,3 +452,16 @@ func parseLowHighNone(lhn string) string {
 		return lhn
 	}
 }
+
+func parseNonePartialComplete(npc string) string {
+	switch npc {
+	case "N":
+		return "none"
+	case "P":
+		return "partial"
+	case "C":
+		return "complete"
+	default:
+		return npc
+	}
+}
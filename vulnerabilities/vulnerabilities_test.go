@@ -43,6 +43,139 @@ func TestVulnerabilities(t *testing.T) {
 			Expect(cvssv3.Scope).To(Equal("changed"))
 		})
 
+		g.It("should score the vector of a cvss version 3", func() {
+			cvssv3 := NewV3FromShorthand("CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:N/A:N")
+			Expect(cvssv3.VectorString).To(Equal("CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:N/A:N"))
+			Expect(cvssv3.BaseScore).To(Equal(5.5))
+			Expect(cvssv3.BaseSeverity).To(Equal("MEDIUM"))
+
+			cvssv3 = NewV3FromShorthand("av:n/ac:l/pr:l/ui:n/s:c/c:h/i:h/a:h")
+			Expect(cvssv3.VectorString).To(Equal("CVSS:3.0/AV:N/AC:L/PR:L/UI:N/S:C/C:H/I:H/A:H"))
+			Expect(cvssv3.BaseScore).To(Equal(9.9))
+			Expect(cvssv3.AttackVector).To(Equal("network"))
+
+			cvssv3 = NewV3FromShorthand("CVSS:3.1/AV:N")
+			Expect(cvssv3.VectorString).To(Equal(""))
+			Expect(cvssv3.AttackVector).To(Equal("network"))
+		})
+
+		g.It("should return a new cvss version 2", func() {
+			cvssv2 := NewV2FromShorthand("(AV:N/AC:M/Au:S/C:P/I:N/A:C)")
+			Expect(cvssv2.VectorString).To(Equal("AV:N/AC:M/Au:S/C:P/I:N/A:C"))
+			Expect(cvssv2.AccessVector).To(Equal("network"))
+			Expect(cvssv2.AccessComplexity).To(Equal("medium"))
+			Expect(cvssv2.Authentication).To(Equal("single"))
+			Expect(cvssv2.ConfidentialityImpact).To(Equal("partial"))
+			Expect(cvssv2.IntegrityImpact).To(Equal("none"))
+			Expect(cvssv2.AvailabilityImpact).To(Equal("complete"))
+			Expect(cvssv2.BaseScore).To(Equal(7.0))
+		})
+
+		g.It("should return a new cvss version 4", func() {
+			cvssv4 := NewV4FromShorthand("AV:N/AC:L/AT:P/PR:N/UI:A/VC:H/VI:L/VA:N/SC:N/SI:N/SA:N/E:P")
+			Expect(cvssv4.VectorString).To(Equal("CVSS:4.0/AV:N/AC:L/AT:P/PR:N/UI:A/VC:H/VI:L/VA:N/SC:N/SI:N/SA:N/E:P"))
+			Expect(cvssv4.AttackRequirements).To(Equal("present"))
+			Expect(cvssv4.UserInteraction).To(Equal("active"))
+			Expect(cvssv4.VulnConfidentialityImpact).To(Equal("high"))
+			Expect(cvssv4.VulnIntegrityImpact).To(Equal("low"))
+			Expect(cvssv4.SubAvailabilityImpact).To(Equal("none"))
+			Expect(cvssv4.ExploitMaturity).To(Equal("proof_of_concept"))
+			Expect(cvssv4.BaseSeverity).NotTo(BeEmpty())
+		})
+
+		g.It("should pick the newest cvss score", func() {
+			v := &Vulnerability{}
+			Expect(v.NewestCVSS()).To(BeNil())
+
+			v.Vector = "AV:N/AC:L/Au:N/C:P/I:P/A:P"
+			Expect(v.NewestCVSS()).To(Equal(&CVSSScore{Version: "2.0", Vector: "AV:N/AC:L/Au:N/C:P/I:P/A:P", Score: 7.5, Severity: "HIGH"}))
+
+			v.ScoreDetails.CVSSv2 = &CVSSv2{VectorString: "AV:N/AC:M/Au:N/C:N/I:P/A:N", BaseScore: 4.3}
+			Expect(v.NewestCVSS().Score).To(Equal(4.3))
+			Expect(v.NewestCVSS().Severity).To(Equal("MEDIUM"))
+
+			v.ScoreDetails.CVSSv3 = NewV3FromShorthand("CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:N/A:N")
+			Expect(v.NewestCVSS()).To(Equal(&CVSSScore{Version: "3.1", Vector: "CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:N/A:N", Score: 5.5, Severity: "MEDIUM"}))
+
+			v.ScoreDetails.CVSSv4 = NewV4FromShorthand("CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N")
+			Expect(v.NewestCVSS()).To(Equal(&CVSSScore{Version: "4.0", Vector: "CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N", Score: 9.3, Severity: "CRITICAL"}))
+		})
+
+		g.It("should set the score from the score details", func() {
+			v := &Vulnerability{Score: "5.0", ScoreVersion: "2.0"}
+			v.SetScoreFromDetails()
+			Expect(v.Score).To(Equal("5.0"))
+			Expect(v.ScoreSystem).To(Equal(""))
+
+			v.ScoreDetails.CVSSv3 = NewV3FromShorthand("CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:N/A:N")
+			v.SetScoreFromDetails()
+			Expect(v.Score).To(Equal("5.5"))
+			Expect(v.ScoreVersion).To(Equal("3.1"))
+			Expect(v.ScoreSystem).To(Equal("CVSS"))
+			Expect(v.Vector).To(Equal("CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:N/A:N"))
+		})
+
+		g.It("should take the effective score in order of precedence", func() {
+			v := &Vulnerability{}
+			Expect(v.EffectiveScore()).To(Equal(0.0))
+			Expect(v.Severity()).To(Equal(SeverityUnknown))
+
+			v.Score = "9.3"
+			v.ScoreVersion = "2.0"
+			Expect(v.EffectiveScore()).To(Equal(9.3))
+			Expect(v.Severity()).To(Equal(SeverityHigh))
+
+			v.ScoreVersion = "3.1"
+			Expect(v.Severity()).To(Equal(SeverityCritical))
+
+			v.Vector = "CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:N/A:N"
+			Expect(v.EffectiveScore()).To(Equal(5.5))
+			Expect(v.Severity()).To(Equal(SeverityMedium))
+
+			v.ScoreDetails.NPM = &NPM{BaseScore: 2.1}
+			Expect(v.EffectiveScore()).To(Equal(2.1))
+			Expect(v.Severity()).To(Equal(SeverityLow))
+
+			v.ScoreDetails.CVSSv2 = &CVSSv2{}
+			v.ScoreDetails.CVSSv4 = &CVSSv4{}
+			Expect(v.EffectiveScore()).To(Equal(2.1))
+			Expect(v.Severity()).To(Equal(SeverityLow))
+
+			v.ScoreDetails.NPM = &NPM{}
+			Expect(v.EffectiveScore()).To(Equal(5.5))
+			Expect(v.Severity()).To(Equal(SeverityMedium))
+
+			v.ScoreDetails.CVSSv2 = &CVSSv2{VectorString: "AV:N/AC:L/Au:N/C:N/I:N/A:N", BaseScore: 0}
+			Expect(v.EffectiveScore()).To(Equal(0.0))
+			Expect(v.Severity()).To(Equal(SeverityLow))
+
+			v.ScoreDetails.CVSSv3 = &CVSSv3{BaseScore: 7.5, BaseSeverity: "HIGH"}
+			Expect(v.EffectiveScore()).To(Equal(7.5))
+			Expect(v.Severity()).To(Equal(SeverityHigh))
+		})
+
+		g.It("should normalize severities", func() {
+			table := []struct {
+				Name     string
+				Severity Severity
+			}{
+				{"CRITICAL", SeverityCritical},
+				{"Important", SeverityHigh},
+				{" moderate", SeverityMedium},
+				{"Low", SeverityLow},
+				{"NONE", SeverityNone},
+				{"", SeverityUnknown},
+				{"severe", SeverityUnknown},
+			}
+
+			for _, row := range table {
+				Expect(ParseSeverity(row.Name)).To(Equal(row.Severity))
+			}
+
+			Expect(SeverityFromScore(0)).To(Equal(SeverityNone))
+			Expect(SeverityFromScore(9.0)).To(Equal(SeverityCritical))
+		})
+
 		g.It("should parse low high and none shorthands", func() {
 			table := []struct {
 				Shorthand string